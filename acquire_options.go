@@ -0,0 +1,151 @@
+package poolmanager
+
+import "context"
+
+// AcquireOption adalah opsi per pemanggilan untuk mengubah perilaku AcquireInstance
+// tanpa harus menambah method baru atau mengubah konfigurasi pool.
+type AcquireOption func(*acquireOptions)
+
+// acquireOptions menyimpan hasil penerapan AcquireOption untuk satu pemanggilan acquire
+type acquireOptions struct {
+	shardKey string            // Kunci yang digunakan untuk memilih shard
+	priority int               // Prioritas pemanggil, semakin besar semakin didahulukan
+	owner    string            // ID pemilik instance yang dipinjam
+	noCreate bool              // Jangan membuat instance baru jika pool kosong
+	tryOnly  bool              // Jangan menunggu atau melampaui MaxSize, diisi oleh TryAcquireInstance
+	tags     map[string]string // Tag yang dicatat pada metadata instance
+	weight   int64             // Jumlah unit MaxActive yang dipakai oleh peminjaman ini
+	degraded bool              // Instance diserahkan dalam mode degraded, diisi oleh acquire
+	leaseID  string            // ID korelasi peminjaman, dibuat oleh acquire jika tidak diberikan
+
+	reclaimValidator func(PoolAble) bool // Pemeriksa instance yang diambil kembali oleh AcquireBound
+
+	coalesceKey string // Kunci acquire berkunci yang hasilnya dibagi dengan pemanggil lain
+	loadKey     string // Kunci AcquireOrLoad tempat instance disimpan setelah dikembalikan
+
+	ctx       context.Context    // Context pemanggil yang membatasi waktu tunggu, diisi oleh AcquireInstanceContext
+	semaphore *weightedSemaphore // Semaphore tempat unit diambil, diisi oleh AcquireInstance
+}
+
+// newAcquireOptions menerapkan seluruh opsi secara berurutan dan mengembalikan hasilnya.
+func newAcquireOptions(opts []AcquireOption) acquireOptions {
+	o := acquireOptions{weight: 1}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// WithShardKey menetapkan kunci yang digunakan untuk memilih shard pada pool yang di-shard,
+// sehingga permintaan dengan kunci yang sama diarahkan ke shard yang sama.
+func WithShardKey(key string) AcquireOption {
+	return func(o *acquireOptions) {
+		o.shardKey = key
+	}
+}
+
+// WithPriority menetapkan prioritas pemanggil. Prioritas dicatat bersama peminjaman dan
+// digunakan oleh jalur acquire yang menunggu instance tersedia; semakin besar nilainya
+// semakin didahulukan.
+func WithPriority(priority int) AcquireOption {
+	return func(o *acquireOptions) {
+		o.priority = priority
+	}
+}
+
+// WithOwner menetapkan ID pemilik (misalnya request ID atau nama subsistem) untuk instance yang dipinjam.
+func WithOwner(ownerID string) AcquireOption {
+	return func(o *acquireOptions) {
+		o.owner = ownerID
+	}
+}
+
+// WithWeight menetapkan jumlah unit MaxActive yang dipakai oleh peminjaman ini, misalnya
+// saat objek pool mewakili kapasitas berukuran berbeda seperti slot worker atau potongan memori.
+// Bobot kurang dari 1 dianggap 1. Bobot hanya dapat dilacak untuk instance bertipe referensi
+// (pointer, map, slice, atau chan).
+func WithWeight(weight int64) AcquireOption {
+	return func(o *acquireOptions) {
+		if weight < 1 {
+			weight = 1
+		}
+		o.weight = weight
+	}
+}
+
+// WithNoCreate mencegah AcquireInstance membuat instance baru melalui factory.
+// Jika pool kosong, AcquireInstance mengembalikan error yang membungkus ErrNoIdleInstance.
+func WithNoCreate() AcquireOption {
+	return func(o *acquireOptions) {
+		o.noCreate = true
+	}
+}
+
+// WithCoalesceKey menggabungkan acquire yang berjalan bersamaan dengan kunci yang sama pada pool
+// yang sama: hanya satu pemanggil yang menjalankan acquire (termasuk pemanggilan factory), sedangkan
+// pemanggil lain menerima instance yang sama selama instance tersebut masih dipegang. Setiap
+// pemanggil tetap harus memanggil ReleaseInstance; instance kembali ke pool setelah pemegang terakhir
+// melepasnya. Cocok untuk objek yang aman dipakai bersama dan mahal dibuat. Acquire berkunci ditolak
+// pada pool dengan LeaseDuration, karena lease yang berakhir menghancurkan instance yang masih dipakai
+// pemegang lain.
+func WithCoalesceKey(key string) AcquireOption {
+	return func(o *acquireOptions) {
+		o.coalesceKey = key
+	}
+}
+
+// WithLeaseID menetapkan ID korelasi peminjaman, misalnya trace ID request, alih-alih ID yang
+// dibuat PoolManager. ID muncul pada trace, PoolEvent.LeaseID, dan PoolItemMetadata.LeaseID.
+func WithLeaseID(id string) AcquireOption {
+	return func(o *acquireOptions) {
+		o.leaseID = id
+	}
+}
+
+// WithReclaimValidator memeriksa instance AcquireBound yang diambil kembali karena context selesai.
+// Instance yang ditolak (validate mengembalikan false) dihancurkan alih-alih dikembalikan ke pool,
+// misalnya koneksi yang mungkin ditinggalkan di tengah transaksi.
+func WithReclaimValidator(validate func(instance PoolAble) bool) AcquireOption {
+	return func(o *acquireOptions) {
+		o.reclaimValidator = validate
+	}
+}
+
+// withAcquireContext membatasi waktu tunggu acquire dengan context pemanggil
+func withAcquireContext(ctx context.Context) AcquireOption {
+	return func(o *acquireOptions) {
+		o.ctx = ctx
+	}
+}
+
+// withTryAcquire membuat acquire gagal dengan errPoolExhausted alih-alih menunggu unit MaxActive
+// atau membuat instance melampaui MaxSize
+func withTryAcquire() AcquireOption {
+	return func(o *acquireOptions) {
+		o.tryOnly = true
+	}
+}
+
+// WithTags melampirkan tag pada instance yang dipinjam. Tag digabungkan ke metadata instance
+// (PoolItemMetadata.Tag) dan tetap tersimpan setelah instance dikembalikan, sehingga dapat
+// digunakan untuk audit maupun pencarian berdasarkan tag.
+func WithTags(tags map[string]string) AcquireOption {
+	return func(o *acquireOptions) {
+		o.tags = mergeTags(o.tags, tags)
+	}
+}
+
+// mergeTags menggabungkan tag tambahan ke salinan tag lama.
+// Salinan baru selalu dibuat agar map yang sudah terlihat oleh pembaca lain tidak dimodifikasi.
+func mergeTags(existing, extra map[string]string) map[string]string {
+	merged := make(map[string]string, len(existing)+len(extra))
+	for k, v := range existing {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
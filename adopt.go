@@ -0,0 +1,101 @@
+package poolmanager
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// adoptedPool mencatat sync.Pool milik pemanggil yang diadopsi dengan AdoptPool
+type adoptedPool struct {
+	pool     *sync.Pool         // sync.Pool yang masih digunakan oleh call site lama
+	original func() interface{} // New asli, dipulihkan saat pool dihapus
+}
+
+// restore memulihkan New asli dan memindahkan objek idle milik PoolManager ke sync.Pool yang
+// diadopsi, sehingga call site lama tetap berjalan seperti sebelum adopsi.
+func (a *adoptedPool) restore(backend interface{}) {
+	a.pool.New = a.original
+	if pool, ok := backend.(*sync.Pool); ok {
+		for value := pool.Get(); value != nil; value = pool.Get() {
+			a.pool.Put(value)
+		}
+	}
+}
+
+// AdoptPool membawa sync.Pool yang sudah ada ke bawah PoolManager sehingga call site dapat
+// dimigrasikan bertahap. Call site baru memakai AcquireInstance/ReleaseInstance dengan metrik,
+// eviksi, dan introspeksi penuh, sedangkan call site lama yang masih memanggil existing.Get dan
+// existing.Put tetap berjalan:
+//   - New milik existing diganti sehingga Get pada pool kosong lebih dulu mengambil objek idle
+//     milik PoolManager, lalu memanggil New asli melalui PoolManager (tercatat sebagai pembuatan).
+//   - Objek yang dikembalikan dengan existing.Put tetap berada di existing dan tidak terlihat oleh
+//     PoolManager hingga call site tersebut dimigrasikan.
+//
+// existing harus memiliki New yang menghasilkan PoolAble dan tidak boleh di-shard. AdoptPool
+// mengubah existing.New, jadi panggil sebelum pool digunakan oleh goroutine lain. RemovePool
+// memulihkan New asli dan memindahkan objek idle kembali ke existing.
+func (pm *PoolManager) AdoptPool(poolName string, existing *sync.Pool, config PoolConfiguration) (*PoolRef, error) {
+	if existing == nil || existing.New == nil {
+		return nil, NewPoolError(poolName, "adopt", errors.New("adopted sync.Pool must have New"))
+	}
+	if config.ShardingEnabled && config.ShardCount > 1 {
+		return nil, NewPoolError(poolName, "adopt", errors.New("adopted pool cannot be sharded"))
+	}
+	if config.Backend == BackendBounded {
+		return nil, NewPoolError(poolName, "adopt", errors.New("adopted pool cannot use the bounded backend"))
+	}
+
+	// Pastikan New menghasilkan PoolAble; objek uji disimpan sebagai objek idle pertama
+	original := existing.New
+	value := original()
+	probe, ok := value.(PoolAble)
+	if !ok {
+		return nil, NewPoolError(poolName, "adopt", fmt.Errorf("%w: New returns %T, which does not implement PoolAble", ErrTypeMismatch, value))
+	}
+
+	factory := func() PoolAble {
+		instance, _ := original().(PoolAble)
+		return instance
+	}
+	adopted := &adoptedPool{pool: existing, original: original}
+	ref, err := pm.addPool(poolName, factory, config, adopted)
+	if err != nil {
+		return nil, err
+	}
+
+	backend := ref.entry.backend.(*sync.Pool)
+	backend.Put(probe)
+	pm.adjustGauges(poolName, -1, 1, 0)
+	pm.adjustRetainedBytes(poolName, estimateSize(probe))
+
+	existing.New = func() interface{} {
+		return pm.adoptedNew(ref.entry)
+	}
+	pm.logPoolf(InfoLevel, poolName, "Adopted existing sync.Pool as pool: %s", poolName)
+	return ref, nil
+}
+
+// adoptedNew dipanggil oleh sync.Pool yang diadopsi saat call site lama memanggil Get pada pool
+// kosong. Objek idle milik PoolManager diserahkan lebih dulu; jika tidak ada, objek baru dibuat
+// melalui factory agar pembuatan tetap tercatat pada metrik pool.
+func (pm *PoolManager) adoptedNew(entry *poolEntry) interface{} {
+	poolName, conf := entry.name, entry.configuration()
+	if value := entry.backend.(*sync.Pool).Get(); value != nil {
+		// Objek keluar dari pengawasan PoolManager karena call site lama mengembalikannya ke existing
+		if instance, ok := value.(PoolAble); ok {
+			pm.adjustGauges(poolName, -1, -1, 0)
+			pm.adjustRetainedBytes(poolName, -estimateSize(instance))
+			key := metadataKey(poolName, instance)
+			if key != poolName {
+				pm.itemMetadata.Delete(key)
+			}
+			pm.tracef("adopt pool=%s key=%s action=handoff reason=legacy Get on empty sync.Pool", poolName, key)
+		}
+		return value
+	}
+	if instance := pm.construct(poolName, conf, entry.factory); instance != nil {
+		return instance
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package poolmanager
+
+import (
+	"runtime/debug"
+	"sort"
+	"time"
+)
+
+// advisoryLeaseLimit adalah jumlah peminjaman terlama yang disertakan dalam SaturationAdvisory
+const advisoryLeaseLimit = 5
+
+// AdvisoryKind menunjukkan dugaan penyebab saturasi pool
+type AdvisoryKind string
+
+const (
+	// AdvisoryLeak berarti seluruh instance yang dipinjam melebihi ExpectedHoldTime, yang
+	// mengindikasikan kebocoran (release terlupa) atau deadlock pada pemegang instance
+	AdvisoryLeak AdvisoryKind = "leak"
+	// AdvisorySizing berarti pool jenuh meskipun durasi peminjaman wajar, sehingga MaxActive
+	// kemungkinan terlalu kecil untuk beban saat ini
+	AdvisorySizing AdvisoryKind = "sizing"
+)
+
+// HeldLease menjelaskan satu instance yang sedang dipinjam saat advisory dibuat
+type HeldLease struct {
+	Key     string        // Kunci unik instance
+	Owner   string        // ID pemilik yang meminjam instance (kosong jika tidak diatur)
+	HeldFor time.Duration // Lama instance dipinjam
+	Stack   string        // Stack trace saat acquire (hanya jika CaptureAcquireStacks atau LeakDetection diaktifkan)
+}
+
+// SaturationAdvisory dikirim saat pool jenuh dengan antrean waiter yang tidak kosong lebih lama
+// dari SaturationAdvisoryAfter, beserta dugaan penyebab dan peminjaman terlama.
+type SaturationAdvisory struct {
+	PoolName     string        // Nama pool yang jenuh
+	Kind         AdvisoryKind  // Dugaan penyebab saturasi
+	Suggestion   string        // Saran tindak lanjut yang dapat dibaca manusia
+	SaturatedFor time.Duration // Lama antrean waiter tidak kosong
+	Waiters      int           // Jumlah waiter saat advisory dibuat
+	InUse        int64         // Jumlah unit MaxActive yang sedang dipakai
+	ExpectedHold time.Duration // Durasi peminjaman yang dianggap wajar
+	LongestHeld  []HeldLease   // Peminjaman terlama, dari yang paling lama
+}
+
+// checkSaturation memeriksa apakah pool sudah jenuh lebih lama dari SaturationAdvisoryAfter dan,
+// jika ya, mengirim satu advisory untuk episode saturasi tersebut.
+func (pm *PoolManager) checkSaturation(poolName string, conf PoolConfiguration, sem *weightedSemaphore) {
+	saturatedFor, waiters := sem.saturation(time.Now())
+	if waiters == 0 || saturatedFor < conf.SaturationAdvisoryAfter || !sem.markAdvised() {
+		return
+	}
+
+	expected := conf.ExpectedHoldTime
+	if expected <= 0 {
+		expected = conf.SaturationAdvisoryAfter
+	}
+
+	var leases []HeldLease
+	allExceeded := true
+	now := time.Now()
+	pm.checkouts.Range(func(key, value interface{}) bool {
+		if record, ok := value.(*checkoutRecord); ok && record.poolName == poolName {
+			lease := HeldLease{Key: record.key, Owner: record.owner, HeldFor: now.Sub(record.acquiredAt), Stack: record.stack}
+			if lease.HeldFor <= expected {
+				allExceeded = false
+			}
+			leases = append(leases, lease)
+		}
+		return true
+	})
+	sort.Slice(leases, func(i, j int) bool {
+		return leases[i].HeldFor > leases[j].HeldFor
+	})
+	if len(leases) > advisoryLeaseLimit {
+		leases = leases[:advisoryLeaseLimit]
+	}
+
+	advisory := SaturationAdvisory{
+		PoolName:     poolName,
+		Kind:         AdvisorySizing,
+		Suggestion:   "hold times look normal; consider raising MaxActive",
+		SaturatedFor: saturatedFor,
+		Waiters:      waiters,
+		InUse:        sem.InUse(),
+		ExpectedHold: expected,
+		LongestHeld:  leases,
+	}
+	if allExceeded && len(leases) > 0 {
+		advisory.Kind = AdvisoryLeak
+		advisory.Suggestion = "every borrowed instance exceeds the expected hold time; look for a missing release or a deadlock in the holders"
+	}
+
+	pm.logPoolf(WarningLevel, poolName, "Saturation advisory for pool: %s, Kind: %s, saturated for %s with %d waiters, %s",
+		poolName, advisory.Kind, saturatedFor, waiters, advisory.Suggestion)
+	pm.triggerEvent(PoolEvent{Type: EventAdvisory, PoolName: poolName, Advisory: &advisory})
+	if onAdvisory := pm.monitoring().OnAdvisory; onAdvisory != nil {
+		onAdvisory(advisory)
+	}
+}
+
+// captureStack mengembalikan stack trace pemanggil acquire jika CaptureAcquireStacks atau
+// LeakDetection diaktifkan
+func captureStack(conf PoolConfiguration) string {
+	if !conf.CaptureAcquireStacks && !conf.LeakDetection {
+		return ""
+	}
+	return string(debug.Stack())
+}
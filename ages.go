@@ -0,0 +1,124 @@
+package poolmanager
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// AgeBucketBounds adalah batas atas (inklusif) setiap bucket AgeHistogram.
+// Observasi yang melebihi batas terakhir dicatat pada bucket overflow.
+var AgeBucketBounds = [...]time.Duration{
+	time.Second,
+	10 * time.Second,
+	time.Minute,
+	10 * time.Minute,
+	time.Hour,
+	6 * time.Hour,
+	24 * time.Hour,
+}
+
+// AgeHistogram adalah histogram umur objek dengan bucket tetap sesuai AgeBucketBounds.
+// Buckets[i] menghitung objek dengan umur <= AgeBucketBounds[i]; bucket terakhir menghitung objek
+// yang lebih tua dari seluruh batas.
+type AgeHistogram struct {
+	Buckets [len(AgeBucketBounds) + 1]int64 // Jumlah objek per bucket
+	Count   int64                           // Total jumlah objek
+	Sum     time.Duration                   // Total umur seluruh objek
+}
+
+// ageBucket mengembalikan indeks bucket untuk umur tertentu
+func ageBucket(age time.Duration) int {
+	for i, bound := range AgeBucketBounds {
+		if age <= bound {
+			return i
+		}
+	}
+	return len(AgeBucketBounds)
+}
+
+// observe mencatat satu umur ke histogram secara atomik
+func (h *AgeHistogram) observe(age time.Duration) {
+	atomic.AddInt64(&h.Buckets[ageBucket(age)], 1)
+	atomic.AddInt64(&h.Count, 1)
+	atomic.AddInt64((*int64)(&h.Sum), int64(age))
+}
+
+// snapshot membaca histogram secara atomik dan mengembalikan salinannya
+func (h *AgeHistogram) snapshot() AgeHistogram {
+	var snap AgeHistogram
+	for i := range h.Buckets {
+		snap.Buckets[i] = atomic.LoadInt64(&h.Buckets[i])
+	}
+	snap.Count = atomic.LoadInt64(&h.Count)
+	snap.Sum = time.Duration(atomic.LoadInt64((*int64)(&h.Sum)))
+	return snap
+}
+
+// Mean mengembalikan rata-rata umur objek
+func (h AgeHistogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// Quantile mengembalikan perkiraan kuantil q (0..1) berupa batas atas bucket yang memuat
+// kuantil tersebut. Mengembalikan -1 jika kuantil jatuh pada bucket overflow.
+func (h AgeHistogram) Quantile(q float64) time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	target := int64(q * float64(h.Count))
+	if target < 1 {
+		target = 1
+	}
+	var seen int64
+	for i, count := range h.Buckets {
+		seen += count
+		if seen >= target {
+			if i < len(AgeBucketBounds) {
+				return AgeBucketBounds[i]
+			}
+			break
+		}
+	}
+	return -1
+}
+
+// objectAge mengembalikan umur instance sejak dibuat berdasarkan PoolItemMetadata.CreationTime.
+// Instance tanpa metadata sendiri (bukan tipe referensi) tidak memiliki umur yang dapat diukur.
+func (pm *PoolManager) objectAge(poolName string, instance PoolAble) (time.Duration, bool) {
+	key := metadataKey(poolName, instance)
+	if key == poolName {
+		return 0, false
+	}
+	metadataVal, ok := pm.itemMetadata.Load(key)
+	if !ok {
+		return 0, false
+	}
+	return time.Since(metadataVal.(*PoolItemMetadata).CreationTime), true
+}
+
+// observeLifetime mencatat umur instance yang dihancurkan pada ObjectLifetime dan MetricsSink.
+func (pm *PoolManager) observeLifetime(poolName string, instance PoolAble) {
+	lifetime, ok := pm.objectAge(poolName, instance)
+	if !ok {
+		return
+	}
+	if metrics := pm.metricsFor(poolName); metrics != nil {
+		metrics.ObjectLifetime.observe(lifetime)
+	}
+	pm.emitDuration(poolName, SinkObjectLifetime, lifetime)
+}
+
+// GetObjectAges mengembalikan histogram umur objek yang sedang idle dan histogram umur objek saat
+// dihancurkan untuk pool tertentu, misalnya untuk menilai apakah TTL sesuai dengan umur objek
+// yang sebenarnya.
+func (pm *PoolManager) GetObjectAges(poolName string) (idle, lifetime AgeHistogram, err error) {
+	entry, err := pm.lookupEntry(poolName, "ages")
+	if err != nil {
+		return AgeHistogram{}, AgeHistogram{}, err
+	}
+	_, idle = pm.metadataStats(poolName)
+	return idle, entry.metrics.Load().ObjectLifetime.snapshot(), nil
+}
@@ -1,54 +1,58 @@
 package poolmanager
 
 func (pm *PoolManager) autoTunePoolSize() {
-	pm.pools.Range(func(key, value interface{}) bool {
-		poolName, ok := key.(string)
-		if !ok {
-			return true
-		}
-
-		configVal, ok := pm.poolConfig.Load(poolName)
-		if !ok {
-			return true
-		}
-
-		conf, ok := configVal.(PoolConfiguration)
-		if !ok || !conf.AutoTune {
-			return true
-		}
-
-		// Hitung ukuran pool saat ini
-		currentSize := pm.getCurrentPoolSize(poolName, value)
-		if currentSize == 0 {
-			pm.logger.Printf("Skipping auto-tuning for empty pool: %s", poolName)
-			return true
+	pm.forEachEntry(func(entry *poolEntry) bool {
+		if entry.configuration().AutoTune && pm.maintenanceAllowed(entry.name, maintenanceAutoTune) {
+			pm.tunePool(entry)
 		}
+		return true
+	})
+}
 
-		// Tentukan ukuran pool baru berdasarkan faktor auto-tuning
+// tunePool menyesuaikan ukuran satu pool. Ukuran saat ini adalah jumlah objek idle ditambah objek
+// yang sedang dipinjam menurut gauge IdleCount dan InUseCount; ResizePool hanya mengubah objek idle.
+func (pm *PoolManager) tunePool(entry *poolEntry) {
+	poolName, conf := entry.name, entry.configuration()
+	pm.markAutoTuned(poolName)
+
+	// Hitung ukuran pool saat ini
+	idle, inUse := pm.poolSize(poolName)
+	currentSize := idle + inUse
+	if currentSize == 0 {
+		pm.logPoolf(DebugLevel, poolName, "Skipping auto-tuning for empty pool: %s", poolName)
+		return
+	}
+
+	// Tentukan ukuran pool baru berdasarkan ramalan permintaan jika diaktifkan dan riwayatnya
+	// cukup, atau berdasarkan faktor auto-tuning
+	newSize, forecasted := 0, false
+	if conf.AutoTuneForecast > 0 {
+		newSize, forecasted = pm.forecastSize(poolName, conf.AutoTuneForecast)
+	}
+	if !forecasted {
 		var factor float64
 		if conf.AutoTuneDynamicFactor != nil {
 			factor = conf.AutoTuneDynamicFactor(currentSize)
 		} else {
 			factor = conf.AutoTuneFactor
 		}
-
-		// Hitung ukuran pool baru dan batasi sesuai konfigurasi
-		newSize := int(float64(currentSize) * factor)
-		if newSize > conf.MaxSize {
-			newSize = conf.MaxSize
-		} else if newSize < conf.MinSize {
-			newSize = conf.MinSize
-		}
-
-		// Hanya ubah ukuran pool jika berbeda dari ukuran saat ini
-		if newSize != currentSize {
-			pm.ResizePool(poolName, newSize)
-			pm.logger.Printf("Auto-tuned pool %s from %d to new size: %d", poolName, currentSize, newSize)
-			if conf.OnAutoTune != nil {
-				conf.OnAutoTune(poolName, newSize)
-			}
+		newSize = int(float64(currentSize) * factor)
+	}
+
+	// Batasi ukuran pool baru sesuai konfigurasi
+	if newSize > conf.MaxSize {
+		newSize = conf.MaxSize
+	} else if newSize < conf.MinSize {
+		newSize = conf.MinSize
+	}
+
+	// Hanya ubah ukuran pool jika berbeda dari ukuran saat ini
+	if newSize != currentSize {
+		pm.ResizePool(poolName, newSize)
+		pm.logPoolf(InfoLevel, poolName, "Auto-tuned pool %s from %d (%d idle, %d in use) to new size: %d",
+			poolName, currentSize, idle, inUse, newSize)
+		if conf.OnAutoTune != nil {
+			conf.OnAutoTune(poolName, newSize)
 		}
-
-		return true
-	})
+	}
 }
@@ -0,0 +1,85 @@
+package poolmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type tuneTestObject struct{ value int }
+
+func (o *tuneTestObject) Reset() { o.value = 0 }
+
+// newSilentManager membuat PoolManager tanpa log untuk test internal
+func newSilentManager(t *testing.T) *PoolManager {
+	t.Helper()
+	pm := NewPoolManager(PoolConfiguration{})
+	pm.SetMonitoringConfig(MonitoringConfig{})
+	t.Cleanup(func() { _ = pm.Close(context.Background()) })
+	return pm
+}
+
+func TestTunePoolCountsIdleAndInUse(t *testing.T) {
+	pm := newSilentManager(t)
+	var tunedTo int
+	_, err := pm.AddPoolWithOptions("tuned", func() PoolAble { return &tuneTestObject{} },
+		WithBackend(BackendBounded), WithSizeLimit(16), WithMinSize(0), WithMaxSize(16), WithInitialSize(0),
+		WithAutoTune(time.Hour, 2), func(config *PoolConfiguration) {
+			config.OnAutoTune = func(_ string, newSize int) { tunedTo = newSize }
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	held := make([]PoolAble, 3)
+	for i := range held {
+		if held[i], err = pm.AcquireInstance("tuned"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pm.ReleaseInstance("tuned", held[2]); err != nil {
+		t.Fatal(err)
+	}
+	if size := pm.GetPoolSize("tuned"); size != 3 {
+		t.Fatalf("GetPoolSize = %d, want 3 (1 idle, 2 in use)", size)
+	}
+
+	entry, _ := pm.entryFor("tuned")
+	pm.tunePool(entry)
+	if tunedTo != 6 {
+		t.Fatalf("OnAutoTune size = %d, want 6", tunedTo)
+	}
+	if idle, inUse := pm.poolSize("tuned"); idle != 4 || inUse != 2 {
+		t.Fatalf("after tuning idle = %d, in use = %d, want 4 and 2", idle, inUse)
+	}
+	if size := pm.GetPoolSize("tuned"); size != 6 {
+		t.Fatalf("GetPoolSize after tuning = %d, want 6", size)
+	}
+	for _, instance := range held[:2] {
+		if err := pm.ReleaseInstance("tuned", instance); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestResizePoolSplitsAcrossShards(t *testing.T) {
+	pm := newSilentManager(t)
+	_, err := pm.AddPoolWithOptions("sharded", func() PoolAble { return &tuneTestObject{} },
+		WithSharding(2, NewRoundRobinSharding(1)), WithMinSize(0), WithInitialSize(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pm.ResizePool("sharded", 5)
+	if first, second := pm.GetShardSize("sharded", 0), pm.GetShardSize("sharded", 1); first != 3 || second != 2 {
+		t.Fatalf("shard sizes = %d and %d, want 3 and 2", first, second)
+	}
+	if size := pm.GetPoolSize("sharded"); size != 5 {
+		t.Fatalf("GetPoolSize = %d, want 5", size)
+	}
+
+	pm.ResizePool("sharded", 2)
+	if size := pm.GetPoolSize("sharded"); size != 2 {
+		t.Fatalf("GetPoolSize after shrinking = %d, want 2", size)
+	}
+}
@@ -0,0 +1,38 @@
+package poolmanager_test
+
+import (
+	"testing"
+
+	poolmanager "github.com/hibbannn/pool-manager"
+)
+
+// BenchmarkAcquireReleaseParallel mengukur acquire/release bersamaan pada satu pool,
+// jalankan dengan -cpu 1,4,8 untuk melihat perebutan pada gauge dan penghitung metrik.
+//
+// Padding cache line pada gauge PoolMetrics dan ShardMetrics ditolak: belum ada pengukuran
+// multi-core yang menunjukkan perbaikan. Penghitung bergaris di counter.go sudah dipadding.
+// Usulan padding berikutnya harus menyertakan hasil benchmark ini pada mesin multi-core.
+func BenchmarkAcquireReleaseParallel(b *testing.B) {
+	pm := poolmanager.NewPoolManager(poolmanager.PoolConfiguration{})
+	pm.SetMonitoringConfig(poolmanager.MonitoringConfig{})
+	defer pm.Close(b.Context())
+	if _, err := pm.AddPoolWithOptions("bench", func() poolmanager.PoolAble { return &testObject{} }); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			instance, err := pm.AcquireInstance("bench")
+			if err != nil {
+				b.Error(err)
+				return
+			}
+			if err := pm.ReleaseInstance("bench", instance); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
@@ -0,0 +1,148 @@
+package poolmanager
+
+import (
+	"context"
+	"errors"
+)
+
+// errReclaimedRelease menandai ReleaseInstance yang datang setelah instance diambil kembali
+// oleh AcquireBound; pemanggil menerima nil karena peminjamannya sudah selesai
+var errReclaimedRelease = errors.New("instance already reclaimed")
+
+// AcquireBound meminjam instance yang terikat pada ctx: jika ctx selesai sebelum instance
+// dikembalikan, instance diambil kembali secara otomatis seperti ReleaseInstance (atau dihancurkan
+// jika WithReclaimValidator menolaknya). Pemanggil tetap sebaiknya memanggil ReleaseInstance;
+// mana pun yang terjadi lebih dulu menyelesaikan peminjaman, dan ReleaseInstance setelah instance
+// diambil kembali diabaikan. Instance tidak boleh dipakai lagi setelah ctx selesai.
+// Instance harus bertipe referensi agar peminjamannya dapat dilacak, dan WithCoalesceKey tidak
+// dapat digabungkan dengan AcquireBound.
+func (pm *PoolManager) AcquireBound(ctx context.Context, poolName string, opts ...AcquireOption) (PoolAble, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, NewPoolError(poolName, "get", err)
+	}
+	o := newAcquireOptions(opts)
+	if o.coalesceKey != "" {
+		return nil, NewPoolError(poolName, "get", errors.New("AcquireBound cannot be combined with WithCoalesceKey"))
+	}
+	if o.leaseID == "" {
+		// Lease ditetapkan di sini agar peminjaman ini dapat dibedakan dari peminjaman berikutnya
+		o.leaseID = newLeaseID()
+		opts = append(opts, WithLeaseID(o.leaseID))
+	}
+
+	// Waktu tunggu MaxActive juga dibatasi oleh ctx
+	instance, err := pm.AcquireInstanceContext(ctx, poolName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	// Selama failover instance berasal dari pool shadow
+	if entry, ok := pm.entryFor(poolName); ok {
+		poolName = pm.routeRelease(entry, instance).name
+	}
+	original := pm.originalInstance(poolName, instance)
+	record := pm.checkoutFor(poolName, original)
+	if record == nil || record.leaseID != o.leaseID {
+		_ = pm.ReleaseInstance(poolName, instance)
+		return nil, NewPoolError(poolName, "get", errors.New("AcquireBound requires an instance of reference type"))
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		pm.reclaimBound(poolName, original, record, o.reclaimValidator, context.Cause(ctx))
+	})
+	record.stopBound.Store(&stop)
+	return instance, nil
+}
+
+// reclaimBound mengambil kembali instance AcquireBound yang context-nya selesai, jika peminjaman
+// yang sama masih berjalan
+func (pm *PoolManager) reclaimBound(poolName string, instance PoolAble, record *checkoutRecord, validate func(PoolAble) bool, cause error) {
+	if !record.settled.CompareAndSwap(false, true) {
+		return
+	}
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		return
+	}
+	pm.recordMetric(poolName, "reclaim")
+	pm.markReclaimed(poolName, instance, record.leaseID)
+	pm.logPoolf(WarningLevel, poolName, "Reclaimed instance from pool: %s, Key: %s, Lease: %s, context done: %v",
+		poolName, record.key, record.leaseID, cause)
+
+	var err error
+	if validate != nil && !validate(instance) {
+		err = pm.discardInstance(poolName, entry.configuration(), instance, "reclaimed instance failed validation")
+	} else {
+		err = pm.releaseSettled(entry, instance)
+	}
+	if err != nil {
+		pm.handleError(poolName, err)
+	}
+}
+
+// settleRelease memastikan hanya satu pihak yang menyelesaikan peminjaman instance.
+// Mengembalikan errReclaimedRelease jika instance sudah diambil kembali AcquireBound saat ctx
+// selesai, atau ErrDoubleRelease/ErrForeignInstance jika instance tidak sedang dipinjam.
+func (pm *PoolManager) settleRelease(poolName string, conf PoolConfiguration, instance PoolAble) error {
+	record := pm.checkoutFor(poolName, instance)
+	if record == nil {
+		if pm.clearReclaimed(poolName, instance) {
+			return errReclaimedRelease
+		}
+		return pm.verifyUntracked(poolName, conf, instance)
+	}
+	if !record.settled.CompareAndSwap(false, true) {
+		// Tanpa AcquireBound dan lease, hanya release lain yang dapat menyelesaikan peminjaman lebih dulu
+		if record.stopBound.Load() == nil && record.leaseUntil.Load() == 0 {
+			return ErrDoubleRelease
+		}
+		return errReclaimedRelease
+	}
+	if stop := record.stopBound.Load(); stop != nil {
+		(*stop)()
+	}
+	return nil
+}
+
+// verifyUntracked memeriksa instance tanpa catatan peminjaman yang dikembalikan ke pool. Instance
+// yang sudah dikenal pool (memiliki metadata) berarti dikembalikan dua kali, sedangkan instance lain
+// tidak pernah dipinjam dari pool ini. Instance yang tidak dapat dilacak (bukan tipe referensi) dan
+// pool dengan EnableCaching, yang menyerahkan instance cache yang sama ke beberapa peminjam, tidak
+// diperiksa.
+func (pm *PoolManager) verifyUntracked(poolName string, conf PoolConfiguration, instance PoolAble) error {
+	key := instanceKey(poolName, instance)
+	if key == "" || conf.EnableCaching {
+		return nil
+	}
+	if _, ok := pm.itemMetadata.Load(key); ok {
+		return ErrDoubleRelease
+	}
+	return ErrForeignInstance
+}
+
+// markReclaimed menandai instance sebagai sudah diambil kembali agar ReleaseInstance yang
+// terlambat tidak memasukkan instance ke pool untuk kedua kalinya. Penanda dihapus saat instance
+// dipinjam kembali atau saat release terlambat tiba. Pembungkus InstanceInterceptor yang diserahkan
+// ikut ditandai karena catatan pembungkusnya dihapus saat peminjaman diselesaikan.
+func (pm *PoolManager) markReclaimed(poolName string, instance PoolAble, leaseID string) {
+	key := instanceKey(poolName, instance)
+	if key == "" {
+		return
+	}
+	pm.reclaimed.Store(key, leaseID)
+	if record := pm.checkoutFor(poolName, instance); record != nil {
+		if wrapper := record.wrapper.Load(); wrapper != nil {
+			pm.reclaimed.Store(*wrapper, leaseID)
+		}
+	}
+}
+
+// clearReclaimed menghapus penanda instance yang diambil kembali.
+// Mengembalikan true jika penanda ada.
+func (pm *PoolManager) clearReclaimed(poolName string, instance PoolAble) bool {
+	key := instanceKey(poolName, instance)
+	if key == "" {
+		return false
+	}
+	_, ok := pm.reclaimed.LoadAndDelete(key)
+	return ok
+}
@@ -0,0 +1,268 @@
+package poolmanager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Backend adalah jenis penyimpanan objek idle pool
+type Backend int
+
+const (
+	BackendSyncPool Backend = iota // sync.Pool: tanpa batas keras, objek idle dapat dibuang oleh GC
+	BackendBounded                 // Penyimpanan berbatas: SizeLimit/MaxSize menjadi batas keras objek hidup
+)
+
+// String mengembalikan nama backend
+func (b Backend) String() string {
+	switch b {
+	case BackendSyncPool:
+		return "sync.Pool"
+	case BackendBounded:
+		return "bounded"
+	default:
+		return "unknown"
+	}
+}
+
+// errBoundedFull menandakan seluruh slot objek hidup pool berbatas sedang terpakai
+var errBoundedFull = errors.New("bounded pool has reached its live object limit")
+
+// idleStore adalah penyimpanan objek idle pool tanpa sharding: *sync.Pool atau *boundedStore
+type idleStore interface {
+	Get() interface{}
+	Put(x interface{})
+}
+
+// idleStores mengembalikan penyimpanan setiap shard dari backend pool
+func idleStores(backend interface{}) ([]idleStore, bool) {
+	switch p := backend.(type) {
+	case []*sync.Pool:
+		stores := make([]idleStore, len(p))
+		for i, shard := range p {
+			stores[i] = shard
+		}
+		return stores, true
+	case idleStore:
+		return []idleStore{p}, true
+	default:
+		return nil, false
+	}
+}
+
+// boundedLimit mengembalikan batas objek hidup BackendBounded: nilai positif terkecil dari
+// SizeLimit dan MaxSize, atau 0 jika keduanya tidak diatur
+func (config PoolConfiguration) boundedLimit() int {
+	limit := config.SizeLimit
+	if config.MaxSize > 0 && (limit <= 0 || config.MaxSize < limit) {
+		limit = config.MaxSize
+	}
+	if limit < 0 {
+		return 0
+	}
+	return limit
+}
+
+// validateBackend memeriksa kombinasi konfigurasi yang tidak dapat dipakai bersama BackendBounded
+func (config PoolConfiguration) validateBackend() error {
+	if config.Backend != BackendBounded {
+		return nil
+	}
+	switch {
+	case config.boundedLimit() == 0:
+		return errors.New("bounded backend requires SizeLimit or MaxSize")
+	case config.ShardingEnabled && config.ShardCount > 1:
+		return errors.New("bounded backend cannot be sharded")
+	case config.ColdTier != nil:
+		return errors.New("bounded backend cannot be combined with ColdTier")
+	}
+	return nil
+}
+
+// boundedStore adalah penyimpanan idle berbasis channel yang membatasi jumlah objek hidup. Setiap
+// objek hidup, baik idle maupun dipinjam, memegang satu slot dari live; slot dilepas saat objek
+// dihancurkan. Berbeda dengan sync.Pool, objek idle tidak pernah dibuang oleh GC.
+type boundedStore struct {
+	idle chan interface{} // Objek idle
+	live chan struct{}    // Satu token per objek hidup
+}
+
+// newBoundedStore membuat boundedStore dengan batas objek hidup tertentu
+func newBoundedStore(limit int) *boundedStore {
+	return &boundedStore{
+		idle: make(chan interface{}, limit),
+		live: make(chan struct{}, limit),
+	}
+}
+
+// Get mengambil objek idle tanpa menunggu, nil jika penyimpanan kosong
+func (s *boundedStore) Get() interface{} {
+	select {
+	case value := <-s.idle:
+		return value
+	default:
+		return nil
+	}
+}
+
+// Put menyimpan objek idle. Objek yang memegang slot tidak pernah melebihi kapasitas, sehingga
+// objek yang tidak muat (dibuat di luar pencatatan slot) dibuang.
+func (s *boundedStore) Put(x interface{}) {
+	select {
+	case s.idle <- x:
+	default:
+	}
+}
+
+// tryReserve memesan slot objek hidup tanpa menunggu
+func (s *boundedStore) tryReserve() bool {
+	select {
+	case s.live <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release melepas slot objek hidup
+func (s *boundedStore) release() {
+	select {
+	case <-s.live:
+	default:
+	}
+}
+
+// await menunggu hingga objek idle dikembalikan atau slot objek hidup tersedia. Mengembalikan objek
+// idle, atau nil jika slot sudah dipesan untuk objek baru.
+func (s *boundedStore) await(ctx context.Context) (interface{}, error) {
+	select {
+	case value := <-s.idle:
+		return value, nil
+	case s.live <- struct{}{}:
+		return nil, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// reserveLive memesan slot objek hidup sebelum pool berbatas membuat objek baru di luar acquire
+// (pengisian awal, ResizePool, dan pemanasan pool shadow). Selalu berhasil untuk backend lain.
+func reserveLive(backend interface{}) bool {
+	store, ok := backend.(*boundedStore)
+	return !ok || store.tryReserve()
+}
+
+// releaseLive melepas slot objek hidup milik instance yang dihancurkan atau gagal dibuat
+func (pm *PoolManager) releaseLive(poolName string) {
+	if entry, ok := pm.entryFor(poolName); ok {
+		if store, ok := entry.backend.(*boundedStore); ok {
+			store.release()
+		}
+	}
+}
+
+// awaitBounded dipanggil saat pool berbatas kosong dan acquire perlu membuat instance baru.
+// Pemanggil menunggu hingga instance idle dikembalikan atau slot objek hidup tersedia, dibatasi oleh
+// context pemanggil dan umur pool. Instance idle yang diterima dikembalikan; nil berarti slot sudah
+// dipesan untuk instance baru. Dengan noWait, errBoundedFull dikembalikan alih-alih menunggu.
+// Untuk backend lain, awaitBounded langsung mengembalikan nil.
+func (pm *PoolManager) awaitBounded(entry *poolEntry, conf PoolConfiguration, o acquireOptions, noWait bool) (PoolAble, error) {
+	store, ok := entry.backend.(*boundedStore)
+	if !ok || store.tryReserve() {
+		return nil, nil
+	}
+	if noWait {
+		return nil, errBoundedFull
+	}
+
+	poolName, poolCtx := entry.name, entry.state.ctx
+	waitCtx, cancel := mergeWaitContext(poolCtx, o.ctx, conf.WaitTimeout)
+	defer cancel()
+
+	metrics := pm.metricsFor(poolName)
+	pm.emitGauge(poolName, SinkWaiters, float64(atomic.AddInt64(&metrics.Waiters, 1)))
+	defer func() {
+		pm.emitGauge(poolName, SinkWaiters, float64(atomic.AddInt64(&metrics.Waiters, -1)))
+	}()
+	endRegion := startTraceRegion(waitCtx, poolName, TraceRegionAcquireWait)
+	defer endRegion()
+
+	for {
+		value, err := store.await(waitCtx)
+		if err != nil {
+			if poolCtx.Err() != nil {
+				return nil, errors.New(ErrPoolDoesNotExist + poolName)
+			}
+			return nil, waitError(waitCtx, err)
+		}
+		if value == nil {
+			return nil, nil
+		}
+		instance, ok := value.(PoolAble)
+		if !ok {
+			continue
+		}
+		// Instance diterima langsung dari release sehingga tidak lagi ditahan sebagai objek idle
+		pm.adjustRetainedBytes(poolName, -estimateSize(instance))
+		pm.adjustGauges(poolName, -1, -1, 0)
+		if !pm.discardExpired(poolName, conf, instance) && !pm.discardInvalid(poolName, conf, instance) {
+			return instance, nil
+		}
+	}
+}
+
+// reserveLoaded memesan slot objek hidup pool berbatas untuk instance hasil loader AcquireOrLoad.
+// Instance idle biasa yang diterima selama menunggu tidak dapat dipakai untuk kunci loader, sehingga
+// dihancurkan agar slot-nya dapat dipakai.
+func (pm *PoolManager) reserveLoaded(entry *poolEntry, conf PoolConfiguration, o acquireOptions) error {
+	for {
+		instance, err := pm.awaitBounded(entry, conf, o, false)
+		if err != nil || instance == nil {
+			return err
+		}
+		pm.recordMetric(entry.name, "discard")
+		if err := pm.destroyInstance(entry.name, conf, instance); err != nil {
+			pm.handleError(entry.name, err)
+		}
+		pm.itemMetadata.Delete(metadataKey(entry.name, instance))
+	}
+}
+
+// mergeWaitContext menggabungkan context pool dengan context pemanggil sehingga waktu tunggu
+// berhenti saat pool dihapus maupun saat pemanggil membatalkan, dan membatasinya dengan timeout
+// (WaitTimeout pool) jika positif. Tanpa context pemanggil dan timeout, context pool dikembalikan
+// apa adanya.
+func mergeWaitContext(poolCtx, ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	waitCtx, cancel := poolCtx, context.CancelFunc(func() {})
+	if ctx != nil {
+		var cancelCaller context.CancelFunc
+		waitCtx, cancelCaller = context.WithCancel(ctx)
+		stop := context.AfterFunc(poolCtx, cancelCaller)
+		cancel = func() {
+			stop()
+			cancelCaller()
+		}
+	}
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		waitCtx, cancelTimeout = context.WithTimeoutCause(waitCtx, timeout, ErrWaitTimeout)
+		cancelMerged := cancel
+		cancel = func() {
+			cancelTimeout()
+			cancelMerged()
+		}
+	}
+	return waitCtx, cancel
+}
+
+// waitError mengganti error tunggu dengan ErrWaitTimeout jika waktu tunggu dihentikan oleh
+// WaitTimeout pool, bukan oleh context pemanggil
+func waitError(waitCtx context.Context, err error) error {
+	if errors.Is(context.Cause(waitCtx), ErrWaitTimeout) {
+		return ErrWaitTimeout
+	}
+	return err
+}
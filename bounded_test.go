@@ -0,0 +1,213 @@
+package poolmanager
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// addBoundedPool mendaftarkan pool BackendBounded tanpa pengisian awal dan mengembalikan penghitung
+// objek yang dibuat factory
+func addBoundedPool(t *testing.T, pm *PoolManager, opts ...PoolOption) *atomic.Int64 {
+	t.Helper()
+	created := &atomic.Int64{}
+	opts = append([]PoolOption{WithBackend(BackendBounded), WithMinSize(0), WithInitialSize(0)}, opts...)
+	_, err := pm.AddPoolWithOptions("bounded", func() PoolAble {
+		created.Add(1)
+		return &tuneTestObject{}
+	}, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return created
+}
+
+// acquireAll meminjam n instance dari pool berbatas
+func acquireAll(t *testing.T, pm *PoolManager, n int) []PoolAble {
+	t.Helper()
+	held := make([]PoolAble, 0, n)
+	for i := 0; i < n; i++ {
+		instance, err := pm.AcquireInstance("bounded")
+		if err != nil {
+			t.Fatal(err)
+		}
+		held = append(held, instance)
+	}
+	return held
+}
+
+// releaseAll mengembalikan instance yang dipinjam agar Close tidak menunggu
+func releaseAll(t *testing.T, pm *PoolManager, held []PoolAble) {
+	t.Helper()
+	for _, instance := range held {
+		if err := pm.ReleaseInstance("bounded", instance); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// waitWaiters menunggu hingga jumlah waiter pool sama dengan n
+func waitWaiters(t *testing.T, pm *PoolManager, poolName string, n int64) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		waiters, _ := pm.GetWaiterCount(poolName)
+		if waiters == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("waiters = %d, want %d", waiters, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestBoundedStoreCapsLiveObjects(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []PoolOption
+		limit int
+	}{
+		{name: "size limit", opts: []PoolOption{WithSizeLimit(3), WithMaxSize(10)}, limit: 3},
+		{name: "max size below size limit", opts: []PoolOption{WithSizeLimit(10), WithMaxSize(2)}, limit: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newSilentManager(t)
+			created := addBoundedPool(t, pm, tt.opts...)
+			held := acquireAll(t, pm, tt.limit)
+			defer func() { releaseAll(t, pm, held) }()
+
+			instance, ok, err := pm.TryAcquireInstance("bounded")
+			if err != nil || ok || instance != nil {
+				t.Fatalf("TryAcquireInstance at capacity = (%v, %v, %v), want (nil, false, nil)", instance, ok, err)
+			}
+			if n := created.Load(); n != int64(tt.limit) {
+				t.Fatalf("factory called %d times, want %d", n, tt.limit)
+			}
+
+			// Instance yang dihancurkan melepas slot-nya sehingga objek baru dapat dibuat
+			if err := pm.DiscardInstance("bounded", held[0]); err != nil {
+				t.Fatal(err)
+			}
+			instance, ok, err = pm.TryAcquireInstance("bounded")
+			if err != nil || !ok {
+				t.Fatalf("TryAcquireInstance after discard = (%v, %v), want (true, nil)", ok, err)
+			}
+			held[0] = instance
+			if n := created.Load(); n != int64(tt.limit)+1 {
+				t.Fatalf("factory called %d times, want %d", n, tt.limit+1)
+			}
+		})
+	}
+}
+
+func TestBoundedAcquireWaitsForRelease(t *testing.T) {
+	pm := newSilentManager(t)
+	created := addBoundedPool(t, pm, WithSizeLimit(1))
+	held := acquireAll(t, pm, 1)
+
+	acquired := make(chan PoolAble, 1)
+	go func() {
+		instance, err := pm.AcquireInstanceContext(context.Background(), "bounded")
+		if err != nil {
+			t.Error(err)
+		}
+		acquired <- instance
+	}()
+	waitWaiters(t, pm, "bounded", 1)
+
+	if err := pm.ReleaseInstance("bounded", held[0]); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case instance := <-acquired:
+		if instance != held[0] {
+			t.Fatalf("waiter received %p, want released instance %p", instance, held[0])
+		}
+		releaseAll(t, pm, []PoolAble{instance})
+	case <-time.After(time.Second):
+		t.Fatal("waiter not served after release")
+	}
+	if n := created.Load(); n != 1 {
+		t.Fatalf("factory called %d times, want 1", n)
+	}
+	if inUse, _ := pm.GetInUseCount("bounded"); inUse != 0 {
+		t.Fatalf("InUseCount = %d, want 0", inUse)
+	}
+}
+
+func TestBoundedAcquireContextDone(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  func() (context.Context, context.CancelFunc)
+		want error
+	}{
+		{
+			name: "cancelled",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(20*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			want: context.Canceled,
+		},
+		{
+			name: "deadline",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 20*time.Millisecond)
+			},
+			want: context.DeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newSilentManager(t)
+			created := addBoundedPool(t, pm, WithSizeLimit(1))
+			held := acquireAll(t, pm, 1)
+			defer func() { releaseAll(t, pm, held) }()
+
+			ctx, cancel := tt.ctx()
+			defer cancel()
+			instance, err := pm.AcquireInstanceContext(ctx, "bounded")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("AcquireInstanceContext returned %v, want %v", err, tt.want)
+			}
+			if instance != nil {
+				t.Fatalf("AcquireInstanceContext returned instance %v alongside error", instance)
+			}
+			if waiters, _ := pm.GetWaiterCount("bounded"); waiters != 0 {
+				t.Fatalf("waiters after context done = %d, want 0", waiters)
+			}
+			if n := created.Load(); n != 1 {
+				t.Fatalf("factory called %d times, want 1", n)
+			}
+		})
+	}
+}
+
+func TestBoundedBackendValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []PoolOption
+		want string
+	}{
+		{name: "no limit", opts: []PoolOption{WithBackend(BackendBounded), WithSizeLimit(0), WithMaxSize(0)}, want: "requires SizeLimit or MaxSize"},
+		{name: "sharded", opts: []PoolOption{WithBackend(BackendBounded), WithSizeLimit(4), WithSharding(2, nil)}, want: "cannot be sharded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newSilentManager(t)
+			_, err := pm.AddPoolWithOptions("bounded", func() PoolAble { return &tuneTestObject{} }, tt.opts...)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("AddPoolWithOptions returned %v, want error containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package poolmanager
+
+import "errors"
+
+// MarkBroken menandai instance yang sedang dipinjam sebagai rusak, misalnya saat koneksi gagal
+// di tengah penggunaan. Kegagalan dicatat pada TotalBroken dan OnBroken dipanggil segera (misalnya
+// untuk membuka circuit breaker factory milik pemanggil); saat instance dikembalikan dengan
+// ReleaseInstance, instance dihancurkan alih-alih dikembalikan ke pool. Pada pool dengan
+// HealthScoring, MarkBroken menurunkan skor kesehatan instance dan instance baru dihancurkan jika
+// skornya di bawah RetireBelow.
+// Mengembalikan ErrNotCheckedOut jika instance tidak sedang tercatat dipinjam dari pool.
+func (pm *PoolManager) MarkBroken(poolName string, instance PoolAble, cause error) error {
+	conf, err := pm.getPoolConfiguration(poolName)
+	if err != nil {
+		pm.handleError(poolName, err)
+		return err
+	}
+
+	original := pm.originalInstance(poolName, instance)
+	record := pm.checkoutFor(poolName, original)
+	if record == nil {
+		return NewPoolError(poolName, "mark_broken", ErrNotCheckedOut)
+	}
+	if cause == nil {
+		cause = errors.New("instance marked broken")
+	}
+	// Instance yang sudah ditandai tidak dihitung ulang
+	if !record.broken.CompareAndSwap(nil, &cause) {
+		return nil
+	}
+
+	pm.recordMetric(poolName, "broken")
+	if conf.Health != nil {
+		// Dengan HealthScoring, kegagalan menurunkan skor dan instance dipensiunkan berdasarkan skornya
+		pm.adjustHealth(poolName, original, conf.Health.withDefaults().ErrorPenalty)
+	}
+	pm.observeOwnerFailure(poolName, record.owner)
+	pm.logPoolf(WarningLevel, poolName, "Instance marked broken in pool: %s, Key: %s, Lease: %s, Cause: %v", poolName, record.key, record.leaseID, cause)
+	if conf.OnBroken != nil {
+		conf.OnBroken(poolName, original, cause)
+	}
+	return nil
+}
+
+// checkoutFor mengembalikan catatan peminjaman instance tanpa menghapusnya, atau nil jika tidak dipinjam.
+func (pm *PoolManager) checkoutFor(poolName string, instance PoolAble) *checkoutRecord {
+	key := instanceKey(poolName, instance)
+	if key == "" {
+		return nil
+	}
+	if recordVal, ok := pm.checkouts.Load(key); ok {
+		return recordVal.(*checkoutRecord)
+	}
+	return nil
+}
+
+// brokenCause mengembalikan penyebab instance ditandai rusak, atau nil jika instance tidak rusak.
+func (r *checkoutRecord) brokenCause() error {
+	if cause := r.broken.Load(); cause != nil {
+		return *cause
+	}
+	return nil
+}
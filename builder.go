@@ -2,6 +2,7 @@ package poolmanager
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -20,6 +21,7 @@ func NewPoolConfiguration(poolName string) *PoolConfigBuilder {
 		MaxSize:          10,               // Ukuran maksimal pool
 		InitialSize:      1,                // Ukuran awal yang sangat kecil
 		AutoTune:         false,            // Auto-tuning tidak diaktifkan secara default
+		AutoTuneInterval: time.Minute,      // Interval auto-tuning default
 		AutoTuneFactor:   1.0,              // Faktor auto-tuning default
 		EnableCaching:    false,            // Caching tidak diaktifkan secara default
 		CacheMaxSize:     5,                // Ukuran cache minimal
@@ -71,11 +73,76 @@ func (b *PoolConfigBuilder) WithOnPut(onPut func(poolType string)) *PoolConfigBu
 	return b
 }
 
+// WithOnGetInstance menetapkan callback yang dipanggil saat objek diambil dari pool,
+// lengkap dengan kunci metadata dan instance yang diserahkan.
+func (b *PoolConfigBuilder) WithOnGetInstance(onGet func(poolType, key string, instance PoolAble)) *PoolConfigBuilder {
+	b.config.OnGetInstance = onGet
+	return b
+}
+
+// WithOnPutInstance menetapkan callback yang dipanggil saat objek dikembalikan ke pool,
+// lengkap dengan kunci metadata dan instance yang dikembalikan.
+func (b *PoolConfigBuilder) WithOnPutInstance(onPut func(poolType, key string, instance PoolAble)) *PoolConfigBuilder {
+	b.config.OnPutInstance = onPut
+	return b
+}
+
+// WithOnItemLifecycle menetapkan callback yang menerima salinan metadata item (umur, frekuensi,
+// durasi penggunaan) saat item dibuat, diambil, dikembalikan, atau dihancurkan.
+func (b *PoolConfigBuilder) WithOnItemLifecycle(onLifecycle func(event ItemLifecycleEvent)) *PoolConfigBuilder {
+	b.config.OnItemLifecycle = onLifecycle
+	return b
+}
+
 func (b *PoolConfigBuilder) WithOnAutoTune(onAutoTune func(poolType string, newSize int)) *PoolConfigBuilder {
 	b.config.OnAutoTune = onAutoTune
 	return b
 }
 
+// WithOnEvict menetapkan callback yang dipanggil saat objek dihapus dari pool.
+func (b *PoolConfigBuilder) WithOnEvict(onEvict func(poolType string)) *PoolConfigBuilder {
+	b.config.OnEvict = onEvict
+	return b
+}
+
+// WithOnEvictInstance menetapkan callback yang menerima instance yang dieviksi beserta kunci
+// metadata-nya, sehingga koneksi atau file dapat ditutup. Dipanggil setelah OnDestroy.
+func (b *PoolConfigBuilder) WithOnEvictInstance(onEvict func(poolType, key string, instance PoolAble)) *PoolConfigBuilder {
+	b.config.OnEvictInstance = onEvict
+	return b
+}
+
+// WithOnError menetapkan callback yang dipanggil saat terjadi error.
+func (b *PoolConfigBuilder) WithOnError(onError func(poolType string, err error)) *PoolConfigBuilder {
+	b.config.OnError = onError
+	return b
+}
+
+// WithOnDestroy menetapkan callback yang dipanggil saat objek dihancurkan.
+func (b *PoolConfigBuilder) WithOnDestroy(onDestroy func(poolType string, instance PoolAble)) *PoolConfigBuilder {
+	b.config.OnDestroy = onDestroy
+	return b
+}
+
+// WithOnBroken menetapkan callback yang dipanggil saat instance ditandai rusak dengan MarkBroken,
+// misalnya untuk membuka circuit breaker factory ketika sumber daya terus gagal.
+func (b *PoolConfigBuilder) WithOnBroken(onBroken func(poolType string, instance PoolAble, err error)) *PoolConfigBuilder {
+	b.config.OnBroken = onBroken
+	return b
+}
+
+// WithOnShard menetapkan callback yang dipanggil saat shard dipilih untuk sebuah operasi.
+func (b *PoolConfigBuilder) WithOnShard(onShard func(poolType string, shardIndex int)) *PoolConfigBuilder {
+	b.config.OnShard = onShard
+	return b
+}
+
+// WithOnCacheHit menetapkan callback yang dipanggil saat objek ditemukan di cache.
+func (b *PoolConfigBuilder) WithOnCacheHit(onCacheHit func(poolType string)) *PoolConfigBuilder {
+	b.config.OnCacheHit = onCacheHit
+	return b
+}
+
 // WithAutoTune mengaktifkan atau menonaktifkan auto-tuning pada pool.
 func (b *PoolConfigBuilder) WithAutoTune(autoTune bool) *PoolConfigBuilder {
 	b.config.AutoTune = autoTune
@@ -88,6 +155,26 @@ func (b *PoolConfigBuilder) WithAutoTuneFactor(factor float64) *PoolConfigBuilde
 	return b
 }
 
+// WithAutoTuneInterval menetapkan interval waktu untuk menjalankan auto-tuning.
+func (b *PoolConfigBuilder) WithAutoTuneInterval(interval time.Duration) *PoolConfigBuilder {
+	b.config.AutoTuneInterval = interval
+	return b
+}
+
+// WithAutoTuneDynamicFactor menetapkan fungsi yang menghitung faktor auto-tuning berdasarkan ukuran pool saat ini.
+// Jika diatur, fungsi ini menggantikan AutoTuneFactor.
+func (b *PoolConfigBuilder) WithAutoTuneDynamicFactor(dynamicFactor func(currentSize int) float64) *PoolConfigBuilder {
+	b.config.AutoTuneDynamicFactor = dynamicFactor
+	return b
+}
+
+// WithAutoTuneForecast membuat auto-tuning mengubah ukuran pool sesuai ramalan permintaan pada
+// horizon tertentu (lihat Forecast). Faktor auto-tuning tetap dipakai selama riwayat belum cukup.
+func (b *PoolConfigBuilder) WithAutoTuneForecast(horizon time.Duration) *PoolConfigBuilder {
+	b.config.AutoTuneForecast = horizon
+	return b
+}
+
 // WithSharding mengaktifkan atau menonaktifkan sharding.
 func (b *PoolConfigBuilder) WithSharding(enabled bool, shardCount int) *PoolConfigBuilder {
 	b.config.ShardingEnabled = enabled
@@ -95,12 +182,38 @@ func (b *PoolConfigBuilder) WithSharding(enabled bool, shardCount int) *PoolConf
 	return b
 }
 
+// WithShardStrategy menetapkan strategi sharding yang digunakan untuk memilih shard.
+func (b *PoolConfigBuilder) WithShardStrategy(strategy ShardingStrategy) *PoolConfigBuilder {
+	b.config.ShardStrategy = strategy
+	return b
+}
+
+// WithKeyGenerator menetapkan fungsi untuk menghasilkan kunci khusus.
+func (b *PoolConfigBuilder) WithKeyGenerator(keyGenerator func() string) *PoolConfigBuilder {
+	b.config.KeyGenerator = keyGenerator
+	return b
+}
+
 // WithTTL menetapkan Time-to-Live (TTL) untuk kebijakan eviksi pada pool.
 func (b *PoolConfigBuilder) WithTTL(ttl time.Duration) *PoolConfigBuilder {
 	b.config.TTL = ttl
 	return b
 }
 
+// WithMaxIdleTime menetapkan batas waktu idle objek; scheduler eviksi menghancurkan objek
+// yang tidak digunakan lebih lama dari batas ini tanpa perlu kebijakan eviksi tambahan.
+func (b *PoolConfigBuilder) WithMaxIdleTime(maxIdleTime time.Duration) *PoolConfigBuilder {
+	b.config.MaxIdleTime = maxIdleTime
+	return b
+}
+
+// WithMaxLifetime menetapkan batas umur objek sejak dibuat; objek yang lebih tua dari batas ini
+// dihancurkan saat dikembalikan alih-alih masuk kembali ke pool.
+func (b *PoolConfigBuilder) WithMaxLifetime(maxLifetime time.Duration) *PoolConfigBuilder {
+	b.config.MaxLifetime = maxLifetime
+	return b
+}
+
 // WithEnableCaching mengaktifkan atau menonaktifkan caching pada pool.
 func (b *PoolConfigBuilder) WithEnableCaching(enableCaching bool) *PoolConfigBuilder {
 	b.config.EnableCaching = enableCaching
@@ -125,6 +238,189 @@ func (b *PoolConfigBuilder) WithEvictionPolicy(evictionPolicy EvictionPolicy) *P
 	return b
 }
 
+// WithLeakFinalizer mengaktifkan finalizer pada instance yang dipinjam untuk mendeteksi kebocoran,
+// yaitu instance yang di-GC tanpa pernah dikembalikan ke pool. Instance harus pointer ke objek
+// berukuran non-nol; pointer ke tengah alokasi (misalnya &arr[i]) baru terdeteksi setelah seluruh
+// alokasinya tidak terjangkau. Instance lain dilaporkan sebagai dugaan kebocoran setelah LeakWindow
+// (default 10 menit), seperti LeakDetection.
+func (b *PoolConfigBuilder) WithLeakFinalizer(enabled bool) *PoolConfigBuilder {
+	b.config.LeakFinalizer = enabled
+	return b
+}
+
+// WithOnLeak menetapkan callback yang dipanggil saat kebocoran instance terdeteksi.
+func (b *PoolConfigBuilder) WithOnLeak(onLeak func(poolType string, heldFor time.Duration)) *PoolConfigBuilder {
+	b.config.OnLeak = onLeak
+	return b
+}
+
+// WithLeaseDuration mengaktifkan mode lease: setiap peminjaman memiliki batas waktu dan instance
+// yang lease-nya berakhir tanpa diperpanjang dengan RenewLease diambil kembali dan dihancurkan.
+func (b *PoolConfigBuilder) WithLeaseDuration(duration time.Duration) *PoolConfigBuilder {
+	b.config.LeaseDuration = duration
+	return b
+}
+
+// WithOnLeaseExpired menetapkan callback yang dipanggil saat instance diambil kembali karena lease berakhir.
+func (b *PoolConfigBuilder) WithOnLeaseExpired(onExpired func(poolType, leaseID string, heldFor time.Duration)) *PoolConfigBuilder {
+	b.config.OnLeaseExpired = onExpired
+	return b
+}
+
+// WithMaxPooledObjectBytes menetapkan batas ukuran objek yang boleh dikembalikan ke pool.
+// Objek dengan perkiraan ukuran di atas batas ini akan dihancurkan saat dikembalikan
+// agar beberapa objek raksasa tidak mendominasi memori yang ditahan pool.
+func (b *PoolConfigBuilder) WithMaxPooledObjectBytes(maxBytes int64) *PoolConfigBuilder {
+	b.config.MaxPooledObjectBytes = maxBytes
+	return b
+}
+
+// WithMaxActive menetapkan kapasitas unit in-use pool. Setiap acquire memakai satu unit
+// (atau bobot dari WithWeight) dan menunggu jika kapasitas habis.
+func (b *PoolConfigBuilder) WithMaxActive(maxActive int64) *PoolConfigBuilder {
+	b.config.MaxActive = maxActive
+	return b
+}
+
+// WithMaxWaiters menetapkan batas jumlah acquire yang boleh mengantre saat MaxActive habis.
+// Acquire berikutnya langsung ditolak dengan ErrTooManyWaiters.
+func (b *PoolConfigBuilder) WithMaxWaiters(maxWaiters int) *PoolConfigBuilder {
+	b.config.MaxWaiters = maxWaiters
+	return b
+}
+
+// WithWaitTimeout menetapkan batas waktu tunggu acquire saat MaxActive habis atau pool berbatas
+// penuh. Acquire yang melewati batas gagal dengan ErrWaitTimeout.
+func (b *PoolConfigBuilder) WithWaitTimeout(timeout time.Duration) *PoolConfigBuilder {
+	b.config.WaitTimeout = timeout
+	return b
+}
+
+// WithStarvationThreshold menetapkan batas waktu tunggu acquire sebelum peringatan kelaparan dikirim.
+func (b *PoolConfigBuilder) WithStarvationThreshold(threshold time.Duration) *PoolConfigBuilder {
+	b.config.StarvationThreshold = threshold
+	return b
+}
+
+// WithSaturationAdvisory mengirim SaturationAdvisory saat pool jenuh dengan antrean waiter yang
+// tidak kosong lebih lama dari after. Jika seluruh peminjaman melebihi expectedHold, advisory
+// menduga kebocoran atau deadlock; jika tidak, advisory menyarankan penambahan MaxActive.
+func (b *PoolConfigBuilder) WithSaturationAdvisory(after, expectedHold time.Duration) *PoolConfigBuilder {
+	b.config.SaturationAdvisoryAfter = after
+	b.config.ExpectedHoldTime = expectedHold
+	return b
+}
+
+// WithCaptureAcquireStacks menyimpan stack trace setiap acquire sehingga advisory saturasi dapat
+// menunjukkan lokasi pemegang instance. Menambah biaya pada setiap acquire.
+func (b *PoolConfigBuilder) WithCaptureAcquireStacks(enabled bool) *PoolConfigBuilder {
+	b.config.CaptureAcquireStacks = enabled
+	return b
+}
+
+// WithLeakDetection mengaktifkan mode debug deteksi kebocoran: stack trace setiap acquire dicatat
+// dan instance yang tidak dikembalikan dalam LeakWindow, atau masih dipinjam saat Close, dilaporkan
+// beserta lokasi peminjamnya. Menambah biaya pada setiap acquire.
+func (b *PoolConfigBuilder) WithLeakDetection(enabled bool) *PoolConfigBuilder {
+	b.config.LeakDetection = enabled
+	return b
+}
+
+// WithLeakWindow menetapkan lama peminjaman sebelum instance dilaporkan sebagai dugaan kebocoran
+// oleh WithLeakDetection. Nilai 0 berarti instance hanya dilaporkan saat Close.
+func (b *PoolConfigBuilder) WithLeakWindow(window time.Duration) *PoolConfigBuilder {
+	b.config.LeakWindow = window
+	return b
+}
+
+// WithSlowFactoryThreshold menetapkan batas durasi pemanggilan factory sebelum peringatan
+// factory lambat dikirim.
+func (b *PoolConfigBuilder) WithSlowFactoryThreshold(threshold time.Duration) *PoolConfigBuilder {
+	b.config.SlowFactoryThreshold = threshold
+	return b
+}
+
+// WithColdTier mengaktifkan cold tier: objek yang melebihi MaxPooledObjectBytes diserialisasi
+// ke store alih-alih dihancurkan, lalu dihidrasi kembali dengan decode saat pool kosong.
+// Instance harus mengimplementasikan encoding.BinaryMarshaler.
+func (b *PoolConfigBuilder) WithColdTier(store BlobStore, decode func(data []byte) (PoolAble, error)) *PoolConfigBuilder {
+	b.config.ColdTier = &ColdTierConfig{Store: store, Decode: decode}
+	return b
+}
+
+// WithGrowthGuard mengaktifkan batas pertumbuhan pool: total pembuatan maksimal, laju pembuatan
+// yang memicu peringatan, dan opsi mode fail-fast hingga ResetGrowthGuard dipanggil.
+func (b *PoolConfigBuilder) WithGrowthGuard(guard GrowthGuardConfig) *PoolConfigBuilder {
+	b.config.GrowthGuard = &guard
+	return b
+}
+
+// WithServeStale mengizinkan item kedaluwarsa diserahkan sebagai instance degraded saat factory
+// gagal membuat pengganti, untuk sumber daya baca yang lebih baik basi daripada tidak ada.
+func (b *PoolConfigBuilder) WithServeStale(enabled bool) *PoolConfigBuilder {
+	b.config.ServeStale = enabled
+	return b
+}
+
+// WithShutdownTimeout membatasi total durasi hook OnPoolShutdown saat pool dihapus. Hook yang
+// belum selesai saat batas tercapai ditinggalkan dan hook berikutnya dilewati.
+func (b *PoolConfigBuilder) WithShutdownTimeout(timeout time.Duration) *PoolConfigBuilder {
+	b.config.ShutdownTimeout = timeout
+	return b
+}
+
+// WithMaintenance menunda sapuan eviksi terjadwal dan resize oleh auto-tuning hingga pool berada
+// di dalam maintenance window atau bebannya di bawah MaxUtilization.
+func (b *PoolConfigBuilder) WithMaintenance(maintenance MaintenanceConfig) *PoolConfigBuilder {
+	b.config.Maintenance = &maintenance
+	return b
+}
+
+// WithBackend menetapkan jenis penyimpanan objek idle. BackendBounded menjadikan nilai positif terkecil
+// dari SizeLimit dan MaxSize sebagai batas keras jumlah objek hidup, dan acquire menunggu saat batas tercapai.
+func (b *PoolConfigBuilder) WithBackend(backend Backend) *PoolConfigBuilder {
+	b.config.Backend = backend
+	return b
+}
+
+// WithHealthScoring memberi setiap instance skor kesehatan dari hasil validasi, MarkBroken, dan usia.
+// Acquire mengutamakan instance yang lebih sehat dan instance dengan skor rendah dipensiunkan.
+func (b *PoolConfigBuilder) WithHealthScoring(health HealthScoringConfig) *PoolConfigBuilder {
+	b.config.Health = &health
+	return b
+}
+
+// WithLabels menetapkan label statis pool (misalnya service, component, tier) yang diteruskan ke
+// snapshot metrik, CustomMetricsFunc, event, dan log sehingga telemetri dapat dipilah per pemilik.
+func (b *PoolConfigBuilder) WithLabels(labels map[string]string) *PoolConfigBuilder {
+	b.config.Labels = copyLabels(labels)
+	return b
+}
+
+// WithLabel menambahkan satu label statis pada pool.
+func (b *PoolConfigBuilder) WithLabel(key, value string) *PoolConfigBuilder {
+	if b.config.Labels == nil {
+		b.config.Labels = make(map[string]string)
+	}
+	b.config.Labels[key] = value
+	return b
+}
+
+// WithLogger menetapkan tujuan log pool, misalnya NewSlogLogger atau DiscardLogger, yang didahulukan
+// atas MonitoringConfig.Logger dan LogFunc untuk pesan pool tersebut. Konfigurasi yang diberikan ke
+// NewPoolManager menjadikannya MonitoringConfig.Logger PoolManager.
+func (b *PoolConfigBuilder) WithLogger(logger Logger) *PoolConfigBuilder {
+	b.config.Logger = logger
+	return b
+}
+
+// WithInterceptor menambahkan interseptor yang membungkus instance sebelum diserahkan ke pemanggil.
+// Interseptor diterapkan sesuai urutan penambahan dan dilepas dalam urutan terbalik saat release.
+func (b *PoolConfigBuilder) WithInterceptor(interceptor InstanceInterceptor) *PoolConfigBuilder {
+	b.config.Interceptors = append(b.config.Interceptors, interceptor)
+	return b
+}
+
 // Build menghasilkan objek PoolConfiguration berdasarkan konfigurasi yang telah diatur pada builder.
 func (b *PoolConfigBuilder) Build() (PoolConfiguration, error) {
 	if err := b.config.Validate(); err != nil {
@@ -134,24 +430,85 @@ func (b *PoolConfigBuilder) Build() (PoolConfiguration, error) {
 }
 
 // Validate memeriksa apakah konfigurasi pool valid.
+// Semua masalah dikumpulkan menjadi satu error gabungan (errors.Join) yang berisi *ConfigFieldError
+// untuk setiap field, lengkap dengan nama field dan nilai aktualnya.
 func (config *PoolConfiguration) Validate() error {
-	if config.SizeLimit < 0 {
-		return errors.New("SizeLimit must be non-negative")
+	var errs []error
+	check := func(failed bool, field string, value interface{}, reason string) {
+		if failed {
+			errs = append(errs, &ConfigFieldError{Field: field, Value: value, Reason: reason})
+		}
 	}
-	if config.MinSize < 0 || config.MaxSize < 0 {
-		return errors.New("MinSize and MaxSize must be non-negative")
+
+	check(config.SizeLimit < 0, "SizeLimit", config.SizeLimit, "must be non-negative")
+	check(config.MinSize < 0, "MinSize", config.MinSize, "must be non-negative")
+	check(config.MaxSize < 0, "MaxSize", config.MaxSize, "must be non-negative")
+	check(config.MaxSize < config.MinSize, "MaxSize", config.MaxSize,
+		fmt.Sprintf("cannot be less than MinSize (%d)", config.MinSize))
+	check(config.InitialSize < config.MinSize || config.InitialSize > config.MaxSize, "InitialSize", config.InitialSize,
+		fmt.Sprintf("must be between MinSize (%d) and MaxSize (%d)", config.MinSize, config.MaxSize))
+	check(config.ShardingEnabled && config.ShardCount <= 1, "ShardCount", config.ShardCount,
+		"must be greater than 1 if ShardingEnabled is true")
+	check(config.MaxPooledObjectBytes < 0, "MaxPooledObjectBytes", config.MaxPooledObjectBytes, "must be non-negative")
+	check(config.MaxActive < 0, "MaxActive", config.MaxActive, "must be non-negative")
+	check(config.MaxWaiters < 0, "MaxWaiters", config.MaxWaiters, "must be non-negative")
+	check(config.WaitTimeout < 0, "WaitTimeout", config.WaitTimeout, "must be non-negative")
+	check(config.LeaseDuration < 0, "LeaseDuration", config.LeaseDuration, "must be non-negative")
+	check(config.StarvationThreshold < 0, "StarvationThreshold", config.StarvationThreshold, "must be non-negative")
+	check(config.SlowFactoryThreshold < 0, "SlowFactoryThreshold", config.SlowFactoryThreshold, "must be non-negative")
+	check(config.ShutdownTimeout < 0, "ShutdownTimeout", config.ShutdownTimeout, "must be non-negative")
+	if maintenance := config.Maintenance; maintenance != nil {
+		check(maintenance.MaxUtilization < 0 || maintenance.MaxUtilization > 1, "Maintenance.MaxUtilization", maintenance.MaxUtilization, "must be between 0 and 1")
+		check(maintenance.MaxDeferral < 0, "Maintenance.MaxDeferral", maintenance.MaxDeferral, "must be non-negative")
+		for _, window := range maintenance.Windows {
+			check(window.Start < 0 || window.Start >= 24*time.Hour, "Maintenance.Windows.Start", window.Start, "must be within a day")
+			check(window.End < 0 || window.End > 24*time.Hour, "Maintenance.Windows.End", window.End, "must be within a day")
+		}
 	}
-	if config.MaxSize < config.MinSize {
-		return errors.New("MaxSize cannot be less than MinSize")
+	if health := config.Health; health != nil {
+		check(health.ErrorPenalty < 0 || health.ErrorPenalty > 1, "Health.ErrorPenalty", health.ErrorPenalty, "must be between 0 and 1")
+		check(health.Recovery < 0 || health.Recovery > 1, "Health.Recovery", health.Recovery, "must be between 0 and 1")
+		check(health.RetireBelow < 0 || health.RetireBelow > 1, "Health.RetireBelow", health.RetireBelow, "must be between 0 and 1")
+		check(health.PreferAbove < 0 || health.PreferAbove > 1, "Health.PreferAbove", health.PreferAbove, "must be between 0 and 1")
+		check(health.MaxAge < 0, "Health.MaxAge", health.MaxAge, "must be non-negative")
+		check(health.Candidates < 0, "Health.Candidates", health.Candidates, "must be non-negative")
 	}
-	if config.InitialSize < config.MinSize || config.InitialSize > config.MaxSize {
-		return errors.New("InitialSize must be between MinSize and MaxSize")
+	if err := config.validateBackend(); err != nil {
+		check(true, "Backend", config.Backend, err.Error())
 	}
-	if config.ShardingEnabled && config.ShardCount <= 1 {
-		return errors.New("ShardCount must be greater than 1 if ShardingEnabled is true")
+	check(config.SaturationAdvisoryAfter < 0, "SaturationAdvisoryAfter", config.SaturationAdvisoryAfter, "must be non-negative")
+	check(config.ExpectedHoldTime < 0, "ExpectedHoldTime", config.ExpectedHoldTime, "must be non-negative")
+	check(config.LeakWindow < 0, "LeakWindow", config.LeakWindow, "must be non-negative")
+
+	// Pemeriksaan lintas field untuk fitur yang saling bergantung
+	check(config.AutoTune && config.AutoTuneFactor <= 0 && config.AutoTuneDynamicFactor == nil, "AutoTuneFactor",
+		config.AutoTuneFactor, "must be greater than 0 if AutoTune is true")
+	check(config.AutoTune && config.AutoTuneInterval <= 0, "AutoTuneInterval", config.AutoTuneInterval,
+		"must be greater than 0 if AutoTune is true")
+	check(config.EnableCaching && config.CacheMaxSize <= 0, "CacheMaxSize", config.CacheMaxSize,
+		"must be greater than 0 if EnableCaching is true")
+	check(config.AutoTuneForecast < 0, "AutoTuneForecast", config.AutoTuneForecast, "must be non-negative")
+	check(config.TTL < 0, "TTL", config.TTL, "must be non-negative")
+	check(config.MaxIdleTime < 0, "MaxIdleTime", config.MaxIdleTime, "must be non-negative")
+	check(config.MaxLifetime < 0, "MaxLifetime", config.MaxLifetime, "must be non-negative")
+	if config.ColdTier != nil {
+		check(config.ColdTier.Store == nil, "ColdTier.Store", nil, "must be set when cold tier is enabled")
+		check(config.ColdTier.Decode == nil, "ColdTier.Decode", nil, "must be set when cold tier is enabled")
+		check(config.ColdTier.MaxItems < 0, "ColdTier.MaxItems", config.ColdTier.MaxItems, "must be non-negative")
+		check(config.MaxPooledObjectBytes <= 0, "MaxPooledObjectBytes", config.MaxPooledObjectBytes,
+			"must be greater than 0 if ColdTier is set")
 	}
-	if config.AutoTune && config.AutoTuneFactor <= 0 {
-		return errors.New("AutoTuneFactor must be greater than 0")
+	if config.GrowthGuard != nil {
+		check(config.GrowthGuard.MaxCreated < 0, "GrowthGuard.MaxCreated", config.GrowthGuard.MaxCreated, "must be non-negative")
+		check(config.GrowthGuard.MaxCreateRate < 0, "GrowthGuard.MaxCreateRate", config.GrowthGuard.MaxCreateRate, "must be non-negative")
+		check(config.GrowthGuard.RateWindow < 0, "GrowthGuard.RateWindow", config.GrowthGuard.RateWindow, "must be non-negative")
 	}
-	return nil
+	check(config.TTL > 0 && config.EvictionInterval <= 0, "EvictionInterval", config.EvictionInterval,
+		fmt.Sprintf("must be greater than 0 if TTL (%s) is set", config.TTL))
+	check(config.SaturationAdvisoryAfter > 0 && config.MaxActive <= 0, "MaxActive", config.MaxActive,
+		"must be greater than 0 if SaturationAdvisoryAfter is set")
+	check(config.MaxIdleTime > 0 && config.EvictionInterval <= 0, "EvictionInterval", config.EvictionInterval,
+		fmt.Sprintf("must be greater than 0 if MaxIdleTime (%s) is set", config.MaxIdleTime))
+
+	return errors.Join(errs...)
 }
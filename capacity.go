@@ -0,0 +1,247 @@
+package poolmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	capacityBucketWidth = time.Minute // Lebar setiap bucket pencatatan kapasitas
+	capacityBucketCount = 24 * 60     // Jumlah bucket yang disimpan (24 jam)
+)
+
+// capacityBucket menyimpan observasi kapasitas pool selama satu menit
+type capacityBucket struct {
+	minute    int64         // Menit Unix yang diwakili bucket
+	peakInUse int64         // Jumlah objek dipinjam tertinggi
+	acquires  int64         // Jumlah acquire
+	misses    int64         // Acquire yang membutuhkan pemanggilan factory
+	waits     int64         // Acquire yang harus mengantre karena MaxActive
+	waitTotal time.Duration // Total waktu tunggu
+	maxWait   time.Duration // Waktu tunggu terlama
+}
+
+// capacityRecorder menyimpan bucket kapasitas per menit untuk satu pool dalam ring buffer
+type capacityRecorder struct {
+	mu      sync.Mutex
+	buckets [capacityBucketCount]capacityBucket
+}
+
+// bucket mengembalikan bucket untuk waktu tertentu, mengosongkannya jika berisi menit lama.
+// Pemanggil harus memegang mu.
+func (r *capacityRecorder) bucket(now time.Time) *capacityBucket {
+	minute := now.Unix() / int64(capacityBucketWidth/time.Second)
+	b := &r.buckets[minute%capacityBucketCount]
+	if b.minute != minute {
+		*b = capacityBucket{minute: minute}
+	}
+	return b
+}
+
+// observe memperbarui bucket menit saat ini
+func (r *capacityRecorder) observe(update func(b *capacityBucket)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	update(r.bucket(time.Now()))
+}
+
+// collect mengembalikan salinan bucket yang berada di dalam window
+func (r *capacityRecorder) collect(now time.Time, window time.Duration) []capacityBucket {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := now.Unix() / int64(capacityBucketWidth/time.Second)
+	oldest := current - int64((window+capacityBucketWidth-1)/capacityBucketWidth) + 1
+	var buckets []capacityBucket
+	for _, b := range r.buckets {
+		if b.minute >= oldest && b.minute <= current && b.acquires > 0 {
+			buckets = append(buckets, b)
+		}
+	}
+	return buckets
+}
+
+// capacityRecorderFor mengambil pencatat kapasitas pool, membuatnya jika belum ada
+func (pm *PoolManager) capacityRecorderFor(poolName string) *capacityRecorder {
+	recorderVal, _ := pm.capacity.LoadOrStore(poolName, &capacityRecorder{})
+	return recorderVal.(*capacityRecorder)
+}
+
+// observeAcquireCapacity mencatat acquire beserta jumlah objek dipinjam setelahnya
+func (pm *PoolManager) observeAcquireCapacity(poolName string, inUse int64) {
+	pm.capacityRecorderFor(poolName).observe(func(b *capacityBucket) {
+		b.acquires++
+		if inUse > b.peakInUse {
+			b.peakInUse = inUse
+		}
+	})
+}
+
+// observeMissCapacity mencatat acquire yang membutuhkan pemanggilan factory
+func (pm *PoolManager) observeMissCapacity(poolName string) {
+	pm.capacityRecorderFor(poolName).observe(func(b *capacityBucket) {
+		b.misses++
+	})
+}
+
+// observeWaitCapacity mencatat waktu tunggu acquire karena MaxActive
+func (pm *PoolManager) observeWaitCapacity(poolName string, waited time.Duration) {
+	pm.capacityRecorderFor(poolName).observe(func(b *capacityBucket) {
+		b.waits++
+		b.waitTotal += waited
+		if waited > b.maxWait {
+			b.maxWait = waited
+		}
+	})
+}
+
+// PoolCapacity berisi ringkasan kapasitas dan rekomendasi ukuran untuk satu pool
+type PoolCapacity struct {
+	Name              string        `json:"name"`
+	PeakInUse         int64         `json:"peak_in_use"`          // Jumlah objek dipinjam tertinggi dalam window
+	TypicalInUse      int64         `json:"typical_in_use"`       // Median puncak per menit dalam window
+	Acquires          int64         `json:"acquires"`             // Jumlah acquire dalam window
+	Misses            int64         `json:"misses"`               // Acquire yang membutuhkan pemanggilan factory
+	MissRate          float64       `json:"miss_rate"`            // Rasio Misses terhadap Acquires
+	Waits             int64         `json:"waits"`                // Acquire yang mengantre karena MaxActive
+	MeanWait          time.Duration `json:"mean_wait"`            // Rata-rata waktu tunggu
+	MaxWait           time.Duration `json:"max_wait"`             // Waktu tunggu terlama
+	RetainedBytes     int64         `json:"retained_bytes"`       // Perkiraan byte yang ditahan objek idle saat ini
+	BytesPerInstance  int64         `json:"bytes_per_instance"`   // Perkiraan byte per objek (0 jika tidak diketahui)
+	CurrentMinSize    int           `json:"current_min_size"`     // MinSize yang dikonfigurasi
+	CurrentMaxSize    int           `json:"current_max_size"`     // MaxSize yang dikonfigurasi
+	CurrentInitial    int           `json:"current_initial_size"` // InitialSize yang dikonfigurasi
+	RecommendedMin    int           `json:"recommended_min_size"`
+	RecommendedMax    int           `json:"recommended_max_size"`
+	RecommendedInit   int           `json:"recommended_initial_size"`
+	RecommendedMemory int64         `json:"recommended_memory_bytes"` // Perkiraan memori pada RecommendedMax
+	Notes             []string      `json:"notes,omitempty"`
+}
+
+// CapacityReport adalah laporan perencanaan kapasitas untuk seluruh pool
+type CapacityReport struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	Window      time.Duration  `json:"window"`
+	Pools       []PoolCapacity `json:"pools"`
+}
+
+// CapacityReport merangkum puncak konkurensi, waktu tunggu, tingkat miss, dan memori setiap pool
+// selama window terakhir (dibulatkan ke menit, maksimal 24 jam), lalu merekomendasikan nilai
+// MinSize, MaxSize, dan InitialSize. MaxSize diberi ruang 25% di atas puncak yang teramati.
+func (pm *PoolManager) CapacityReport(window time.Duration) CapacityReport {
+	if window <= 0 || window > capacityBucketCount*capacityBucketWidth {
+		window = capacityBucketCount * capacityBucketWidth
+	}
+	report := CapacityReport{GeneratedAt: time.Now(), Window: window}
+	for _, name := range pm.ListPools() {
+		report.Pools = append(report.Pools, pm.poolCapacity(name, report.GeneratedAt, window))
+	}
+	return report
+}
+
+// poolCapacity menyusun PoolCapacity untuk satu pool
+func (pm *PoolManager) poolCapacity(poolName string, now time.Time, window time.Duration) PoolCapacity {
+	conf, _ := pm.getPoolConfiguration(poolName)
+	capacity := PoolCapacity{
+		Name:           poolName,
+		CurrentMinSize: conf.MinSize,
+		CurrentMaxSize: conf.MaxSize,
+		CurrentInitial: conf.InitialSize,
+	}
+
+	if entry, ok := pm.entryFor(poolName); ok {
+		snap := entry.metrics.Load().snapshot()
+		capacity.RetainedBytes = snap.RetainedBytes
+		if snap.IdleCount > 0 {
+			capacity.BytesPerInstance = snap.RetainedBytes / snap.IdleCount
+		}
+	}
+
+	var buckets []capacityBucket
+	if recorderVal, ok := pm.capacity.Load(poolName); ok {
+		buckets = recorderVal.(*capacityRecorder).collect(now, window)
+	}
+	if len(buckets) == 0 {
+		capacity.RecommendedMin = conf.MinSize
+		capacity.RecommendedMax = conf.MaxSize
+		capacity.RecommendedInit = conf.InitialSize
+		capacity.RecommendedMemory = int64(conf.MaxSize) * capacity.BytesPerInstance
+		capacity.Notes = append(capacity.Notes, "no traffic observed in window, keeping current sizes")
+		return capacity
+	}
+
+	peaks := make([]int64, 0, len(buckets))
+	for _, b := range buckets {
+		capacity.Acquires += b.acquires
+		capacity.Misses += b.misses
+		capacity.Waits += b.waits
+		capacity.MeanWait += b.waitTotal
+		if b.maxWait > capacity.MaxWait {
+			capacity.MaxWait = b.maxWait
+		}
+		if b.peakInUse > capacity.PeakInUse {
+			capacity.PeakInUse = b.peakInUse
+		}
+		peaks = append(peaks, b.peakInUse)
+	}
+	if capacity.Waits > 0 {
+		capacity.MeanWait /= time.Duration(capacity.Waits)
+	}
+	capacity.MissRate = float64(capacity.Misses) / float64(capacity.Acquires)
+	sort.Slice(peaks, func(i, j int) bool { return peaks[i] < peaks[j] })
+	capacity.TypicalInUse = peaks[len(peaks)/2]
+
+	capacity.RecommendedMin = int(capacity.TypicalInUse)
+	capacity.RecommendedMax = int(math.Ceil(float64(capacity.PeakInUse) * 1.25))
+	if capacity.RecommendedMax < 1 {
+		capacity.RecommendedMax = 1
+	}
+	capacity.RecommendedInit = capacity.RecommendedMin
+	capacity.RecommendedMemory = int64(capacity.RecommendedMax) * capacity.BytesPerInstance
+
+	if capacity.Waits > 0 && conf.MaxActive > 0 {
+		capacity.Notes = append(capacity.Notes, fmt.Sprintf("%d acquire(s) waited up to %s for MaxActive %d",
+			capacity.Waits, capacity.MaxWait, conf.MaxActive))
+	}
+	if capacity.MissRate > 0.1 {
+		capacity.Notes = append(capacity.Notes, fmt.Sprintf("miss rate %.1f%% is high, raise MinSize/InitialSize to keep instances warm",
+			capacity.MissRate*100))
+	}
+	if int(capacity.PeakInUse) > conf.MaxSize {
+		capacity.Notes = append(capacity.Notes, fmt.Sprintf("peak in-use %d exceeds MaxSize %d", capacity.PeakInUse, conf.MaxSize))
+	}
+	return capacity
+}
+
+// Write menulis laporan dalam format teks (DumpText) atau JSON (DumpJSON)
+func (r CapacityReport) Write(w io.Writer, format DumpFormat) error {
+	if format == DumpJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Capacity report at %s (window %s)\n\n", r.GeneratedAt.Format(time.RFC3339), r.Window)
+	for _, pool := range r.Pools {
+		fmt.Fprintf(tw, "Pool %s\n", pool.Name)
+		fmt.Fprintf(tw, "  in-use peak/typical:\t%d/%d\n", pool.PeakInUse, pool.TypicalInUse)
+		fmt.Fprintf(tw, "  acquires/misses:\t%d/%d (%.1f%%)\n", pool.Acquires, pool.Misses, pool.MissRate*100)
+		fmt.Fprintf(tw, "  waits mean/max:\t%d %s/%s\n", pool.Waits, pool.MeanWait, pool.MaxWait)
+		fmt.Fprintf(tw, "  retained/per instance:\t%d/%d bytes\n", pool.RetainedBytes, pool.BytesPerInstance)
+		fmt.Fprintf(tw, "  min/max/initial:\t%d/%d/%d -> %d/%d/%d\n",
+			pool.CurrentMinSize, pool.CurrentMaxSize, pool.CurrentInitial,
+			pool.RecommendedMin, pool.RecommendedMax, pool.RecommendedInit)
+		fmt.Fprintf(tw, "  memory at max:\t%d bytes\n", pool.RecommendedMemory)
+		for _, note := range pool.Notes {
+			fmt.Fprintf(tw, "  note:\t%s\n", note)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
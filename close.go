@@ -0,0 +1,90 @@
+package poolmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// closeCheckInterval adalah interval pemeriksaan instance yang masih dipinjam selama Close menunggu
+const closeCheckInterval = 10 * time.Millisecond
+
+// Close menutup PoolManager secara graceful. Acquire dan AddPool berikutnya ditolak dengan
+// ErrManagerClosed, lalu Close menunggu hingga seluruh instance yang dipinjam dikembalikan atau ctx
+// selesai. Setelah itu seluruh tugas latar belakang dihentikan dan setiap pool dihapus seperti
+// RemovePool, sehingga objek idle dihancurkan melalui OnDestroy (dan Close jika objek
+// mengimplementasikan io.Closer). Jika ctx selesai lebih dulu, pool tetap dihapus dan error yang
+// dikembalikan membungkus ctx.Err() beserta jumlah instance yang belum dikembalikan; instance
+// tersebut tidak dihancurkan. Pemanggilan Close berikutnya tidak melakukan apa pun.
+func (pm *PoolManager) Close(ctx context.Context) error {
+	if !pm.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	pm.logf(InfoLevel, "Closing PoolManager")
+
+	// Hentikan auto-tuning global; tugas latar belakang per pool dihentikan oleh RemovePool
+	pm.autoTuneMu.Lock()
+	if pm.autoTuneCancel != nil {
+		pm.autoTuneCancel()
+		pm.autoTuneCancel = nil
+	}
+	pm.autoTuneMu.Unlock()
+
+	var errs []error
+	if outstanding, err := pm.awaitReturned(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("closing with %d instance(s) still checked out: %w", outstanding, err))
+		pm.reportOutstandingLeaks()
+	}
+	if err := pm.Clear(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := errors.Join(errs...); err != nil {
+		pm.logf(WarningLevel, "PoolManager closed with errors: %v", err)
+		return err
+	}
+	pm.logf(InfoLevel, "PoolManager closed")
+	return nil
+}
+
+// Closed melaporkan apakah Close sudah dipanggil
+func (pm *PoolManager) Closed() bool {
+	return pm.closed.Load()
+}
+
+// checkOpen mengembalikan ErrManagerClosed yang dibungkus PoolError jika PoolManager sudah ditutup
+func (pm *PoolManager) checkOpen(poolName, op string) error {
+	if pm.closed.Load() {
+		return NewPoolError(poolName, op, ErrManagerClosed)
+	}
+	return nil
+}
+
+// awaitReturned menunggu hingga tidak ada instance yang dipinjam dari pool mana pun atau ctx selesai.
+// Mengembalikan jumlah instance yang masih dipinjam beserta ctx.Err() jika ctx selesai lebih dulu.
+func (pm *PoolManager) awaitReturned(ctx context.Context) (int64, error) {
+	ticker := time.NewTicker(closeCheckInterval)
+	defer ticker.Stop()
+	for {
+		outstanding := pm.inUseTotal()
+		if outstanding <= 0 {
+			return 0, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return outstanding, ctx.Err()
+		}
+	}
+}
+
+// inUseTotal menjumlahkan gauge in-use seluruh pool
+func (pm *PoolManager) inUseTotal() int64 {
+	var total int64
+	pm.forEachEntry(func(entry *poolEntry) bool {
+		total += atomic.LoadInt64(&entry.metrics.Load().InUseCount)
+		return true
+	})
+	return total
+}
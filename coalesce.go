@@ -0,0 +1,125 @@
+package poolmanager
+
+import "errors"
+
+// errLeasedCoalesce dikembalikan saat acquire berkunci dijalankan pada pool dengan LeaseDuration.
+// Instance yang lease-nya berakhir dihancurkan, sedangkan pemegang bersama lainnya masih memakainya.
+var errLeasedCoalesce = errors.New("LeaseDuration cannot be combined with WithCoalesceKey or AcquireOrLoad")
+
+// coalescedCall adalah satu acquire berkunci yang hasilnya dibagi ke seluruh pemanggil dengan
+// pool dan kunci yang sama. Seluruh field selain done dilindungi pm.coalesceMu.
+type coalescedCall struct {
+	done     chan struct{} // Ditutup setelah acquire pemimpin selesai
+	key      string        // Kunci call pada pm.coalesced
+	handle   string        // instanceKey instance yang dibagikan (kosong jika tidak dapat dilacak)
+	instance PoolAble      // Instance yang diserahkan ke seluruh pemanggil
+	err      error         // Error acquire pemimpin
+	refs     int           // Jumlah pemanggil yang masih memegang instance
+}
+
+// coalesceKey membentuk kunci call. Nama pool tidak boleh mengandung '#', sehingga kunci
+// dari pool yang berbeda tidak dapat bertabrakan.
+func coalesceKey(poolName, key string) string {
+	return poolName + "#" + key
+}
+
+// acquireCoalesced menjalankan acquire berkunci. Pemanggil pertama menjalankan acquire, sedangkan
+// pemanggil lain dengan kunci yang sama menunggu dan menerima instance yang sama selama instance
+// tersebut masih dipegang. Instance baru kembali ke pool setelah seluruh pemegangnya memanggil
+// ReleaseInstance.
+func (pm *PoolManager) acquireCoalesced(entry *poolEntry, o acquireOptions, key string, acquire func(acquireOptions) (PoolAble, error)) (PoolAble, error) {
+
+	pm.coalesceMu.Lock()
+	if call, ok := pm.coalesced[key]; ok {
+		call.refs++
+		pm.coalesceMu.Unlock()
+
+		<-call.done
+		if call.err != nil {
+			return nil, call.err
+		}
+		if call.handle == "" {
+			// Instance yang tidak dapat dilacak tidak dapat dibagi dengan akuntansi release yang benar
+			return acquire(o)
+		}
+		pm.joinCoalesced(entry, call, o)
+		return call.instance, nil
+	}
+	if pm.coalesced == nil {
+		pm.coalesced = make(map[string]*coalescedCall)
+		pm.coalescedHeld = make(map[string]*coalescedCall)
+	}
+	call := &coalescedCall{done: make(chan struct{}), key: key, refs: 1}
+	pm.coalesced[key] = call
+	pm.coalesceMu.Unlock()
+
+	instance, err := acquire(o)
+
+	pm.coalesceMu.Lock()
+	call.instance, call.err = instance, err
+	if err == nil {
+		call.handle = instanceKey(entry.name, instance)
+	}
+	if err != nil || call.handle == "" {
+		delete(pm.coalesced, key)
+	} else {
+		pm.coalescedHeld[call.handle] = call
+	}
+	pm.coalesceMu.Unlock()
+	close(call.done)
+	return instance, err
+}
+
+// joinCoalesced mencatat peminjaman pemanggil yang berbagi instance dengan pemimpin. Peminjaman
+// bersama dihitung sebagai cache hit sehingga CurrentUsage tetap seimbang saat setiap pemegang
+// mengembalikan instance.
+func (pm *PoolManager) joinCoalesced(entry *poolEntry, call *coalescedCall, o acquireOptions) {
+	poolName, conf := entry.name, entry.configuration()
+	original := pm.originalInstance(poolName, call.instance)
+	// Seluruh pemegang instance bersama berbagi lease milik pemimpin
+	if record := pm.checkoutFor(poolName, original); record != nil {
+		o.leaseID = record.leaseID
+	}
+
+	pm.updateMetadata(poolName, original, "Active", o)
+	pm.observeOwnerAcquire(poolName, o.owner)
+	pm.recordMetric(poolName, "coalesced")
+	pm.adjustGauges(poolName, -1, 0, 1)
+	pm.triggerCallback(conf.OnGet, poolName)
+	pm.triggerInstanceCallback(conf.OnGetInstance, poolName, original)
+	pm.observeOperation(PoolEvent{Type: EventAcquire, PoolName: poolName, Item: original, LeaseID: o.leaseID},
+		"acquire pool=%s key=%s lease=%s source=coalesced", poolName, metadataKey(poolName, original), o.leaseID)
+}
+
+// releaseShared mengurangi jumlah pemegang instance yang dibagi oleh acquire berkunci.
+// Mengembalikan true jika masih ada pemegang lain sehingga instance belum boleh kembali ke pool;
+// pemegang terakhir mengembalikan false dan melanjutkan release seperti biasa.
+func (pm *PoolManager) releaseShared(poolName string, instance PoolAble) bool {
+	handle := instanceKey(poolName, instance)
+	if handle == "" {
+		return false
+	}
+
+	pm.coalesceMu.Lock()
+	call, ok := pm.coalescedHeld[handle]
+	if !ok {
+		pm.coalesceMu.Unlock()
+		return false
+	}
+	call.refs--
+	if call.refs <= 0 {
+		delete(pm.coalescedHeld, handle)
+		if pm.coalesced[call.key] == call {
+			delete(pm.coalesced, call.key)
+		}
+		pm.coalesceMu.Unlock()
+		return false
+	}
+	pm.coalesceMu.Unlock()
+
+	// Pemegang yang bukan terakhir hanya menyelesaikan bagiannya dari peminjaman bersama
+	pm.recordMetric(poolName, "put")
+	pm.adjustGauges(poolName, -1, 0, -1)
+	pm.tracef("release pool=%s key=%s lease=%s action=shared reason=other holders remain", poolName, handle, pm.lastLeaseID(poolName, instance))
+	return true
+}
@@ -0,0 +1,78 @@
+package poolmanager_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	poolmanager "github.com/hibbannn/pool-manager"
+	"github.com/hibbannn/pool-manager/poolmanagertest"
+)
+
+type testObject struct {
+	value int
+}
+
+func (o *testObject) Reset() {
+	o.value = 0
+}
+
+// newTestManager membuat PoolManager senyap untuk test
+func newTestManager(t *testing.T) *poolmanager.PoolManager {
+	t.Helper()
+	pm := poolmanager.NewPoolManager(poolmanager.PoolConfiguration{})
+	pm.SetMonitoringConfig(poolmanager.MonitoringConfig{})
+	t.Cleanup(func() {
+		if err := pm.Close(context.Background()); err != nil {
+			t.Errorf("close: %v", err)
+		}
+	})
+	return pm
+}
+
+// inspectMetadata membaca metadata seluruh item pool seperti eviksi dan endpoint admin hingga stop ditutup
+func inspectMetadata(pm *poolmanager.PoolManager, poolName string, stop <-chan struct{}) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			pm.CompactMetadata(poolName)
+			for _, metadata := range pm.ExportMetadata(poolName) {
+				_ = metadata.LeaseID
+			}
+		}
+	}()
+	return done
+}
+
+func TestCoalescedAcquireConcurrent(t *testing.T) {
+	pm := newTestManager(t)
+	_, err := pm.AddPoolWithOptions("coalesce", func() poolmanager.PoolAble { return &testObject{} },
+		poolmanager.WithMaxIdleTime(time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := make(chan struct{})
+	inspected := inspectMetadata(pm, "coalesce", stop)
+	result := poolmanagertest.RunWorkload(pm, "coalesce", poolmanagertest.Workload{
+		Goroutines: 8,
+		Iterations: 200,
+		Options:    []poolmanager.AcquireOption{poolmanager.WithCoalesceKey("shared"), poolmanager.WithOwner("worker")},
+	})
+	close(stop)
+	<-inspected
+
+	if len(result.Errors) > 0 {
+		t.Fatalf("workload errors: %v", result.Errors)
+	}
+	if result.Released != result.Acquired {
+		t.Fatalf("released %d of %d instances", result.Released, result.Acquired)
+	}
+	poolmanagertest.AssertAllReleased(t, pm, "coalesce")
+}
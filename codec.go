@@ -0,0 +1,192 @@
+package poolmanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// Nama pool yang didaftarkan oleh RegisterCodecPools, di dalam namespace yang diberikan
+const (
+	JSONEncoderPool   = "json-encoder"   // Pool JSONEncoder
+	JSONDecoderPool   = "json-decoder"   // Pool JSONDecoder
+	StringBuilderPool = "string-builder" // Pool StringBuilder
+)
+
+// JSONEncoder adalah json.Encoder yang menulis ke buffer milik objek pool. Hasil encode dibaca
+// dari Bytes atau ditulis ke tujuan akhir dengan WriteTo sebelum encoder dikembalikan ke pool.
+type JSONEncoder struct {
+	*json.Encoder
+	buf bytes.Buffer
+}
+
+// newJSONEncoder membuat JSONEncoder dengan encoder yang terikat pada buffer-nya sendiri
+func newJSONEncoder() *JSONEncoder {
+	e := &JSONEncoder{}
+	e.Encoder = json.NewEncoder(&e.buf)
+	return e
+}
+
+// Bytes mengembalikan hasil encode. Slice hanya valid hingga encoder dikembalikan ke pool.
+func (e *JSONEncoder) Bytes() []byte {
+	return e.buf.Bytes()
+}
+
+// WriteTo menulis hasil encode ke w dan mengosongkan buffer
+func (e *JSONEncoder) WriteTo(w io.Writer) (int64, error) {
+	return e.buf.WriteTo(w)
+}
+
+// Reset mengosongkan buffer dan mengembalikan pengaturan indentasi serta escape HTML ke default
+func (e *JSONEncoder) Reset() {
+	e.buf.Reset()
+	e.SetIndent("", "")
+	e.SetEscapeHTML(true)
+}
+
+// SizeBytes melaporkan kapasitas buffer untuk perhitungan RetainedBytes dan MaxPooledObjectBytes
+func (e *JSONEncoder) SizeBytes() int64 {
+	return int64(e.buf.Cap())
+}
+
+// JSONDecoder adalah json.Decoder yang membaca dari buffer milik objek pool. Input dimuat dengan
+// Load atau ReadFrom lalu didekode dengan Decode. json.Decoder tidak dapat diarahkan ke sumber baru,
+// sehingga decoder dibuat ulang saat Reset sementara buffer input dipakai ulang.
+type JSONDecoder struct {
+	*json.Decoder
+	buf bytes.Buffer
+}
+
+// newJSONDecoder membuat JSONDecoder dengan decoder yang terikat pada buffer-nya sendiri
+func newJSONDecoder() *JSONDecoder {
+	d := &JSONDecoder{}
+	d.Decoder = json.NewDecoder(&d.buf)
+	return d
+}
+
+// Load menambahkan data ke input decoder
+func (d *JSONDecoder) Load(data []byte) {
+	d.buf.Write(data)
+}
+
+// ReadFrom membaca seluruh isi r ke input decoder
+func (d *JSONDecoder) ReadFrom(r io.Reader) (int64, error) {
+	return d.buf.ReadFrom(r)
+}
+
+// Reset mengosongkan input dan membuat decoder baru tanpa opsi UseNumber atau DisallowUnknownFields
+func (d *JSONDecoder) Reset() {
+	d.buf.Reset()
+	d.Decoder = json.NewDecoder(&d.buf)
+}
+
+// SizeBytes melaporkan kapasitas buffer input untuk perhitungan RetainedBytes dan MaxPooledObjectBytes
+func (d *JSONDecoder) SizeBytes() int64 {
+	return int64(d.buf.Cap())
+}
+
+// StringBuilder adalah pengganti strings.Builder yang dapat dipakai ulang. strings.Builder tidak
+// aman di-pool karena String berbagi memori dengan builder, sehingga StringBuilder menyalin isi
+// buffer saat String dipanggil dan mempertahankan buffer-nya untuk peminjaman berikutnya.
+type StringBuilder struct {
+	buf []byte
+}
+
+// Len mengembalikan jumlah byte yang sudah ditulis
+func (b *StringBuilder) Len() int { return len(b.buf) }
+
+// Cap mengembalikan kapasitas buffer
+func (b *StringBuilder) Cap() int { return cap(b.buf) }
+
+// Grow memastikan buffer dapat menampung n byte tambahan tanpa alokasi ulang
+func (b *StringBuilder) Grow(n int) {
+	if n < 0 {
+		panic("poolmanager.StringBuilder.Grow: negative count")
+	}
+	if cap(b.buf)-len(b.buf) < n {
+		grown := make([]byte, len(b.buf), 2*cap(b.buf)+n)
+		copy(grown, b.buf)
+		b.buf = grown
+	}
+}
+
+// Write menambahkan p ke buffer
+func (b *StringBuilder) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+// WriteString menambahkan s ke buffer
+func (b *StringBuilder) WriteString(s string) (int, error) {
+	b.buf = append(b.buf, s...)
+	return len(s), nil
+}
+
+// WriteByte menambahkan satu byte ke buffer
+func (b *StringBuilder) WriteByte(c byte) error {
+	b.buf = append(b.buf, c)
+	return nil
+}
+
+// WriteRune menambahkan representasi UTF-8 dari r ke buffer
+func (b *StringBuilder) WriteRune(r rune) (int, error) {
+	n := len(b.buf)
+	b.buf = utf8.AppendRune(b.buf, r)
+	return len(b.buf) - n, nil
+}
+
+// String mengembalikan salinan isi buffer yang tetap valid setelah builder dikembalikan ke pool
+func (b *StringBuilder) String() string {
+	return string(b.buf)
+}
+
+// Reset mengosongkan buffer tanpa melepas kapasitasnya
+func (b *StringBuilder) Reset() {
+	b.buf = b.buf[:0]
+}
+
+// SizeBytes melaporkan kapasitas buffer untuk perhitungan RetainedBytes dan MaxPooledObjectBytes
+func (b *StringBuilder) SizeBytes() int64 {
+	return int64(cap(b.buf))
+}
+
+// CodecPoolName mengembalikan nama lengkap pool codec di dalam namespace, misalnya
+// CodecPoolName("api", JSONEncoderPool) menjadi "api/json-encoder"
+func CodecPoolName(namespace, pool string) string {
+	if namespace == "" {
+		return pool
+	}
+	return namespace + PoolNameSeparator + pool
+}
+
+// RegisterCodecPools mendaftarkan pool JSONEncoder, JSONDecoder, dan StringBuilder dalam satu
+// panggilan, masing-masing dengan konfigurasi config. Nama pool dibentuk dengan CodecPoolName;
+// namespace kosong mendaftarkan pool tanpa namespace. Disarankan mengatur MaxPooledObjectBytes
+// agar buffer yang membesar karena satu payload besar tidak ditahan selamanya.
+// Jika salah satu pool gagal didaftarkan, pool yang sudah terdaftar dihapus kembali.
+func (pm *PoolManager) RegisterCodecPools(namespace string, config PoolConfiguration) error {
+	factories := []struct {
+		name    string
+		factory func() PoolAble
+	}{
+		{JSONEncoderPool, func() PoolAble { return newJSONEncoder() }},
+		{JSONDecoderPool, func() PoolAble { return newJSONDecoder() }},
+		{StringBuilderPool, func() PoolAble { return &StringBuilder{} }},
+	}
+
+	var registered []string
+	for _, f := range factories {
+		name := CodecPoolName(namespace, f.name)
+		if _, err := pm.AddPool(name, f.factory, config); err != nil {
+			errs := []error{err}
+			for _, done := range registered {
+				errs = append(errs, pm.RemovePool(done))
+			}
+			return errors.Join(errs...)
+		}
+		registered = append(registered, name)
+	}
+	return nil
+}
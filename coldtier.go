@@ -0,0 +1,184 @@
+package poolmanager
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+)
+
+// BlobStore adalah penyimpanan byte untuk cold tier. Implementasi harus aman dipakai
+// oleh beberapa goroutine secara bersamaan.
+type BlobStore interface {
+	// Put menyimpan data dengan kunci tertentu
+	Put(key string, data []byte) error
+	// Get mengambil data berdasarkan kunci
+	Get(key string) ([]byte, error)
+	// Delete menghapus data berdasarkan kunci
+	Delete(key string) error
+}
+
+// ColdTierConfig mengaktifkan cold tier untuk pool yang menyimpan objek besar yang dapat diserialisasi.
+// Objek yang akan dibuang karena melebihi MaxPooledObjectBytes diserialisasi dengan
+// encoding.BinaryMarshaler ke Store, lalu dihidrasi kembali dengan Decode saat pool kosong.
+// Cold tier menukar latensi acquire dengan jejak heap yang lebih kecil.
+type ColdTierConfig struct {
+	Store    BlobStore                           // Penyimpanan untuk objek yang diserialisasi
+	Decode   func(data []byte) (PoolAble, error) // Membentuk kembali instance dari data yang diserialisasi
+	MaxItems int                                 // Batas jumlah objek di cold tier (0 = tanpa batas)
+}
+
+// FileBlobStore adalah BlobStore yang menyimpan setiap objek sebagai file di dalam Dir.
+type FileBlobStore struct {
+	Dir string // Direktori tempat file disimpan
+}
+
+// NewFileBlobStore membuat FileBlobStore dan memastikan direktorinya ada
+func NewFileBlobStore(dir string) (*FileBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileBlobStore{Dir: dir}, nil
+}
+
+// path mengubah kunci menjadi path file yang aman
+func (s *FileBlobStore) path(key string) string {
+	return filepath.Join(s.Dir, url.PathEscape(key))
+}
+
+// Put menyimpan data sebagai file
+func (s *FileBlobStore) Put(key string, data []byte) error {
+	return os.WriteFile(s.path(key), data, 0o600)
+}
+
+// Get membaca data dari file
+func (s *FileBlobStore) Get(key string) ([]byte, error) {
+	return os.ReadFile(s.path(key))
+}
+
+// Delete menghapus file, file yang sudah tidak ada tidak dianggap error
+func (s *FileBlobStore) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// coldTier menyimpan kunci objek pool yang sedang berada di cold tier
+type coldTier struct {
+	mu   sync.Mutex
+	keys []string
+	seq  uint64
+}
+
+// coldTierFor mengambil status cold tier pool, membuatnya jika belum ada
+func (pm *PoolManager) coldTierFor(poolName string) *coldTier {
+	tierVal, _ := pm.coldTiers.LoadOrStore(poolName, &coldTier{})
+	return tierVal.(*coldTier)
+}
+
+// spillToCold menyerialisasi instance ke cold tier pool. Mengembalikan false jika cold tier
+// tidak aktif, instance tidak dapat diserialisasi, atau cold tier sudah penuh.
+func (pm *PoolManager) spillToCold(poolName string, conf PoolConfiguration, instance PoolAble) bool {
+	if conf.ColdTier == nil || conf.ColdTier.Store == nil || conf.ColdTier.Decode == nil {
+		return false
+	}
+	marshaler, ok := instance.(encoding.BinaryMarshaler)
+	if !ok {
+		return false
+	}
+
+	tier := pm.coldTierFor(poolName)
+	tier.mu.Lock()
+	defer tier.mu.Unlock()
+	if conf.ColdTier.MaxItems > 0 && len(tier.keys) >= conf.ColdTier.MaxItems {
+		return false
+	}
+
+	data, err := marshaler.MarshalBinary()
+	if err != nil {
+		pm.handleError(poolName, NewPoolError(poolName, "spill", err))
+		return false
+	}
+	tier.seq++
+	key := fmt.Sprintf("%s-%d", poolName, tier.seq)
+	if err := conf.ColdTier.Store.Put(key, data); err != nil {
+		pm.handleError(poolName, NewPoolError(poolName, "spill", err))
+		return false
+	}
+	tier.keys = append(tier.keys, key)
+
+	atomic.AddInt64(&pm.metricsFor(poolName).ColdCount, 1)
+	pm.recordMetric(poolName, "spill")
+	return true
+}
+
+// rehydrateFromCold mengambil objek terakhir yang masuk ke cold tier dan membentuknya kembali.
+// Mengembalikan nil jika cold tier kosong atau objek gagal dihidrasi.
+func (pm *PoolManager) rehydrateFromCold(poolName string, conf PoolConfiguration) PoolAble {
+	if conf.ColdTier == nil || conf.ColdTier.Store == nil || conf.ColdTier.Decode == nil {
+		return nil
+	}
+	tierVal, ok := pm.coldTiers.Load(poolName)
+	if !ok {
+		return nil
+	}
+	tier := tierVal.(*coldTier)
+
+	for {
+		tier.mu.Lock()
+		if len(tier.keys) == 0 {
+			tier.mu.Unlock()
+			return nil
+		}
+		key := tier.keys[len(tier.keys)-1]
+		tier.keys = tier.keys[:len(tier.keys)-1]
+		tier.mu.Unlock()
+		atomic.AddInt64(&pm.metricsFor(poolName).ColdCount, -1)
+
+		data, err := conf.ColdTier.Store.Get(key)
+		if err == nil {
+			err = conf.ColdTier.Store.Delete(key)
+		}
+		var instance PoolAble
+		if err == nil {
+			instance, err = conf.ColdTier.Decode(data)
+		}
+		if err != nil || instance == nil {
+			// Objek yang rusak dibuang, coba objek berikutnya
+			pm.handleError(poolName, NewPoolError(poolName, "rehydrate", errors.Join(err, errors.New("cannot rehydrate key: "+key))))
+			continue
+		}
+
+		atomic.AddInt64(&pm.metricsFor(poolName).TotalRehydrates, 1)
+		pm.tracef("rehydrate pool=%s key=%s bytes=%d", poolName, key, len(data))
+		return instance
+	}
+}
+
+// purgeColdTier menghapus seluruh objek pool dari cold tier
+func (pm *PoolManager) purgeColdTier(poolName string, conf PoolConfiguration) error {
+	tierVal, ok := pm.coldTiers.LoadAndDelete(poolName)
+	if !ok || conf.ColdTier == nil || conf.ColdTier.Store == nil {
+		return nil
+	}
+	tier := tierVal.(*coldTier)
+	tier.mu.Lock()
+	defer tier.mu.Unlock()
+
+	var errs []error
+	for _, key := range tier.keys {
+		if err := conf.ColdTier.Store.Delete(key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	tier.keys = nil
+	if err := errors.Join(errs...); err != nil {
+		return NewPoolError(poolName, "purge", err)
+	}
+	return nil
+}
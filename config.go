@@ -7,32 +7,70 @@ import "time"
 // Konfigurasi ini memungkinkan penyesuaian perilaku pool, termasuk pengaturan cache dan kebijakan eviksi.
 // PoolConfiguration digunakan untuk mengatur konfigurasi pool, termasuk jenis key dan pemrosesannya
 type PoolConfiguration struct {
-	Name                  string                                   // Nama pool
-	SizeLimit             int                                      // Batas maksimum jumlah objek dalam pool
-	MinSize               int                                      // Batas minimum jumlah objek dalam pool
-	MaxSize               int                                      // Batas maksimum ukuran pool saat auto-tuning
-	InitialSize           int                                      // Ukuran awal pool ketika diinisialisasi
-	AutoTune              bool                                     // Menentukan apakah auto-tuning diaktifkan atau tidak
-	AutoTuneInterval      time.Duration                            // Interval waktu untuk menjalankan auto-tuning
-	AutoTuneFactor        float64                                  // Faktor peningkatan ukuran saat auto-tuning diaktifkan
-	AutoTuneDynamicFactor func(currentSize int) float64            // Fungsi dinamis untuk faktor auto-tuning
-	EnableCaching         bool                                     // Menentukan apakah caching diaktifkan
-	CacheMaxSize          int                                      // Batas maksimum jumlah objek dalam cache
-	ShardingEnabled       bool                                     // Menentukan apakah sharding diaktifkan
-	ShardCount            int                                      // Jumlah shard yang digunakan untuk sharding
-	ShardStrategy         ShardingStrategy                         // Strategi sharding yang digunakan
-	TTL                   time.Duration                            // Time-to-live untuk kebijakan eviksi pada objek yang tidak digunakan
-	Eviction              EvictionPolicy                           // Kebijakan eviksi untuk menghapus objek dari pool
-	EvictionInterval      time.Duration                            // Interval waktu untuk menjalankan eviksi
-	KeyGenerator          func() string                            // Fungsi untuk menghasilkan kunci khusus
-	OnGet                 func(poolType string)                    // Callback yang dipanggil saat objek diambil dari pool
-	OnPut                 func(poolType string)                    // Callback yang dipanggil saat objek dikembalikan ke pool
-	OnEvict               func(poolType string)                    // Callback yang dipanggil saat objek dihapus dari pool
-	OnAutoTune            func(poolType string, newSize int)       // Callback yang dipanggil saat auto-tuning terjadi
-	OnCreate              func(poolType string, instance PoolAble) // Callback yang dipanggil saat objek dibuat
-	OnDestroy             func(poolType string, instance PoolAble) // Callback yang dipanggil saat objek dihancurkan
-	OnReset               func(poolType string, instance PoolAble) // Callback yang dipanggil saat objek direset
-	OnShard               func(poolType string, shardIndex int)    // Callback yang dipanggil saat sharding terjadi
-	OnCacheHit            func(poolType string)                    // Callback yang dipanggil saat objek ditemukan
-	OnError               func(poolType string, err error)         // Callback yang dipanggil saat terjadi error
+	Name                    string                                                // Nama pool
+	SizeLimit               int                                                   // Batas maksimum jumlah objek dalam pool
+	Backend                 Backend                                               // Jenis penyimpanan objek idle; BackendBounded menjadikan SizeLimit/MaxSize batas keras
+	MinSize                 int                                                   // Batas minimum jumlah objek dalam pool
+	MaxSize                 int                                                   // Batas maksimum ukuran pool saat auto-tuning
+	InitialSize             int                                                   // Ukuran awal pool ketika diinisialisasi
+	AutoTune                bool                                                  // Menentukan apakah auto-tuning diaktifkan atau tidak
+	AutoTuneInterval        time.Duration                                         // Interval waktu untuk menjalankan auto-tuning
+	AutoTuneFactor          float64                                               // Faktor peningkatan ukuran saat auto-tuning diaktifkan
+	AutoTuneDynamicFactor   func(currentSize int) float64                         // Fungsi dinamis untuk faktor auto-tuning
+	AutoTuneForecast        time.Duration                                         // Horizon ramalan permintaan yang dipakai auto-tuning alih-alih faktor (0 = tidak aktif)
+	EnableCaching           bool                                                  // Menentukan apakah caching diaktifkan
+	CacheMaxSize            int                                                   // Batas maksimum jumlah objek dalam cache
+	ShardingEnabled         bool                                                  // Menentukan apakah sharding diaktifkan
+	ShardCount              int                                                   // Jumlah shard yang digunakan untuk sharding
+	ShardStrategy           ShardingStrategy                                      // Strategi sharding yang digunakan
+	TTL                     time.Duration                                         // Time-to-live untuk kebijakan eviksi pada objek yang tidak digunakan
+	MaxIdleTime             time.Duration                                         // Objek idle yang tidak digunakan lebih lama dari batas ini dihancurkan oleh scheduler eviksi (0 = tanpa batas)
+	MaxLifetime             time.Duration                                         // Objek yang umurnya sejak dibuat melebihi batas ini dihancurkan saat dikembalikan (0 = tanpa batas)
+	Eviction                EvictionPolicy                                        // Kebijakan eviksi untuk menghapus objek dari pool
+	EvictionInterval        time.Duration                                         // Interval waktu untuk menjalankan eviksi
+	KeyGenerator            func() string                                         // Fungsi untuk menghasilkan kunci khusus
+	OnGet                   func(poolType string)                                 // Callback yang dipanggil saat objek diambil dari pool
+	OnPut                   func(poolType string)                                 // Callback yang dipanggil saat objek dikembalikan ke pool
+	OnGetInstance           func(poolType, key string, instance PoolAble)         // Seperti OnGet, namun menerima instance beserta kunci metadata-nya
+	OnPutInstance           func(poolType, key string, instance PoolAble)         // Seperti OnPut, namun menerima instance beserta kunci metadata-nya
+	OnItemLifecycle         func(event ItemLifecycleEvent)                        // Callback dengan salinan metadata item saat item dibuat, diambil, dikembalikan, atau dihancurkan
+	OnEvict                 func(poolType string)                                 // Callback yang dipanggil saat objek dihapus dari pool
+	OnEvictInstance         func(poolType, key string, instance PoolAble)         // Seperti OnEvict, namun menerima instance yang dieviksi beserta kunci metadata-nya
+	OnAutoTune              func(poolType string, newSize int)                    // Callback yang dipanggil saat auto-tuning terjadi
+	OnCreate                func(poolType string, instance PoolAble)              // Callback yang dipanggil saat objek dibuat
+	OnDestroy               func(poolType string, instance PoolAble)              // Callback yang dipanggil saat objek dihancurkan
+	OnReset                 func(poolType string, instance PoolAble)              // Callback yang dipanggil saat objek direset
+	OnShard                 func(poolType string, shardIndex int)                 // Callback yang dipanggil saat sharding terjadi
+	OnCacheHit              func(poolType string)                                 // Callback yang dipanggil saat objek ditemukan
+	OnError                 func(poolType string, err error)                      // Callback yang dipanggil saat terjadi error
+	LeakFinalizer           bool                                                  // Mendeteksi instance yang di-GC saat dipinjam; instance harus pointer ke objek berukuran non-nol
+	OnLeak                  func(poolType string, heldFor time.Duration)          // Callback yang dipanggil saat instance bocor terdeteksi
+	LeaseDuration           time.Duration                                         // Lama lease setiap peminjaman; instance yang lease-nya berakhir diambil kembali (0 = tanpa lease)
+	OnLeaseExpired          func(poolType, leaseID string, heldFor time.Duration) // Callback yang dipanggil saat instance diambil kembali karena lease berakhir
+	OnBroken                func(poolType string, instance PoolAble, err error)   // Callback yang dipanggil saat instance ditandai rusak dengan MarkBroken
+	MaxPooledObjectBytes    int64                                                 // Objek yang lebih besar dari batas ini dihancurkan saat dikembalikan (0 = tanpa batas)
+	MaxActive               int64                                                 // Kapasitas unit in-use; acquire menunggu jika kapasitas habis (0 = tanpa batas)
+	MaxWaiters              int                                                   // Batas jumlah acquire yang boleh mengantre saat MaxActive habis (0 = tanpa batas)
+	WaitTimeout             time.Duration                                         // Batas waktu tunggu acquire saat MaxActive habis atau pool berbatas penuh (0 = tanpa batas)
+	StarvationThreshold     time.Duration                                         // Waktu tunggu acquire yang dilaporkan sebagai kelaparan (0 = tanpa peringatan)
+	SaturationAdvisoryAfter time.Duration                                         // Lama pool jenuh dengan waiter sebelum advisory kebocoran/ukuran dikirim (0 = tanpa advisory)
+	ExpectedHoldTime        time.Duration                                         // Durasi peminjaman yang dianggap wajar untuk advisory (0 = SaturationAdvisoryAfter)
+	CaptureAcquireStacks    bool                                                  // Simpan stack trace setiap acquire agar advisory dapat menunjukkan pemegang instance
+	LeakDetection           bool                                                  // Mode debug: catat stack acquire dan laporkan instance yang tidak dikembalikan
+	LeakWindow              time.Duration                                         // Lama peminjaman sebelum dilaporkan sebagai dugaan kebocoran (0 = hanya saat Close)
+	SlowFactoryThreshold    time.Duration                                         // Durasi pemanggilan factory yang dilaporkan sebagai lambat (0 = tanpa peringatan)
+	ColdTier                *ColdTierConfig                                       // Cold tier untuk objek besar yang dapat diserialisasi (nil = tidak aktif)
+	GrowthGuard             *GrowthGuardConfig                                    // Batas dan peringatan pertumbuhan tak terkendali (nil = tidak aktif)
+	ServeStale              bool                                                  // Serahkan item kedaluwarsa sebagai instance degraded jika pembuatan pengganti gagal
+	ShutdownTimeout         time.Duration                                         // Batas total durasi hook OnPoolShutdown saat pool dihapus (0 = tanpa batas)
+	Maintenance             *MaintenanceConfig                                    // Window dan batas beban untuk eviksi terjadwal dan auto-tuning (nil = selalu berjalan)
+	Health                  *HealthScoringConfig                                  // Skor kesehatan per instance untuk memilih dan memensiunkan instance (nil = tidak aktif)
+	Labels                  map[string]string                                     // Label statis pool (misalnya service, component, tier) untuk telemetri
+	Interceptors            []InstanceInterceptor                                 // Interseptor yang membungkus instance saat acquire dan dilepas saat release
+	Logger                  Logger                                                // Tujuan log pool, didahulukan atas MonitoringConfig.Logger; pada NewPoolManager menjadi MonitoringConfig.Logger
+}
+
+// evictionEnabled melaporkan apakah pool membutuhkan goroutine eviksi
+func (config PoolConfiguration) evictionEnabled() bool {
+	return config.TTL > 0 || config.MaxIdleTime > 0 || (config.Eviction != nil && config.EvictionInterval > 0)
 }
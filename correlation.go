@@ -0,0 +1,52 @@
+package poolmanager
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	leaseIDPrefix  = newLeaseIDPrefix() // Awalan acak per proses agar ID dari beberapa proses tidak bertabrakan di log
+	leaseIDCounter atomic.Uint64        // Nomor urut lease dalam proses
+)
+
+// newLeaseIDPrefix membuat awalan acak untuk ID lease
+func newLeaseIDPrefix() string {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano()&0xffffffff, 16)
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// newLeaseID membuat ID korelasi untuk satu peminjaman
+func newLeaseID() string {
+	return leaseIDPrefix + "-" + strconv.FormatUint(leaseIDCounter.Add(1), 36)
+}
+
+// LeaseID mengembalikan ID korelasi peminjaman instance yang sedang dipinjam. ID yang sama
+// muncul sebagai lease=<id> pada trace, PoolEvent.LeaseID, dan PoolItemMetadata.LeaseID, sehingga
+// perjalanan satu objek dapat dicari di log. Mengembalikan false jika instance tidak sedang dipinjam.
+func (pm *PoolManager) LeaseID(poolName string, instance PoolAble) (string, bool) {
+	record := pm.checkoutFor(poolName, pm.originalInstance(poolName, instance))
+	if record == nil {
+		return "", false
+	}
+	return record.leaseID, true
+}
+
+// lastLeaseID mengembalikan ID peminjaman terakhir instance dari metadatanya, termasuk setelah
+// instance dikembalikan, agar eviksi dan pemusnahan dapat dikaitkan dengan peminjaman terakhirnya
+func (pm *PoolManager) lastLeaseID(poolName string, instance PoolAble) string {
+	key := metadataKey(poolName, instance)
+	if key == poolName {
+		return ""
+	}
+	if metadataVal, ok := pm.itemMetadata.Load(key); ok {
+		return metadataVal.(*PoolItemMetadata).LeaseID
+	}
+	return ""
+}
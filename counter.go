@@ -0,0 +1,61 @@
+package poolmanager
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// counterStripes adalah jumlah sel pada setiap stripedCounter, harus berupa pangkat dua
+const counterStripes = 16
+
+// cacheLineSize adalah ukuran cache line yang diasumsikan untuk padding
+const cacheLineSize = 64
+
+// paddedInt64 menempati satu cache line penuh agar sel yang berdekatan tidak saling berebut
+type paddedInt64 struct {
+	value int64
+	_     [cacheLineSize - 8]byte
+}
+
+// stripedCounter adalah penghitung yang dipecah ke beberapa sel. Setiap penambahan mengenai sel
+// acak sehingga goroutine di core berbeda jarang menulis cache line yang sama; nilai penghitung
+// adalah jumlah seluruh sel dan dihitung saat dibaca.
+type stripedCounter [counterStripes]paddedInt64
+
+// add menambahkan delta ke salah satu sel
+func (c *stripedCounter) add(delta int64) {
+	atomic.AddInt64(&c[rand.Uint32()&(counterStripes-1)].value, delta)
+}
+
+// load menjumlahkan seluruh sel
+func (c *stripedCounter) load() int64 {
+	var sum int64
+	for i := range c {
+		sum += atomic.LoadInt64(&c[i].value)
+	}
+	return sum
+}
+
+// hotCounters menampung penghitung yang diperbarui pada setiap acquire dan release
+type hotCounters struct {
+	gets      stripedCounter // Sumber TotalGets
+	puts      stripedCounter // Sumber TotalPuts
+	cacheHits stripedCounter // Sumber TotalCacheHits
+}
+
+// usageParts membaca penghitung yang membentuk CurrentUsage. CurrentUsage tidak disimpan sebagai
+// gauge tersendiri, melainkan diturunkan dari penghitung yang sama dengan yang dibaca snapshot,
+// sehingga keduanya selalu konsisten: gets + cache hits - puts - leaks - discards - spills + adjust.
+func (m *PoolMetrics) usageParts() (gets, puts, cacheHits, usage int64) {
+	gets, puts, cacheHits = m.hot.gets.load(), m.hot.puts.load(), m.hot.cacheHits.load()
+	usage = gets + cacheHits - puts -
+		atomic.LoadInt64(&m.TotalLeaks) - atomic.LoadInt64(&m.TotalDiscards) - atomic.LoadInt64(&m.TotalSpills)
+	return gets, puts, cacheHits, usage
+}
+
+// currentUsage mengembalikan jumlah objek yang sedang digunakan beserta nilai koreksi yang dipakai
+func (m *PoolMetrics) currentUsage() (usage int32, adjust int64) {
+	adjust = atomic.LoadInt64(&m.usageAdjust)
+	_, _, _, usage64 := m.usageParts()
+	return int32(usage64 + adjust), adjust
+}
@@ -0,0 +1,251 @@
+package poolmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ConfigDescription adalah representasi PoolConfiguration yang dapat diserialisasi.
+// Field berupa fungsi dihilangkan dan hanya dicatat namanya pada Callbacks, sedangkan
+// strategi sharding dan kebijakan eviksi ditampilkan berdasarkan nama tipenya.
+type ConfigDescription struct {
+	Name                    string            `json:"name"`
+	SizeLimit               int               `json:"size_limit"`
+	Backend                 string            `json:"backend"`
+	MinSize                 int               `json:"min_size"`
+	MaxSize                 int               `json:"max_size"`
+	InitialSize             int               `json:"initial_size"`
+	AutoTune                bool              `json:"auto_tune"`
+	AutoTuneInterval        string            `json:"auto_tune_interval"`
+	AutoTuneFactor          float64           `json:"auto_tune_factor"`
+	AutoTuneDynamic         bool              `json:"auto_tune_dynamic"`
+	AutoTuneForecast        string            `json:"auto_tune_forecast"`
+	EnableCaching           bool              `json:"enable_caching"`
+	CacheMaxSize            int               `json:"cache_max_size"`
+	ShardingEnabled         bool              `json:"sharding_enabled"`
+	ShardCount              int               `json:"shard_count"`
+	ShardStrategy           string            `json:"shard_strategy,omitempty"`
+	TTL                     string            `json:"ttl"`
+	MaxIdleTime             string            `json:"max_idle_time"`
+	MaxLifetime             string            `json:"max_lifetime"`
+	EvictionPolicy          string            `json:"eviction_policy,omitempty"`
+	EvictionInterval        string            `json:"eviction_interval"`
+	KeyGenerator            bool              `json:"key_generator"`
+	LeakFinalizer           bool              `json:"leak_finalizer"`
+	LeaseDuration           string            `json:"lease_duration"`
+	MaxPooledObjectBytes    int64             `json:"max_pooled_object_bytes"`
+	MaxActive               int64             `json:"max_active"`
+	MaxWaiters              int               `json:"max_waiters"`
+	WaitTimeout             string            `json:"wait_timeout"`
+	StarvationThreshold     string            `json:"starvation_threshold"`
+	SaturationAdvisoryAfter string            `json:"saturation_advisory_after"`
+	ExpectedHoldTime        string            `json:"expected_hold_time"`
+	CaptureAcquireStacks    bool              `json:"capture_acquire_stacks"`
+	LeakDetection           bool              `json:"leak_detection"`
+	LeakWindow              string            `json:"leak_window"`
+	SlowFactoryThreshold    string            `json:"slow_factory_threshold"`
+	ColdTier                bool              `json:"cold_tier"`
+	GrowthGuard             bool              `json:"growth_guard"`
+	ServeStale              bool              `json:"serve_stale"`
+	ShutdownTimeout         string            `json:"shutdown_timeout"`
+	Maintenance             bool              `json:"maintenance"`
+	HealthScoring           bool              `json:"health_scoring"`
+	Labels                  map[string]string `json:"labels,omitempty"`
+	Interceptors            int               `json:"interceptors"`
+	Callbacks               []string          `json:"callbacks,omitempty"`
+}
+
+// Describe mengembalikan ConfigDescription dari konfigurasi pool.
+func (config PoolConfiguration) Describe() ConfigDescription {
+	desc := ConfigDescription{
+		Name:                    config.Name,
+		SizeLimit:               config.SizeLimit,
+		Backend:                 config.Backend.String(),
+		MinSize:                 config.MinSize,
+		MaxSize:                 config.MaxSize,
+		InitialSize:             config.InitialSize,
+		AutoTune:                config.AutoTune,
+		AutoTuneInterval:        config.AutoTuneInterval.String(),
+		AutoTuneFactor:          config.AutoTuneFactor,
+		AutoTuneDynamic:         config.AutoTuneDynamicFactor != nil,
+		AutoTuneForecast:        config.AutoTuneForecast.String(),
+		EnableCaching:           config.EnableCaching,
+		CacheMaxSize:            config.CacheMaxSize,
+		ShardingEnabled:         config.ShardingEnabled,
+		ShardCount:              config.ShardCount,
+		ShardStrategy:           typeName(config.ShardStrategy),
+		TTL:                     config.TTL.String(),
+		MaxIdleTime:             config.MaxIdleTime.String(),
+		MaxLifetime:             config.MaxLifetime.String(),
+		EvictionPolicy:          typeName(config.Eviction),
+		EvictionInterval:        config.EvictionInterval.String(),
+		KeyGenerator:            config.KeyGenerator != nil,
+		LeakFinalizer:           config.LeakFinalizer,
+		LeaseDuration:           config.LeaseDuration.String(),
+		MaxPooledObjectBytes:    config.MaxPooledObjectBytes,
+		MaxActive:               config.MaxActive,
+		MaxWaiters:              config.MaxWaiters,
+		WaitTimeout:             config.WaitTimeout.String(),
+		StarvationThreshold:     config.StarvationThreshold.String(),
+		SaturationAdvisoryAfter: config.SaturationAdvisoryAfter.String(),
+		ExpectedHoldTime:        config.ExpectedHoldTime.String(),
+		CaptureAcquireStacks:    config.CaptureAcquireStacks,
+		LeakDetection:           config.LeakDetection,
+		LeakWindow:              config.LeakWindow.String(),
+		SlowFactoryThreshold:    config.SlowFactoryThreshold.String(),
+		ColdTier:                config.ColdTier != nil,
+		GrowthGuard:             config.GrowthGuard != nil,
+		ServeStale:              config.ServeStale,
+		ShutdownTimeout:         config.ShutdownTimeout.String(),
+		Maintenance:             config.Maintenance != nil,
+		HealthScoring:           config.Health != nil,
+		Labels:                  copyLabels(config.Labels),
+		Interceptors:            len(config.Interceptors),
+	}
+
+	// Catat nama callback yang terpasang tanpa menyertakan fungsinya
+	callbacks := []struct {
+		name string
+		set  bool
+	}{
+		{"OnGet", config.OnGet != nil},
+		{"OnPut", config.OnPut != nil},
+		{"OnGetInstance", config.OnGetInstance != nil},
+		{"OnPutInstance", config.OnPutInstance != nil},
+		{"OnItemLifecycle", config.OnItemLifecycle != nil},
+		{"OnBroken", config.OnBroken != nil},
+		{"OnEvict", config.OnEvict != nil},
+		{"OnEvictInstance", config.OnEvictInstance != nil},
+		{"OnAutoTune", config.OnAutoTune != nil},
+		{"OnCreate", config.OnCreate != nil},
+		{"OnDestroy", config.OnDestroy != nil},
+		{"OnReset", config.OnReset != nil},
+		{"OnShard", config.OnShard != nil},
+		{"OnCacheHit", config.OnCacheHit != nil},
+		{"OnError", config.OnError != nil},
+		{"OnLeak", config.OnLeak != nil},
+		{"OnLeaseExpired", config.OnLeaseExpired != nil},
+	}
+	for _, cb := range callbacks {
+		if cb.set {
+			desc.Callbacks = append(desc.Callbacks, cb.name)
+		}
+	}
+	return desc
+}
+
+// MarshalJSON mengimplementasikan json.Marshaler untuk PoolConfiguration.
+// Field berupa fungsi tidak dapat diserialisasi sehingga dikonversi melalui Describe.
+func (config PoolConfiguration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(config.Describe())
+}
+
+// DescribeConfig mengembalikan konfigurasi efektif dari pool yang sedang berjalan,
+// misalnya untuk keperluan diagnostik atau endpoint admin.
+func (pm *PoolManager) DescribeConfig(poolName string) (ConfigDescription, error) {
+	conf, err := pm.getPoolConfiguration(poolName)
+	if err != nil {
+		return ConfigDescription{}, err
+	}
+	return conf.Describe(), nil
+}
+
+// typeName mengembalikan nama tipe dari sebuah nilai tanpa prefix pointer dan package.
+// Mengembalikan string kosong jika nilai nil.
+func typeName(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// PoolDescription berisi gambaran lengkap sebuah pool yang sedang berjalan untuk keperluan introspeksi.
+type PoolDescription struct {
+	Name            string            `json:"name"`                 // Nama pool
+	Namespace       string            `json:"namespace,omitempty"`  // Namespace pool (kosong jika nama tidak bernamespace)
+	Config          ConfigDescription `json:"config"`               // Ringkasan konfigurasi efektif
+	Backend         string            `json:"backend"`              // Jenis penyimpanan pool
+	ShardCount      int               `json:"shard_count"`          // Jumlah shard aktual (1 jika tidak di-shard)
+	Idle            int               `json:"idle"`                 // Jumlah item yang metadatanya berstatus idle
+	Active          int               `json:"active"`               // Jumlah instance yang sedang dipinjam
+	Metrics         PoolMetrics       `json:"metrics"`              // Salinan metrik pool
+	WaitStats       map[int]WaitStats `json:"wait_stats,omitempty"` // Statistik waktu tunggu per kelas prioritas
+	ShardStrategy   string            `json:"shard_strategy"`       // Nama strategi sharding yang dikonfigurasi
+	EvictionPolicy  string            `json:"eviction_policy"`      // Nama kebijakan eviksi yang berlaku
+	AutoTuneRunning bool              `json:"auto_tune_running"`    // Apakah goroutine auto-tuning sedang berjalan
+	EvictionRunning bool              `json:"eviction_running"`     // Apakah goroutine eviksi sedang berjalan
+	LastAutoTune    time.Time         `json:"last_auto_tune"`       // Waktu auto-tuning terakhir (nol jika belum pernah)
+	LastEviction    time.Time         `json:"last_eviction"`        // Waktu eviksi terakhir (nol jika belum pernah)
+	FailFast        bool              `json:"fail_fast"`            // Apakah growth guard menolak pembuatan instance baru
+
+	EvictionDeferredSince time.Time `json:"eviction_deferred_since"`  // Awal penundaan eviksi oleh maintenance window (nol jika tidak ditunda)
+	AutoTuneDeferredSince time.Time `json:"auto_tune_deferred_since"` // Awal penundaan auto-tuning oleh maintenance window (nol jika tidak ditunda)
+}
+
+// DescribePool mengembalikan gambaran lengkap pool: konfigurasi, jenis backend, tata letak shard,
+// jumlah item idle dan aktif, metrik, kebijakan yang berlaku, serta status tugas latar belakang.
+func (pm *PoolManager) DescribePool(poolName string) (PoolDescription, error) {
+	entry, err := pm.lookupEntry(poolName, "describe")
+	if err != nil {
+		return PoolDescription{}, err
+	}
+	poolVal, conf := entry.backend, entry.configuration()
+
+	desc := PoolDescription{
+		Name:           poolName,
+		Namespace:      PoolNamespace(poolName),
+		Config:         conf.Describe(),
+		ShardStrategy:  typeName(conf.ShardStrategy),
+		EvictionPolicy: typeName(pm.evictionPolicyFor(poolName)),
+	}
+
+	switch pool := poolVal.(type) {
+	case []*sync.Pool:
+		desc.Backend = "sharded sync.Pool"
+		desc.ShardCount = len(pool)
+	case *sync.Pool:
+		desc.Backend = "sync.Pool"
+		desc.ShardCount = 1
+	case *boundedStore:
+		desc.Backend = fmt.Sprintf("bounded (%d live)", cap(pool.live))
+		desc.ShardCount = 1
+	default:
+		desc.Backend = typeName(poolVal)
+	}
+
+	pm.itemMetadata.Range(func(key, value interface{}) bool {
+		if metadata, ok := value.(*PoolItemMetadata); ok && metadata.PoolName == poolName && metadata.Status == "Idle" {
+			desc.Idle++
+		}
+		return true
+	})
+	pm.checkouts.Range(func(key, value interface{}) bool {
+		if record, ok := value.(*checkoutRecord); ok && record.poolName == poolName {
+			desc.Active++
+		}
+		return true
+	})
+
+	desc.Metrics = pm.snapshotMetrics(poolName, entry.metrics.Load())
+	desc.Metrics.MetadataEntries, desc.Metrics.IdleAges = pm.metadataStats(poolName)
+	if recorderVal, ok := pm.waits.Load(poolName); ok {
+		desc.WaitStats = recorderVal.(*waitRecorder).snapshot()
+	}
+
+	state := entry.state
+	desc.AutoTuneRunning = state.autoTuneRunning.Load()
+	desc.EvictionRunning = state.evictionRunning.Load()
+	desc.LastAutoTune = unixNanoToTime(state.lastAutoTune.Load())
+	desc.LastEviction = unixNanoToTime(state.lastEviction.Load())
+	desc.FailFast = entry.growth.failFast.Load()
+	desc.EvictionDeferredSince = unixNanoToTime(state.evictionDeferred.Load())
+	desc.AutoTuneDeferredSince = unixNanoToTime(state.autoTuneDeferred.Load())
+	return desc, nil
+}
@@ -0,0 +1,122 @@
+package poolmanager
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// destroyInstance menghancurkan instance yang tidak akan dikembalikan ke pool.
+// Callback OnDestroy dipanggil terlebih dahulu, lalu Close jika instance mengimplementasikan io.Closer.
+func (pm *PoolManager) destroyInstance(poolName string, conf PoolConfiguration, instance PoolAble) error {
+	pm.observeLifetime(poolName, instance)
+	pm.releaseLive(poolName)
+	pm.triggerCallbackWithInstance(conf.OnDestroy, poolName, instance)
+	pm.triggerLifecycle(conf, "destroy", poolName, instance)
+	if closer, ok := instance.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// DiscardInstance mengembalikan instance yang dipinjam tanpa memasukkannya kembali ke pool.
+// Peminjaman tetap dihitung sebagai pengembalian (gauge in-use dan unit MaxActive dilepas),
+// namun instance dihancurkan melalui OnDestroy dan Close. Gunakan untuk objek yang diketahui
+// tidak dapat dipakai ulang.
+func (pm *PoolManager) DiscardInstance(poolName string, instance PoolAble) error {
+	if instance == nil {
+		err := errors.New("cannot discard nil instance from pool")
+		pm.handleError(poolName, err)
+		return err
+	}
+
+	conf, err := pm.getPoolConfiguration(poolName)
+	if err != nil {
+		pm.handleError(poolName, err)
+		return err
+	}
+	instance = pm.unwrapInstance(poolName, instance)
+	if err := pm.settleRelease(poolName, conf, instance); err != nil {
+		if errors.Is(err, errReclaimedRelease) {
+			return nil
+		}
+		err = NewPoolError(poolName, "discard", err)
+		pm.handleError(poolName, err)
+		return err
+	}
+	return pm.discardInstance(poolName, conf, instance, "caller discarded")
+}
+
+// discardInstance menyelesaikan peminjaman instance dan menghancurkannya alih-alih mengembalikannya ke pool.
+// reason hanya digunakan untuk jejak TraceOperations.
+func (pm *PoolManager) discardInstance(poolName string, conf PoolConfiguration, instance PoolAble, reason string) error {
+	shard := -1
+	if record := pm.untrackCheckout(poolName, instance); record != nil {
+		shard = record.shard
+		defer record.releaseUnits()
+	}
+
+	key, lease := metadataKey(poolName, instance), pm.lastLeaseID(poolName, instance)
+	pm.recordMetric(poolName, "discard")
+	pm.adjustGauges(poolName, shard, 0, -1)
+
+	// Instance yang dihancurkan tidak boleh lagi diserahkan dari cache
+	if cached, ok := pm.cache.Load(poolName); ok && key != poolName && instanceKey(poolName, cached) == key {
+		pm.cache.Delete(poolName)
+	}
+
+	err := pm.destroyInstance(poolName, conf, instance)
+	if key != poolName {
+		pm.itemMetadata.Delete(key)
+	}
+	pm.tracef("release pool=%s key=%s lease=%s action=discard reason=%s", poolName, key, lease, reason)
+	if err != nil {
+		err = NewPoolError(poolName, "discard", err)
+		pm.handleError(poolName, err)
+		return err
+	}
+	return nil
+}
+
+// drainPool mengambil seluruh objek idle dari pool dan menghancurkannya.
+// Pool harus sudah dilepas dari PoolManager sehingga tidak ada pemanggil baru yang menggunakannya.
+// Mengembalikan gabungan error dari setiap objek yang gagal dihancurkan.
+func (pm *PoolManager) drainPool(poolName string, pool interface{}, conf PoolConfiguration) error {
+	shards, ok := idleStores(pool)
+	if !ok {
+		return NewPoolError(poolName, "drain", errors.New(ErrInvalidNonShardedPoolName))
+	}
+
+	var errs []error
+	for _, shard := range shards {
+		// Tanpa New, Get mengembalikan nil saat shard sudah kosong
+		if p, ok := shard.(*sync.Pool); ok {
+			p.New = nil
+		}
+		for value := shard.Get(); value != nil; value = shard.Get() {
+			instance, ok := value.(PoolAble)
+			if !ok {
+				continue
+			}
+			if err := pm.destroyInstance(poolName, conf, instance); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return NewPoolError(poolName, "drain", err)
+	}
+	return nil
+}
+
+// deletePoolMetadata menghapus metadata seluruh item milik pool tertentu
+func (pm *PoolManager) deletePoolMetadata(poolName string) {
+	pm.itemMetadata.Range(func(key, value interface{}) bool {
+		if metadata, ok := value.(*PoolItemMetadata); ok && metadata.PoolName == poolName {
+			pm.itemMetadata.Delete(key)
+		}
+		return true
+	})
+	pm.itemMetadata.Delete(poolName)
+}
@@ -0,0 +1,166 @@
+package poolmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// PoolDiffStatus menunjukkan keberadaan pool pada dua snapshot yang dibandingkan
+type PoolDiffStatus string
+
+const (
+	PoolUnchanged PoolDiffStatus = "unchanged" // Pool ada pada kedua snapshot tanpa perubahan
+	PoolChanged   PoolDiffStatus = "changed"   // Pool ada pada kedua snapshot dengan perubahan metrik
+	PoolAdded     PoolDiffStatus = "added"     // Pool hanya ada pada snapshot kedua
+	PoolRemoved   PoolDiffStatus = "removed"   // Pool hanya ada pada snapshot pertama
+)
+
+// PoolDelta berisi perubahan metrik satu pool di antara dua snapshot. Penghitung (Gets hingga
+// Invalid) adalah jumlah kejadian di antara snapshot, sedangkan gauge (Idle hingga RetainedBytes)
+// adalah selisih nilai snapshot kedua terhadap snapshot pertama.
+type PoolDelta struct {
+	Name          string         `json:"name"`
+	Status        PoolDiffStatus `json:"status"`
+	Reset         bool           `json:"reset,omitempty"` // Penghitung lebih kecil pada snapshot kedua, misalnya karena ReinitializePool
+	Gets          int64          `json:"gets"`
+	Puts          int64          `json:"puts"`
+	CacheHits     int64          `json:"cache_hits"`
+	Evicts        int64          `json:"evicts"`
+	Leaks         int64          `json:"leaks"`
+	Discards      int64          `json:"discards"`
+	Broken        int64          `json:"broken"`
+	Spills        int64          `json:"spills"`
+	Rehydrates    int64          `json:"rehydrates"`
+	Stale         int64          `json:"stale"`
+	Coalesced     int64          `json:"coalesced"`
+	Reclaimed     int64          `json:"reclaimed"`
+	Invalid       int64          `json:"invalid"`
+	Idle          int64          `json:"idle"`
+	InUse         int64          `json:"in_use"`
+	Cold          int64          `json:"cold"`
+	RetainedBytes int64          `json:"retained_bytes"`
+}
+
+// DiffReport adalah perbandingan dua ManagerSnapshot, misalnya sebelum dan sesudah uji beban
+type DiffReport struct {
+	From    time.Time     `json:"from"`
+	To      time.Time     `json:"to"`
+	Elapsed time.Duration `json:"elapsed"`
+	Pools   []PoolDelta   `json:"pools"` // Diurutkan berdasarkan nama pool
+}
+
+// SnapshotDiff menghitung perubahan metrik setiap pool dari snapshot a ke snapshot b. Jika suatu
+// penghitung pada b lebih kecil dari a, metrik dianggap telah di-reset dan nilai b dipakai sebagai
+// jumlah kejadian. Pool yang hanya ada pada salah satu snapshot dibandingkan dengan metrik kosong.
+func SnapshotDiff(a, b ManagerSnapshot) DiffReport {
+	report := DiffReport{From: a.Timestamp, To: b.Timestamp, Elapsed: b.Timestamp.Sub(a.Timestamp)}
+
+	names := make(map[string]struct{}, len(b.Pools))
+	for name := range a.Pools {
+		names[name] = struct{}{}
+	}
+	for name := range b.Pools {
+		names[name] = struct{}{}
+	}
+	for name := range names {
+		before, inA := a.Pools[name]
+		after, inB := b.Pools[name]
+		delta := diffPool(name, before, after)
+		switch {
+		case !inA:
+			delta.Status = PoolAdded
+		case !inB:
+			delta.Status = PoolRemoved
+		case delta.changed():
+			delta.Status = PoolChanged
+		default:
+			delta.Status = PoolUnchanged
+		}
+		report.Pools = append(report.Pools, delta)
+	}
+	sort.Slice(report.Pools, func(i, j int) bool { return report.Pools[i].Name < report.Pools[j].Name })
+	return report
+}
+
+// diffPool menghitung PoolDelta dari metrik sebelum dan sesudah
+func diffPool(name string, before, after PoolMetrics) PoolDelta {
+	delta := PoolDelta{Name: name}
+	counter := func(a, b int64) int64 {
+		if b < a {
+			delta.Reset = true
+			return b
+		}
+		return b - a
+	}
+	delta.Gets = counter(before.TotalGets, after.TotalGets)
+	delta.Puts = counter(before.TotalPuts, after.TotalPuts)
+	delta.CacheHits = counter(before.TotalCacheHits, after.TotalCacheHits)
+	delta.Evicts = counter(before.TotalEvicts, after.TotalEvicts)
+	delta.Leaks = counter(before.TotalLeaks, after.TotalLeaks)
+	delta.Discards = counter(before.TotalDiscards, after.TotalDiscards)
+	delta.Broken = counter(before.TotalBroken, after.TotalBroken)
+	delta.Spills = counter(before.TotalSpills, after.TotalSpills)
+	delta.Rehydrates = counter(before.TotalRehydrates, after.TotalRehydrates)
+	delta.Stale = counter(before.TotalStale, after.TotalStale)
+	delta.Coalesced = counter(before.TotalCoalesced, after.TotalCoalesced)
+	delta.Reclaimed = counter(before.TotalReclaimed, after.TotalReclaimed)
+	delta.Invalid = counter(before.TotalInvalid, after.TotalInvalid)
+	delta.Idle = after.IdleCount - before.IdleCount
+	delta.InUse = after.InUseCount - before.InUseCount
+	delta.Cold = after.ColdCount - before.ColdCount
+	delta.RetainedBytes = after.RetainedBytes - before.RetainedBytes
+	return delta
+}
+
+// changed memeriksa apakah ada metrik yang berubah
+func (d PoolDelta) changed() bool {
+	unchanged := PoolDelta{Name: d.Name, Status: d.Status}
+	return d != unchanged
+}
+
+// Pool mengembalikan perubahan metrik pool tertentu
+func (r DiffReport) Pool(name string) (PoolDelta, bool) {
+	i := sort.Search(len(r.Pools), func(i int) bool { return r.Pools[i].Name >= name })
+	if i < len(r.Pools) && r.Pools[i].Name == name {
+		return r.Pools[i], true
+	}
+	return PoolDelta{}, false
+}
+
+// Write menulis laporan dalam format teks atau JSON. Format teks hanya memuat pool yang berubah.
+func (r DiffReport) Write(w io.Writer, format DumpFormat) error {
+	if format == DumpJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Snapshot diff %s -> %s (%s)\n\n", r.From.Format(time.RFC3339), r.To.Format(time.RFC3339), r.Elapsed)
+	fmt.Fprintln(tw, "POOL\tSTATUS\tGETS\tPUTS\tCACHE HITS\tEVICTS\tDISCARDS\tLEAKS\tIDLE\tIN USE\tRETAINED BYTES")
+	for _, pool := range r.Pools {
+		if pool.Status == PoolUnchanged {
+			continue
+		}
+		status := string(pool.Status)
+		if pool.Reset {
+			status += " (reset)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%d\t%+d\t%+d\t%+d\n", pool.Name, status,
+			pool.Gets, pool.Puts, pool.CacheHits, pool.Evicts, pool.Discards, pool.Leaks,
+			pool.Idle, pool.InUse, pool.RetainedBytes)
+	}
+	return tw.Flush()
+}
+
+// String merangkum laporan dalam format teks
+func (r DiffReport) String() string {
+	var sb strings.Builder
+	_ = r.Write(&sb, DumpText)
+	return sb.String()
+}
@@ -0,0 +1,132 @@
+package poolmanager
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// recentOperationLimit adalah jumlah operasi terakhir per pool yang disimpan untuk laporan drift
+const recentOperationLimit = 32
+
+// OperationRecord mencatat satu operasi pada pool untuk keperluan diagnosa
+type OperationRecord struct {
+	Action string    // Tindakan yang dicatat oleh recordMetric ("get", "put", dan seterusnya)
+	Time   time.Time // Waktu operasi dicatat
+}
+
+// DriftReport menjelaskan pelanggaran invarian akuntansi metrik pada sebuah pool,
+// misalnya CurrentUsage yang menjadi negatif akibat instance dikembalikan dua kali.
+type DriftReport struct {
+	PoolName         string            // Nama pool yang mengalami drift
+	Invariant        string            // Invarian yang dilanggar
+	Metrics          PoolMetrics       // Salinan metrik saat pelanggaran terdeteksi
+	RecentOperations []OperationRecord // Operasi terakhir pada pool, dari yang terlama
+}
+
+// operationHistory menyimpan operasi terakhir sebuah pool dalam ring buffer berukuran tetap
+type operationHistory struct {
+	mu      sync.Mutex
+	records [recentOperationLimit]OperationRecord
+	next    int
+	full    bool
+}
+
+// add menambahkan operasi ke ring buffer, menimpa operasi tertua jika penuh
+func (h *operationHistory) add(action string, now time.Time) {
+	h.mu.Lock()
+	h.records[h.next] = OperationRecord{Action: action, Time: now}
+	h.next = (h.next + 1) % recentOperationLimit
+	if h.next == 0 {
+		h.full = true
+	}
+	h.mu.Unlock()
+}
+
+// recent mengembalikan salinan operasi yang tersimpan, dari yang terlama
+func (h *operationHistory) recent() []OperationRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.full {
+		return append([]OperationRecord(nil), h.records[:h.next]...)
+	}
+	records := make([]OperationRecord, 0, recentOperationLimit)
+	records = append(records, h.records[h.next:]...)
+	return append(records, h.records[:h.next]...)
+}
+
+// recordOperation mencatat operasi ke riwayat pool
+func (pm *PoolManager) recordOperation(poolName, action string) {
+	historyVal, _ := pm.operations.LoadOrStore(poolName, &operationHistory{})
+	historyVal.(*operationHistory).add(action, time.Now())
+}
+
+// recentOperations mengembalikan riwayat operasi terakhir pool
+func (pm *PoolManager) recentOperations(poolName string) []OperationRecord {
+	historyVal, ok := pm.operations.Load(poolName)
+	if !ok {
+		return nil
+	}
+	return historyVal.(*operationHistory).recent()
+}
+
+// checkInvariants memeriksa invarian akuntansi setelah recordMetric memperbarui metrik:
+// CurrentUsage tidak boleh negatif, dan jumlah put tidak boleh melebihi jumlah get,
+// cache hit, dan objek awal pool. CurrentUsage yang negatif dikoreksi ke nol setelah dilaporkan.
+func (pm *PoolManager) checkInvariants(poolName, action string, metrics *PoolMetrics) {
+	if usage, adjust := metrics.currentUsage(); usage < 0 {
+		snap := metrics.snapshot()
+		if atomic.CompareAndSwapInt64(&metrics.usageAdjust, adjust, adjust-int64(usage)) {
+			pm.reportDrift(poolName, "CurrentUsage must not be negative", snap)
+		}
+	}
+
+	if action != "put" {
+		return
+	}
+
+	initialSize := 0
+	if conf, err := pm.getPoolConfiguration(poolName); err == nil {
+		initialSize = conf.InitialSize
+	}
+	gets, puts, cacheHits, _ := metrics.usageParts()
+	acquired := gets + cacheHits + int64(initialSize) + atomic.LoadInt64(&metrics.carriedInUse)
+	// Pelanggaran ini bersifat kumulatif, jadi hanya dilaporkan satu kali per pool
+	if puts > acquired && atomic.CompareAndSwapInt32(&metrics.putDriftReported, 0, 1) {
+		pm.reportDrift(poolName, "TotalPuts must not exceed TotalGets + TotalCacheHits + InitialSize", metrics.snapshot())
+	}
+}
+
+// checkInUseGauge memastikan gauge InUseCount pool dan shard tidak negatif.
+func (pm *PoolManager) checkInUseGauge(poolName string, shard int, metrics *PoolMetrics) {
+	if inUse := atomic.LoadInt64(&metrics.InUseCount); inUse < 0 {
+		snap := metrics.snapshot()
+		if atomic.CompareAndSwapInt64(&metrics.InUseCount, inUse, 0) {
+			pm.reportDrift(poolName, "InUseCount must not be negative", snap)
+		}
+	}
+	if shard >= 0 && shard < len(metrics.Shards) {
+		if inUse := atomic.LoadInt64(&metrics.Shards[shard].InUseCount); inUse < 0 {
+			atomic.CompareAndSwapInt64(&metrics.Shards[shard].InUseCount, inUse, 0)
+		}
+	}
+}
+
+// reportDrift mencatat pelanggaran invarian, memicu EventDrift, dan memanggil OnDrift jika diatur.
+// snap adalah salinan metrik sebelum nilai yang melanggar dikoreksi.
+func (pm *PoolManager) reportDrift(poolName, invariant string, snap PoolMetrics) {
+	snap.Labels = pm.poolLabels(poolName)
+	report := DriftReport{
+		PoolName:         poolName,
+		Invariant:        invariant,
+		Metrics:          snap,
+		RecentOperations: pm.recentOperations(poolName),
+	}
+
+	pm.logPoolf(WarningLevel, poolName, "Metric accounting drift detected in pool: %s, invariant violated: %s", poolName, invariant)
+	pm.triggerEvent(PoolEvent{Type: EventDrift, PoolName: poolName, Drift: &report})
+	if onDrift := pm.monitoring().OnDrift; onDrift != nil {
+		onDrift(report)
+	}
+}
@@ -0,0 +1,206 @@
+package poolmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+)
+
+// DumpFormat menentukan format keluaran DumpState
+type DumpFormat int
+
+const (
+	DumpText DumpFormat = iota // Keluaran teks yang mudah dibaca manusia
+	DumpJSON                   // Keluaran JSON untuk dilampirkan ke laporan bug atau diolah tooling
+)
+
+// DumpOptions mengatur isi dan format keluaran DumpState
+type DumpOptions struct {
+	Format          DumpFormat // Format keluaran
+	IncludeMetadata bool       // Sertakan seluruh entri metadata item
+	IncludeCache    bool       // Sertakan isi cache
+	IncludeCheckout bool       // Sertakan daftar instance yang sedang dipinjam
+	Namespace       string     // Batasi keluaran pada pool dalam namespace ini (kosong berarti seluruh pool)
+}
+
+// StateDump adalah isi lengkap dari DumpState dalam bentuk terstruktur
+type StateDump struct {
+	Timestamp time.Time                   `json:"timestamp"`
+	Pools     []PoolDescription           `json:"pools"`
+	Metadata  map[string]PoolItemMetadata `json:"metadata,omitempty"`
+	Cache     map[string]string           `json:"cache,omitempty"`
+	Checkouts []CheckoutDump              `json:"checkouts,omitempty"`
+}
+
+// CheckoutDump menggambarkan satu instance yang sedang dipinjam saat DumpState dipanggil
+type CheckoutDump struct {
+	Key        string        `json:"key"`
+	PoolName   string        `json:"pool_name"`
+	Owner      string        `json:"owner,omitempty"`
+	Shard      int           `json:"shard"` // -1 jika pool tidak menggunakan sharding
+	AcquiredAt time.Time     `json:"acquired_at"`
+	HeldFor    time.Duration `json:"held_for"`
+}
+
+// DumpState menulis keadaan seluruh pool, metadata, cache, dan tugas latar belakang ke w.
+// Fungsi ini setara dengan heap dump untuk PoolManager dan berguna untuk dilampirkan pada laporan bug.
+func (pm *PoolManager) DumpState(w io.Writer, opts DumpOptions) error {
+	dump := pm.collectState(opts)
+	if opts.Format == DumpJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(dump)
+	}
+	return writeStateText(w, dump)
+}
+
+// collectState mengumpulkan keadaan PoolManager sesuai opsi yang diberikan
+func (pm *PoolManager) collectState(opts DumpOptions) StateDump {
+	dump := StateDump{Timestamp: time.Now()}
+
+	for _, name := range pm.ListPoolsInNamespace(opts.Namespace) {
+		if desc, err := pm.DescribePool(name); err == nil {
+			dump.Pools = append(dump.Pools, desc)
+		}
+	}
+
+	if opts.IncludeMetadata {
+		dump.Metadata = make(map[string]PoolItemMetadata)
+		pm.itemMetadata.Range(func(key, value interface{}) bool {
+			if metadata, ok := value.(*PoolItemMetadata); ok && inNamespace(metadata.PoolName, opts.Namespace) {
+				dump.Metadata[key.(string)] = *metadata
+			}
+			return true
+		})
+	}
+
+	if opts.IncludeCache {
+		dump.Cache = make(map[string]string)
+		pm.cache.Range(func(key, value interface{}) bool {
+			if name := fmt.Sprint(key); inNamespace(name, opts.Namespace) {
+				dump.Cache[name] = fmt.Sprintf("%T", value)
+			}
+			return true
+		})
+	}
+
+	if opts.IncludeCheckout {
+		pm.checkouts.Range(func(key, value interface{}) bool {
+			if record, ok := value.(*checkoutRecord); ok && inNamespace(record.poolName, opts.Namespace) {
+				dump.Checkouts = append(dump.Checkouts, CheckoutDump{
+					Key:        record.key,
+					PoolName:   record.poolName,
+					Owner:      record.owner,
+					Shard:      record.shard,
+					AcquiredAt: record.acquiredAt,
+					HeldFor:    dump.Timestamp.Sub(record.acquiredAt),
+				})
+			}
+			return true
+		})
+		sort.Slice(dump.Checkouts, func(i, j int) bool {
+			return dump.Checkouts[i].AcquiredAt.Before(dump.Checkouts[j].AcquiredAt)
+		})
+	}
+	return dump
+}
+
+// writeStateText menulis StateDump dalam format teks yang mudah dibaca
+func writeStateText(w io.Writer, dump StateDump) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "PoolManager state at %s\n\n", dump.Timestamp.Format(time.RFC3339))
+
+	for _, pool := range dump.Pools {
+		fmt.Fprintf(tw, "Pool %s\n", pool.Name)
+		fmt.Fprintf(tw, "  backend:\t%s (%d shard)\n", pool.Backend, pool.ShardCount)
+		fmt.Fprintf(tw, "  idle/active:\t%d/%d\n", pool.Idle, pool.Active)
+		fmt.Fprintf(tw, "  gauge idle/in-use/waiters:\t%d/%d/%d\n", pool.Metrics.IdleCount, pool.Metrics.InUseCount, pool.Metrics.Waiters)
+		for i, shard := range pool.Metrics.Shards {
+			fmt.Fprintf(tw, "    shard %d idle/in-use:\t%d/%d\n", i, shard.IdleCount, shard.InUseCount)
+		}
+		fmt.Fprintf(tw, "  gets/puts/evicts:\t%d/%d/%d\n", pool.Metrics.TotalGets, pool.Metrics.TotalPuts, pool.Metrics.TotalEvicts)
+		fmt.Fprintf(tw, "  leaks/discards/broken:\t%d/%d/%d\n", pool.Metrics.TotalLeaks, pool.Metrics.TotalDiscards, pool.Metrics.TotalBroken)
+		fmt.Fprintf(tw, "  usage/retained:\t%d/%d bytes\n", pool.Metrics.CurrentUsage, pool.Metrics.RetainedBytes)
+		fmt.Fprintf(tw, "  metadata entries:\t%d\n", pool.Metrics.MetadataEntries)
+		fmt.Fprintf(tw, "  cold/spills/rehydrates:\t%d/%d/%d\n", pool.Metrics.ColdCount, pool.Metrics.TotalSpills, pool.Metrics.TotalRehydrates)
+		fmt.Fprintf(tw, "  construction mean/slowest:\t%s/%s (%d calls)\n",
+			pool.Metrics.FactoryLatency.Mean(), pool.Metrics.SlowestConstruction, pool.Metrics.FactoryLatency.Count)
+		fmt.Fprintf(tw, "  idle age mean/p90:\t%s/%s (%d idle)\n",
+			pool.Metrics.IdleAges.Mean(), formatAgeQuantile(pool.Metrics.IdleAges, 0.9), pool.Metrics.IdleAges.Count)
+		fmt.Fprintf(tw, "  lifetime mean/p90:\t%s/%s (%d destroyed)\n",
+			pool.Metrics.ObjectLifetime.Mean(), formatAgeQuantile(pool.Metrics.ObjectLifetime, 0.9), pool.Metrics.ObjectLifetime.Count)
+		fmt.Fprintf(tw, "  strategy/policy:\t%s/%s\n", orNone(pool.ShardStrategy), orNone(pool.EvictionPolicy))
+		fmt.Fprintf(tw, "  auto-tune:\trunning=%t last=%s\n", pool.AutoTuneRunning, formatDumpTime(pool.LastAutoTune))
+		fmt.Fprintf(tw, "  eviction:\trunning=%t last=%s\n", pool.EvictionRunning, formatDumpTime(pool.LastEviction))
+		fmt.Fprintln(tw)
+	}
+
+	if len(dump.Metadata) > 0 {
+		fmt.Fprintln(tw, "Metadata")
+		for _, key := range sortedKeys(dump.Metadata) {
+			meta := dump.Metadata[key]
+			fmt.Fprintf(tw, "  %s\tpool=%s status=%s freq=%d last_used=%s tags=%v\n",
+				key, meta.PoolName, meta.Status, meta.Frequency, formatDumpTime(meta.LastUsed), meta.Tag)
+		}
+		fmt.Fprintln(tw)
+	}
+
+	if len(dump.Cache) > 0 {
+		fmt.Fprintln(tw, "Cache")
+		for _, key := range sortedKeys(dump.Cache) {
+			fmt.Fprintf(tw, "  %s\t%s\n", key, dump.Cache[key])
+		}
+		fmt.Fprintln(tw)
+	}
+
+	if len(dump.Checkouts) > 0 {
+		fmt.Fprintln(tw, "Checkouts")
+		for _, checkout := range dump.Checkouts {
+			fmt.Fprintf(tw, "  %s\tpool=%s owner=%s shard=%d held_for=%s\n",
+				checkout.Key, checkout.PoolName, orNone(checkout.Owner), checkout.Shard, checkout.HeldFor)
+		}
+	}
+	return tw.Flush()
+}
+
+// sortedKeys mengembalikan kunci map yang sudah diurutkan agar keluaran dump stabil
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// orNone mengganti string kosong dengan "-" pada keluaran teks
+func orNone(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// formatDumpTime memformat waktu untuk keluaran teks, "never" untuk waktu nol
+func formatDumpTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Format(time.RFC3339)
+}
+
+// formatAgeQuantile memformat kuantil AgeHistogram, "-" untuk histogram kosong dan ">24h" untuk
+// bucket overflow
+func formatAgeQuantile(h AgeHistogram, q float64) string {
+	switch quantile := h.Quantile(q); {
+	case h.Count == 0:
+		return "-"
+	case quantile < 0:
+		return ">" + AgeBucketBounds[len(AgeBucketBounds)-1].String()
+	default:
+		return quantile.String()
+	}
+}
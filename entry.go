@@ -0,0 +1,67 @@
+package poolmanager
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// poolEntry menyimpan seluruh data milik satu pool yang terdaftar. Entri dibuat sekali oleh
+// AddPool dan dihapus oleh RemovePool, sehingga acquire dan release cukup melakukan satu
+// pencarian map untuk mendapatkan pool, konfigurasi, factory, dan semaphore-nya.
+type poolEntry struct {
+	name    string          // Nama pool
+	backend interface{}     // *sync.Pool atau []*sync.Pool, tidak berubah selama pool terdaftar
+	factory func() PoolAble // Factory untuk membuat objek baru
+	state   *poolState      // Status tugas latar belakang dan semaphore MaxActive pool
+
+	// config dipublikasikan sebagai pointer ke salinan yang tidak pernah diubah, sehingga pembacaan
+	// konfigurasi pada setiap operasi bebas tunggu dan selalu melihat konfigurasi yang utuh
+	config   atomic.Pointer[PoolConfiguration]
+	updateMu sync.Mutex                  // Menyerialkan UpdatePoolConfig agar konfigurasi dan tugas latar belakang selaras
+	metrics  atomic.Pointer[PoolMetrics] // Metrik pool, diganti oleh ReinitializePool
+	removed  atomic.Bool                 // Ditandai oleh RemovePool agar PoolRef lama kembali mencari berdasarkan nama
+	growth   growthGuard                 // Penghitung pembuatan instance untuk GrowthGuard
+	adopted  *adoptedPool                // sync.Pool milik pemanggil yang diadopsi dengan AdoptPool (nil jika tidak ada)
+	shadow   atomic.Pointer[shadowLink]  // Pool shadow untuk Failover (nil jika tidak ada)
+
+	shutdownMu    sync.Mutex     // Melindungi shutdownHooks
+	shutdownHooks []ShutdownHook // Hook OnPoolShutdown sesuai urutan pendaftaran
+}
+
+// configuration mengembalikan salinan konfigurasi pool yang sedang berlaku
+func (e *poolEntry) configuration() PoolConfiguration {
+	return *e.config.Load()
+}
+
+// setConfiguration mempublikasikan konfigurasi baru. Label disalin agar perubahan map milik
+// pemanggil tidak ikut terlihat oleh pool.
+func (e *poolEntry) setConfiguration(config PoolConfiguration) {
+	config.Labels = copyLabels(config.Labels)
+	e.config.Store(&config)
+}
+
+// entryFor mengambil entri pool yang terdaftar
+func (pm *PoolManager) entryFor(poolName string) (*poolEntry, bool) {
+	entryVal, ok := pm.entries.Load(poolName)
+	if !ok {
+		return nil, false
+	}
+	return entryVal.(*poolEntry), true
+}
+
+// lookupEntry mengambil entri pool, mengembalikan PoolError jika pool tidak terdaftar
+func (pm *PoolManager) lookupEntry(poolName, op string) (*poolEntry, error) {
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		return nil, NewPoolError(poolName, op, errors.New(ErrPoolDoesNotExist+poolName))
+	}
+	return entry, nil
+}
+
+// forEachEntry memanggil visit untuk setiap pool yang terdaftar hingga visit mengembalikan false
+func (pm *PoolManager) forEachEntry(visit func(entry *poolEntry) bool) {
+	pm.entries.Range(func(key, value interface{}) bool {
+		return visit(value.(*poolEntry))
+	})
+}
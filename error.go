@@ -1,6 +1,11 @@
 package poolmanager
 
-import "strings"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // Error constants untuk berbagai jenis kesalahan pada PoolManager
 // Konstanta ini digunakan sebagai pesan dasar untuk error yang mungkin terjadi
@@ -13,6 +18,50 @@ const (
 	ErrInvalidFactoryType        = "invalid factory type"            // Error untuk tipe factory yang tidak valid
 )
 
+// Sentinel error yang dapat diperiksa dengan errors.Is, meskipun dibungkus dalam PoolError.
+var (
+	// ErrNoIdleInstance dikembalikan saat pool kosong dan pembuatan instance baru tidak diizinkan
+	ErrNoIdleInstance = errors.New("no idle instance available")
+
+	// ErrWeightExceedsCapacity dikembalikan saat bobot acquire melebihi MaxActive pool
+	ErrWeightExceedsCapacity = errors.New("acquire weight exceeds pool capacity")
+
+	// ErrTooManyWaiters dikembalikan saat antrean acquire pool sudah mencapai MaxWaiters
+	ErrTooManyWaiters = errors.New("too many waiters")
+
+	// ErrNotCheckedOut dikembalikan saat operasi membutuhkan instance yang sedang dipinjam dari pool
+	ErrNotCheckedOut = errors.New("instance is not checked out")
+
+	// ErrDoubleRelease dikembalikan saat instance yang sudah dikembalikan ke pool dikembalikan lagi
+	ErrDoubleRelease = errors.New("instance released twice")
+
+	// ErrForeignInstance dikembalikan saat instance yang tidak pernah dipinjam dari pool dikembalikan
+	// ke pool tersebut
+	ErrForeignInstance = errors.New("instance was not acquired from this pool")
+
+	// ErrTypeMismatch dikembalikan saat instance dari pool tidak bertipe sesuai yang diminta
+	ErrTypeMismatch = errors.New("instance type mismatch")
+
+	// ErrInvalidPoolName dikembalikan saat nama pool tidak mengikuti aturan penamaan ValidatePoolName
+	ErrInvalidPoolName = errors.New("invalid pool name")
+
+	// ErrGrowthLimitExceeded dikembalikan saat pembuatan instance baru melebihi GrowthGuardConfig.MaxCreated
+	ErrGrowthLimitExceeded = errors.New("pool growth limit exceeded")
+
+	// ErrPoolFailFast dikembalikan saat pool berada dalam mode fail-fast akibat growth guard
+	ErrPoolFailFast = errors.New("pool is in fail-fast mode")
+
+	// ErrLeaseExpired dikembalikan oleh RenewLease saat lease sudah berakhir atau peminjaman sudah selesai
+	ErrLeaseExpired = errors.New("lease expired")
+
+	// ErrWaitTimeout dikembalikan saat acquire menunggu kapasitas pool lebih lama dari WaitTimeout.
+	// Membungkus context.DeadlineExceeded.
+	ErrWaitTimeout = fmt.Errorf("timed out waiting for pool capacity: %w", context.DeadlineExceeded)
+
+	// ErrManagerClosed dikembalikan saat acquire atau AddPool dipanggil setelah PoolManager ditutup
+	ErrManagerClosed = errors.New("pool manager is closed")
+)
+
 // PoolError adalah tipe error khusus yang digunakan untuk mencatat kesalahan pada operasi PoolManager
 // PoolError menyimpan informasi tentang tipe pool, operasi yang gagal, dan error asli yang menyebabkan kegagalan.
 type PoolError struct {
@@ -53,3 +102,34 @@ func (e *PoolError) Error() string {
 func (e *PoolError) Unwrap() error {
 	return e.Err
 }
+
+// ConfigFieldError menjelaskan satu masalah validasi pada sebuah field PoolConfiguration.
+// Validate mengembalikan gabungan dari error ini sehingga semua masalah terlihat sekaligus;
+// gunakan errors.As untuk memeriksa field tertentu.
+type ConfigFieldError struct {
+	Field  string      // Nama field yang tidak valid
+	Value  interface{} // Nilai aktual dari field tersebut
+	Reason string      // Penjelasan mengapa nilai tidak valid
+}
+
+// Error mengimplementasikan interface error dengan menyebutkan nama field, nilai, dan alasannya.
+func (e *ConfigFieldError) Error() string {
+	return fmt.Sprintf("invalid %s (%v): %s", e.Field, e.Value, e.Reason)
+}
+
+// TypeMismatchError menjelaskan tipe yang diharapkan dan tipe aktual instance dari pool.
+// errors.Is(err, ErrTypeMismatch) bernilai true untuk error ini.
+type TypeMismatchError struct {
+	Expected string // Tipe yang diminta oleh pemanggil
+	Actual   string // Tipe instance yang dikembalikan oleh pool
+}
+
+// Error mengimplementasikan interface error dengan menyebutkan kedua tipe.
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("%s: expected %s, got %s", ErrTypeMismatch, e.Expected, e.Actual)
+}
+
+// Unwrap mengembalikan ErrTypeMismatch agar dapat diperiksa dengan errors.Is
+func (e *TypeMismatchError) Unwrap() error {
+	return ErrTypeMismatch
+}
@@ -1,6 +1,8 @@
 package poolmanager
 
 import (
+	"errors"
+	"sort"
 	"time"
 )
 
@@ -21,16 +23,9 @@ type EvictionPolicy interface {
 }
 
 // Implementasi Evict untuk SmartEvictionPolicy
+// Objek idle yang memenuhi ShouldEvict dikeluarkan dari pool dan dihancurkan.
 func (p *SmartEvictionPolicy) Evict(poolType string, pm *PoolManager) {
-	pm.itemMetadata.Range(func(key, value interface{}) bool {
-		if metadata, ok := value.(*PoolItemMetadata); ok && p.ShouldEvict(key.(string), metadata) {
-			// Evict jika kebijakan terpenuhi
-			pm.cache.Delete(key)
-			pm.itemMetadata.Delete(key)
-			pm.logger.Printf("Evicted item from pool: %s, Key: %s, LastUsed: %s", poolType, key, metadata.LastUsed)
-		}
-		return true
-	})
+	pm.evictIdleWhere(poolType, "smart policy", p.ShouldEvict)
 }
 
 // SmartEvictionPolicy menggabungkan kebijakan eviksi berbasis TTL, LRU, dan LFU
@@ -69,21 +64,9 @@ type TTLEvictionPolicy struct {
 
 // Evict mengevaluasi apakah objek harus dieviksikan
 // poolType: tipe pool dari mana item akan dihapus
-// Fungsi ini mencari item dengan TTL terakhir digunakan paling lama dan menghapusnya dari cache dan metadata.
+// Objek idle yang tidak digunakan lebih lama dari TTL dikeluarkan dari pool dan dihancurkan.
 func (p *TTLEvictionPolicy) Evict(poolType string, pm *PoolManager) {
-	pm.itemMetadata.Range(func(key, value interface{}) bool {
-		// Evaluasi kebijakan eviksi
-		if metadata, ok := value.(*PoolItemMetadata); ok && p.ShouldEvict(key.(string), metadata) {
-			// Hapus item dari cache dan metadata jika kebijakan eviksi terpenuhi
-			pm.cache.Delete(key)
-			pm.itemMetadata.Delete(key)
-
-			// Tambahkan log dengan menggunakan key dan poolType
-			pm.logger.Printf("Evicted item from pool: %s, Key: %s, LastUsed: %s, Frequency: %d",
-				poolType, key, metadata.LastUsed, metadata.Frequency)
-		}
-		return true
-	})
+	pm.evictIdleWhere(poolType, "idle exceeds TTL "+p.TTL.String(), p.ShouldEvict)
 }
 
 // ShouldEvict mengevaluasi apakah objek harus dieviksikan berdasarkan TTL
@@ -100,8 +83,13 @@ type LRUEvictionPolicy struct {
 	MaxIdleTime time.Duration // Batas waktu idle untuk objek
 }
 
+// Evict menghancurkan objek idle di dalam pool yang tidak digunakan lebih lama dari MaxIdleTime
+// poolType: tipe pool dari mana item akan dihapus
 func (p *LRUEvictionPolicy) Evict(poolType string, pm *PoolManager) {
-	// Tidak ada item yang dieviksikan
+	if p.MaxIdleTime <= 0 {
+		return
+	}
+	pm.evictIdle(poolType, p.MaxIdleTime)
 }
 
 // ShouldEvict mengevaluasi apakah objek harus dieviksikan berdasarkan waktu terakhir digunakan
@@ -125,3 +113,166 @@ type LFUEvictionPolicy struct {
 func (p *LFUEvictionPolicy) ShouldEvict(key string, metadata *PoolItemMetadata) bool {
 	return metadata.Frequency < p.MinFrequency
 }
+
+// CostAwareEvictionPolicy mengevaluasi item idle dengan mempertimbangkan biaya pembuatannya.
+// Item yang mahal dibuat (CreationCost besar) diberi waktu idle lebih lama sebelum dieviksi,
+// sehingga item yang murah dibuat ulang akan dieviksi lebih dahulu.
+type CostAwareEvictionPolicy struct {
+	MaxIdleTime time.Duration // Batas waktu idle untuk item yang biaya pembuatannya nol
+	CostFactor  float64       // Tambahan waktu idle per satuan durasi pembuatan (misalnya 1000: 1ms biaya = 1s tambahan)
+	MaxCost     time.Duration // Item dengan biaya pembuatan >= MaxCost tidak pernah dieviksi (0 = tanpa batas)
+	MaxPerRun   int           // Batas jumlah item yang dieviksi per putaran, dimulai dari yang termurah (0 = tanpa batas)
+}
+
+// idleAllowance menghitung batas waktu idle sebuah item berdasarkan biaya pembuatannya
+func (p *CostAwareEvictionPolicy) idleAllowance(metadata *PoolItemMetadata) time.Duration {
+	return p.MaxIdleTime + time.Duration(float64(metadata.CreationCost)*p.CostFactor)
+}
+
+// ShouldEvict mengevaluasi apakah objek harus dieviksikan berdasarkan waktu idle dan biaya pembuatan
+// key: kunci unik dari objek yang dievaluasi
+// metadata: metadata objek yang digunakan untuk evaluasi
+// Mengembalikan nilai true jika waktu sejak penggunaan terakhir melebihi batas idle yang
+// sudah disesuaikan dengan biaya pembuatan item.
+func (p *CostAwareEvictionPolicy) ShouldEvict(key string, metadata *PoolItemMetadata) bool {
+	if p.MaxCost > 0 && metadata.CreationCost >= p.MaxCost {
+		return false
+	}
+	return time.Since(metadata.LastUsed) > p.idleAllowance(metadata)
+}
+
+// Evict menghancurkan objek idle yang memenuhi ShouldEvict, dimulai dari item dengan biaya pembuatan
+// termurah hingga MaxPerRun tercapai.
+// poolType: tipe pool dari mana item akan dihapus
+func (p *CostAwareEvictionPolicy) Evict(poolType string, pm *PoolManager) {
+	type candidate struct {
+		key      string
+		metadata *PoolItemMetadata
+	}
+	var candidates []candidate
+	pm.itemMetadata.Range(func(key, value interface{}) bool {
+		if metadata, ok := value.(*PoolItemMetadata); ok && metadata.PoolName == poolType && metadata.IsPooled && p.ShouldEvict(key.(string), metadata) {
+			candidates = append(candidates, candidate{key: key.(string), metadata: metadata})
+		}
+		return true
+	})
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].metadata.CreationCost < candidates[j].metadata.CreationCost
+	})
+	if p.MaxPerRun > 0 && len(candidates) > p.MaxPerRun {
+		candidates = candidates[:p.MaxPerRun]
+	}
+
+	selected := make(map[string]bool, len(candidates))
+	for _, c := range candidates {
+		selected[c.key] = true
+	}
+	pm.evictIdleWhere(poolType, "idle exceeds cost-adjusted limit", func(key string, metadata *PoolItemMetadata) bool {
+		return selected[key]
+	})
+}
+
+// EvictWhere menghancurkan objek idle di dalam pool yang memenuhi predicate, misalnya
+// "seluruh objek yang dibuat sebelum konfigurasi baru diterapkan". predicate menerima salinan
+// metadata item sehingga perubahan padanya tidak memengaruhi PoolManager. Objek yang sedang
+// dipinjam tidak dieviksi. Mengembalikan jumlah objek yang dieviksi.
+func (pm *PoolManager) EvictWhere(poolName string, predicate func(key string, metadata *PoolItemMetadata) bool) int {
+	if predicate == nil {
+		return 0
+	}
+	evicted := pm.evictIdleWhere(poolName, "predicate", func(key string, metadata *PoolItemMetadata) bool {
+		snapshot := metadata.snapshot()
+		return predicate(key, &snapshot)
+	})
+	if evicted > 0 {
+		pm.logPoolf(InfoLevel, poolName, "Evicted %d items from pool: %s by predicate", evicted, poolName)
+	}
+	return evicted
+}
+
+// evictIdle menghancurkan objek idle di dalam pool yang tidak digunakan lebih lama dari maxIdle.
+// Mengembalikan jumlah objek yang dieviksi.
+func (pm *PoolManager) evictIdle(poolName string, maxIdle time.Duration) int {
+	return pm.evictIdleWhere(poolName, "idle exceeds "+maxIdle.String(), func(key string, metadata *PoolItemMetadata) bool {
+		return time.Since(metadata.LastUsed) > maxIdle
+	})
+}
+
+// evictIdleWhere mengambil objek idle dari pool, menghancurkan objek yang memenuhi shouldEvict,
+// lalu mengembalikan sisanya ke shard asalnya. Objek tanpa metadata per instance selalu dipertahankan.
+// Mengembalikan jumlah objek yang dieviksi.
+func (pm *PoolManager) evictIdleWhere(poolName, reason string, shouldEvict func(key string, metadata *PoolItemMetadata) bool) int {
+	conf, err := pm.getPoolConfiguration(poolName)
+	if err != nil {
+		return 0
+	}
+
+	evicted := 0
+	pm.forEachIdle(poolName, func(instance PoolAble, shard int) bool {
+		key := metadataKey(poolName, instance)
+		metadataVal, found := pm.itemMetadata.Load(key)
+		if key == poolName || !found || !shouldEvict(key, metadataVal.(*PoolItemMetadata)) {
+			return true
+		}
+		pm.evictInstance(poolName, conf, instance, shard, key, reason)
+		evicted++
+		return false
+	})
+	return evicted
+}
+
+// forEachIdle mengambil seluruh objek idle yang terjangkau dari pool dan memanggil visit untuk
+// setiap objek beserta indeks shard-nya (-1 tanpa sharding). Objek yang dipertahankan (visit
+// mengembalikan true) dimasukkan kembali ke shard asalnya setelah shard selesai dipindai.
+// Selama pemindaian, acquire yang berjalan bersamaan dapat melihat pool kosong dan membuat
+// instance baru. Objek di slot privat sync.Pool milik P lain tidak terjangkau dan baru diperiksa
+// pada putaran berikutnya. Mengembalikan false jika pool tidak ditemukan.
+func (pm *PoolManager) forEachIdle(poolName string, visit func(instance PoolAble, shard int) bool) bool {
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		return false
+	}
+
+	shards, ok := idleStores(entry.backend)
+	if !ok {
+		pm.handleError(poolName, NewPoolError(poolName, "scan", errors.New(ErrInvalidNonShardedPoolName)))
+		return false
+	}
+
+	for i, shard := range shards {
+		shardIndex := -1
+		if len(shards) > 1 {
+			shardIndex = i
+		}
+
+		var keep []interface{}
+		for value := shard.Get(); value != nil; value = shard.Get() {
+			instance, ok := value.(PoolAble)
+			if !ok || visit(instance, shardIndex) {
+				keep = append(keep, value)
+			}
+		}
+		for _, value := range keep {
+			shard.Put(value)
+		}
+	}
+	return true
+}
+
+// evictInstance menghancurkan objek idle yang sudah dikeluarkan dari pool dan memperbarui gauge serta metadatanya.
+func (pm *PoolManager) evictInstance(poolName string, conf PoolConfiguration, instance PoolAble, shard int, key, reason string) {
+	lease := pm.lastLeaseID(poolName, instance)
+	pm.adjustGauges(poolName, shard, -1, 0)
+	pm.adjustRetainedBytes(poolName, -estimateSize(instance))
+	pm.recordMetric(poolName, "evict")
+	if err := pm.destroyInstance(poolName, conf, instance); err != nil {
+		pm.handleError(poolName, err)
+	}
+	pm.itemMetadata.Delete(key)
+	pm.triggerCallback(conf.OnEvict, poolName)
+	pm.triggerInstanceCallback(conf.OnEvictInstance, poolName, instance)
+	pm.logPoolf(DebugLevel, poolName, "Evicted idle item from pool: %s, Key: %s, Lease: %s, Reason: %s", poolName, key, lease, reason)
+	pm.tracef("evict pool=%s key=%s lease=%s reason=%s", poolName, key, lease, reason)
+	pm.triggerEvent(PoolEvent{Type: EventEvict, PoolName: poolName, Item: instance, LeaseID: lease})
+}
@@ -4,11 +4,9 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
-	"runtime"
-	"sync"
-	"time"
 
 	poolmanager "github.com/hibbannn/pool-manager"
+	"github.com/hibbannn/pool-manager/poolbench"
 )
 
 // Matrix adalah struktur untuk menyimpan data matriks besar
@@ -63,83 +61,6 @@ func (m *Matrix) Multiply(other *Matrix) *Matrix {
 	return result
 }
 
-// MeasureMemoryUsage mengukur penggunaan memori saat ini
-func MeasureMemoryUsage() uint64 {
-	var m runtime.MemStats
-	runtime.ReadMemStats(&m)
-	return m.Alloc / 1024 // Memori yang digunakan dalam KB
-}
-
-// performMatrixOperationsWithoutPool mensimulasikan operasi matriks tanpa menggunakan pool
-func performMatrixOperationsWithoutPool(numOperations int, matrixSize int) {
-	start := time.Now()
-	initialMemory := MeasureMemoryUsage()
-
-	var wg sync.WaitGroup
-	for i := 0; i < numOperations; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			// Membuat dua matriks baru untuk setiap operasi
-			matrixA := NewMatrix(matrixSize, matrixSize)
-			matrixB := NewMatrix(matrixSize, matrixSize)
-			matrixA.RandomFill()
-			matrixB.RandomFill()
-			// Lakukan perkalian matriks
-			_ = matrixA.Multiply(matrixB)
-		}()
-	}
-	wg.Wait()
-
-	finalMemory := MeasureMemoryUsage()
-	fmt.Printf("Tanpa Pool: Waktu = %v, Memori Digunakan = %d KB\n", time.Since(start), finalMemory-initialMemory)
-}
-
-// performMatrixOperationsWithPool mensimulasikan operasi matriks dengan menggunakan pool
-func performMatrixOperationsWithPool(pm *poolmanager.PoolManager, numOperations int, matrixSize int) {
-	start := time.Now()
-	initialMemory := MeasureMemoryUsage()
-
-	var wg sync.WaitGroup
-	for i := 0; i < numOperations; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			// Mengambil dua matriks dari pool
-			instanceA, err := pm.AcquireInstance("MatrixPool")
-			if err != nil {
-				log.Printf("Error acquiring instance: %v", err)
-				return
-			}
-			instanceB, err := pm.AcquireInstance("MatrixPool")
-			if err != nil {
-				log.Printf("Error acquiring instance: %v", err)
-				return
-			}
-
-			matrixA := instanceA.(*Matrix)
-			matrixB := instanceB.(*Matrix)
-			matrixA.RandomFill()
-			matrixB.RandomFill()
-
-			// Lakukan perkalian matriks
-			_ = matrixA.Multiply(matrixB)
-
-			// Kembalikan matriks ke pool
-			if err := pm.ReleaseInstance("MatrixPool", matrixA); err != nil {
-				log.Printf("Error releasing instance: %v", err)
-			}
-			if err := pm.ReleaseInstance("MatrixPool", matrixB); err != nil {
-				log.Printf("Error releasing instance: %v", err)
-			}
-		}()
-	}
-	wg.Wait()
-
-	finalMemory := MeasureMemoryUsage()
-	fmt.Printf("Dengan PoolManager (Auto-Tune): Waktu = %v, Memori Digunakan = %d KB\n", time.Since(start), finalMemory-initialMemory)
-}
-
 func main() {
 	// Konfigurasi PoolManager
 	config, err := poolmanager.NewPoolConfiguration("MatrixPool").
@@ -152,21 +73,20 @@ func main() {
 		log.Fatalf("Error creating pool configuration: %v", err)
 	}
 
-	// Buat PoolManager baru
-	pm := poolmanager.NewPoolManager(config)
-	err = pm.AddPool("MatrixPool", func() poolmanager.PoolAble {
-		return NewMatrix(100, 100) // Ukuran matriks 100x100
-	}, config)
+	// Bandingkan beban kerja yang sama dengan alokasi langsung dan dengan PoolManager
+	matrixSize := 100
+	fmt.Println("Memulai perbandingan dengan beban kerja berat...")
+	result, err := poolbench.Compare(func() poolmanager.PoolAble {
+		return NewMatrix(matrixSize, matrixSize) // Ukuran matriks 100x100
+	}, func(instance poolmanager.PoolAble) {
+		matrix := instance.(*Matrix)
+		matrix.RandomFill()
+		_ = matrix.Multiply(matrix)
+	}, poolbench.WithOperations(10000), poolbench.WithPoolConfig(config))
 	if err != nil {
-		log.Fatalf("Error adding pool: %v", err)
+		log.Fatalf("Error running comparison: %v", err)
 	}
 
-	// Jumlah operasi dan ukuran matriks untuk simulasi
-	numOperations := 10000
-	matrixSize := 100
-
-	fmt.Println("Memulai perbandingan dengan beban kerja berat...")
-	performMatrixOperationsWithoutPool(numOperations, matrixSize)
-	performMatrixOperationsWithPool(pm, numOperations, matrixSize)
+	fmt.Println(result)
 	fmt.Println("Perbandingan selesai.")
 }
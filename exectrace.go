@@ -0,0 +1,43 @@
+package poolmanager
+
+import (
+	"context"
+	"runtime/trace"
+)
+
+// Nama task dan region runtime/trace yang dipancarkan PoolManager. Setiap region dan task diberi
+// log dengan kategori TraceCategoryPool berisi nama pool, sehingga "go tool trace" dapat
+// menampilkan aktivitas pool berdampingan dengan perilaku scheduler dan GC.
+const (
+	TraceCategoryPool      = "pool"              // Kategori log trace yang berisi nama pool
+	TraceRegionAcquireWait = "pool.acquire.wait" // Region saat acquire menunggu unit MaxActive
+	TraceRegionFactory     = "pool.factory"      // Region pemanggilan factory
+	TraceTaskEviction      = "pool.eviction"     // Task satu putaran eviksi
+	TraceRegionEvictPolicy = "pool.evict.policy" // Region kebijakan eviksi di dalam TraceTaskEviction
+	TraceRegionEvictIdle   = "pool.evict.idle"   // Region eviksi MaxIdleTime di dalam TraceTaskEviction
+	TraceRegionCompact     = "pool.compact"      // Region pemadatan metadata di dalam TraceTaskEviction
+)
+
+// endTrace adalah fungsi penutup kosong yang dipakai saat trace tidak aktif
+func endTrace() {}
+
+// startTraceRegion memulai region runtime/trace untuk operasi pool dan mengembalikan fungsi
+// penutupnya. Tanpa trace yang aktif, fungsi ini tidak melakukan apa pun dan tidak mengalokasikan.
+func startTraceRegion(ctx context.Context, poolName, regionType string) func() {
+	if !trace.IsEnabled() {
+		return endTrace
+	}
+	trace.Log(ctx, TraceCategoryPool, poolName)
+	return trace.StartRegion(ctx, regionType).End
+}
+
+// startTraceTask memulai task runtime/trace untuk operasi pool dan mengembalikan context task
+// beserta fungsi penutupnya. Tanpa trace yang aktif, ctx dikembalikan apa adanya.
+func startTraceTask(ctx context.Context, poolName, taskType string) (context.Context, func()) {
+	if !trace.IsEnabled() {
+		return ctx, endTrace
+	}
+	ctx, task := trace.NewTask(ctx, taskType)
+	trace.Log(ctx, TraceCategoryPool, poolName)
+	return ctx, task.End
+}
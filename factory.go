@@ -0,0 +1,75 @@
+package poolmanager
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// construct memanggil factory pool sambil mengukur durasi pembuatan instance.
+// Pembuatan yang lebih lama dari SlowFactoryThreshold dicatat sebagai peringatan dan
+// memicu EventSlowFactory, sedangkan durasi terlama disimpan pada metrik pool.
+func (pm *PoolManager) construct(poolName string, conf PoolConfiguration, factory func() PoolAble) PoolAble {
+	endRegion := startTraceRegion(context.Background(), poolName, TraceRegionFactory)
+	start := time.Now()
+	instance := factory()
+	elapsed := time.Since(start)
+	endRegion()
+
+	pm.observeConstruction(poolName, elapsed)
+	pm.recordCreationCost(poolName, instance, elapsed)
+	if instance != nil {
+		pm.triggerLifecycle(conf, "create", poolName, instance)
+	}
+	if conf.SlowFactoryThreshold > 0 && elapsed > conf.SlowFactoryThreshold {
+		pm.logPoolf(WarningLevel, poolName, "Slow factory detected in pool: %s, construction took %s (threshold %s)",
+			poolName, elapsed, conf.SlowFactoryThreshold)
+		pm.triggerEvent(PoolEvent{Type: EventSlowFactory, PoolName: poolName, Item: instance, Duration: elapsed})
+	}
+	return instance
+}
+
+// observeConstruction mencatat durasi pembuatan ke histogram latensi factory dan
+// memperbarui durasi pembuatan terlama pada metrik pool
+func (pm *PoolManager) observeConstruction(poolName string, elapsed time.Duration) {
+	metrics := pm.metricsFor(poolName)
+	metrics.FactoryLatency.observe(elapsed)
+	pm.emitDuration(poolName, SinkFactoryDuration, elapsed)
+	for {
+		slowest := atomic.LoadInt64((*int64)(&metrics.SlowestConstruction))
+		if int64(elapsed) <= slowest ||
+			atomic.CompareAndSwapInt64((*int64)(&metrics.SlowestConstruction), slowest, int64(elapsed)) {
+			return
+		}
+	}
+}
+
+// recordCreationCost menyimpan durasi pembuatan instance pada metadata-nya agar kebijakan
+// eviksi dapat mendahulukan instance yang murah untuk dibuat ulang. Instance yang tidak
+// dapat dilacak per objek dilewati karena metadata-nya dipakai bersama.
+func (pm *PoolManager) recordCreationCost(poolName string, instance PoolAble, elapsed time.Duration) {
+	if instance == nil {
+		return
+	}
+	key := instanceKey(poolName, instance)
+	if key == "" {
+		return
+	}
+	pm.safelyUpdateMetadata(key, func(metadata *PoolItemMetadata) {
+		metadata.PoolName = poolName
+		metadata.CreationCost = elapsed
+		metadata.Status = "Idle"
+		metadata.IsPooled = true
+	})
+}
+
+// GetFactoryLatency mengembalikan histogram durasi pemanggilan factory untuk pool tertentu.
+// Histogram ini membantu menentukan MinSize atau InitialSize berdasarkan mahalnya pembuatan instance.
+func (pm *PoolManager) GetFactoryLatency(poolName string) (LatencyHistogram, error) {
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		return LatencyHistogram{}, errors.New("metrics not found for pool: " + poolName)
+	}
+	return entry.metrics.Load().FactoryLatency.snapshot(), nil
+}
@@ -0,0 +1,117 @@
+package poolmanager
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	forecastAlpha      = 0.5              // Bobot pemulusan level pada metode Holt
+	forecastBeta       = 0.3              // Bobot pemulusan tren pada metode Holt
+	forecastHeadroom   = 1.25             // Ruang di atas permintaan yang diprediksi, sama dengan CapacityReport
+	forecastMinSamples = 3                // Jumlah menit minimum sebelum auto-tuner memakai ramalan
+	forecastMinHistory = 30 * time.Minute // Panjang riwayat minimum yang digunakan ramalan
+)
+
+// DemandForecast adalah ramalan permintaan sebuah pool pada akhir horizon tertentu, dihitung dari
+// riwayat puncak objek dipinjam per menit yang juga dipakai CapacityReport.
+type DemandForecast struct {
+	PoolName         string        `json:"pool_name"`
+	GeneratedAt      time.Time     `json:"generated_at"`
+	Horizon          time.Duration `json:"horizon"`
+	History          time.Duration `json:"history"`            // Panjang riwayat yang digunakan
+	Samples          int           `json:"samples"`            // Jumlah menit riwayat yang digunakan (0 jika belum ada trafik)
+	CurrentDemand    float64       `json:"current_demand"`     // Level puncak objek dipinjam setelah pemulusan
+	Trend            float64       `json:"trend_per_minute"`   // Perubahan permintaan per menit
+	PredictedDemand  float64       `json:"predicted_demand"`   // Perkiraan puncak objek dipinjam pada akhir horizon
+	PredictedAcquire float64       `json:"predicted_acquires"` // Perkiraan jumlah acquire per menit pada akhir horizon
+	RecommendedSize  int           `json:"recommended_size"`   // Ukuran pool yang disarankan, sebelum dibatasi MinSize/MaxSize
+}
+
+// Forecast meramalkan permintaan pool pada horizon tertentu menggunakan pemulusan eksponensial
+// ganda (metode Holt) atas riwayat per menit. Riwayat yang digunakan adalah empat kali horizon,
+// minimal 30 menit dan maksimal 24 jam. Hasilnya dapat dipakai autoscaler eksternal maupun
+// auto-tuner internal (lihat AutoTuneForecast).
+func (pm *PoolManager) Forecast(poolName string, horizon time.Duration) (DemandForecast, error) {
+	entry, err := pm.lookupEntry(poolName, "forecast")
+	if err != nil {
+		return DemandForecast{}, err
+	}
+	if horizon < 0 {
+		horizon = 0
+	}
+
+	history := 4 * horizon
+	if history < forecastMinHistory {
+		history = forecastMinHistory
+	}
+	if limit := capacityBucketCount * capacityBucketWidth; history > limit {
+		history = limit
+	}
+
+	forecast := DemandForecast{PoolName: poolName, GeneratedAt: time.Now(), Horizon: horizon, History: history}
+	var demand, acquires []float64
+	if recorderVal, ok := pm.capacity.Load(poolName); ok {
+		demand, acquires = recorderVal.(*capacityRecorder).series(forecast.GeneratedAt, history)
+	}
+	forecast.Samples = len(demand)
+	if forecast.Samples == 0 {
+		forecast.RecommendedSize = entry.configuration().MinSize
+		return forecast, nil
+	}
+
+	steps := horizon.Minutes()
+	level, trend := holt(demand)
+	forecast.CurrentDemand = level
+	forecast.Trend = trend
+	forecast.PredictedDemand = math.Max(0, level+trend*steps)
+	acquireLevel, acquireTrend := holt(acquires)
+	forecast.PredictedAcquire = math.Max(0, acquireLevel+acquireTrend*steps)
+	forecast.RecommendedSize = int(math.Ceil(forecast.PredictedDemand * forecastHeadroom))
+	return forecast, nil
+}
+
+// holt menerapkan pemulusan eksponensial ganda dan mengembalikan level serta tren terakhir
+func holt(series []float64) (level, trend float64) {
+	level = series[0]
+	for _, value := range series[1:] {
+		previous := level
+		level = forecastAlpha*value + (1-forecastAlpha)*(level+trend)
+		trend = forecastBeta*(level-previous) + (1-forecastBeta)*trend
+	}
+	return level, trend
+}
+
+// series mengembalikan puncak objek dipinjam dan jumlah acquire per menit dalam window, dimulai
+// dari menit pertama yang memiliki trafik. Menit tanpa trafik di antaranya bernilai nol.
+func (r *capacityRecorder) series(now time.Time, window time.Duration) (demand, acquires []float64) {
+	buckets := r.collect(now, window)
+	if len(buckets) == 0 {
+		return nil, nil
+	}
+
+	current := now.Unix() / int64(capacityBucketWidth/time.Second)
+	first := current
+	for _, b := range buckets {
+		if b.minute < first {
+			first = b.minute
+		}
+	}
+	demand = make([]float64, current-first+1)
+	acquires = make([]float64, current-first+1)
+	for _, b := range buckets {
+		demand[b.minute-first] = float64(b.peakInUse)
+		acquires[b.minute-first] = float64(b.acquires)
+	}
+	return demand, acquires
+}
+
+// forecastSize mengembalikan ukuran pool yang disarankan ramalan untuk auto-tuner.
+// Mengembalikan false jika riwayat belum cukup sehingga auto-tuner memakai faktor biasa.
+func (pm *PoolManager) forecastSize(poolName string, horizon time.Duration) (int, bool) {
+	forecast, err := pm.Forecast(poolName, horizon)
+	if err != nil || forecast.Samples < forecastMinSamples {
+		return 0, false
+	}
+	return forecast.RecommendedSize, true
+}
@@ -0,0 +1,177 @@
+package poolmanager
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ShardMetrics menyimpan gauge objek idle dan objek yang sedang dipinjam untuk satu shard.
+type ShardMetrics struct {
+	IdleCount  int64 // Jumlah objek idle di dalam shard
+	InUseCount int64 // Jumlah objek dari shard ini yang sedang dipinjam
+}
+
+// metricsFor mengambil metrik pool. Untuk pool yang tidak terdaftar dikembalikan metrik kosong
+// yang tidak disimpan, sehingga pencatatan setelah RemovePool dibuang begitu saja.
+func (pm *PoolManager) metricsFor(poolName string) *PoolMetrics {
+	if entry, ok := pm.entryFor(poolName); ok {
+		return entry.metrics.Load()
+	}
+	return newPoolMetrics(0)
+}
+
+// loadMetrics mengambil metrik pool yang terdaftar untuk dibaca oleh getter publik
+func (pm *PoolManager) loadMetrics(poolName string) (*PoolMetrics, error) {
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		return nil, errors.New("metrics not found for pool: " + poolName)
+	}
+	return entry.metrics.Load(), nil
+}
+
+// adjustGauges memperbarui gauge idle dan in-use untuk pool dan, jika diketahui, shard-nya.
+// shard: indeks shard (-1 jika pool tanpa sharding atau shard tidak diketahui)
+// idleDelta: perubahan jumlah objek idle
+// inUseDelta: perubahan jumlah objek yang sedang dipinjam
+func (pm *PoolManager) adjustGauges(poolName string, shard int, idleDelta, inUseDelta int64) {
+	metrics := pm.metricsFor(poolName)
+	if metrics == nil {
+		return
+	}
+
+	if idleDelta != 0 {
+		atomic.AddInt64(&metrics.IdleCount, idleDelta)
+	}
+	if inUseDelta != 0 {
+		inUse := atomic.AddInt64(&metrics.InUseCount, inUseDelta)
+		if inUseDelta > 0 {
+			pm.observeAcquireCapacity(poolName, inUse)
+		}
+	}
+
+	if shard >= 0 && shard < len(metrics.Shards) {
+		if idleDelta != 0 {
+			atomic.AddInt64(&metrics.Shards[shard].IdleCount, idleDelta)
+		}
+		if inUseDelta != 0 {
+			atomic.AddInt64(&metrics.Shards[shard].InUseCount, inUseDelta)
+		}
+	}
+
+	if inUseDelta < 0 {
+		pm.checkInUseGauge(poolName, shard, metrics)
+	}
+
+	if idleDelta != 0 {
+		pm.emitGauge(poolName, SinkIdle, float64(atomic.LoadInt64(&metrics.IdleCount)))
+	}
+	if inUseDelta != 0 {
+		pm.emitGauge(poolName, SinkInUse, float64(atomic.LoadInt64(&metrics.InUseCount)))
+	}
+}
+
+// resetIdleGauge dipanggil saat pool atau shard ternyata kosong. sync.Pool dapat membuang
+// objek idle saat GC tanpa pemberitahuan, sehingga gauge idle diselaraskan kembali ke nol.
+func (pm *PoolManager) resetIdleGauge(poolName string, shard int) {
+	metrics := pm.metricsFor(poolName)
+	if metrics == nil {
+		return
+	}
+
+	if len(metrics.Shards) == 0 {
+		atomic.StoreInt64(&metrics.IdleCount, 0)
+		return
+	}
+	if shard >= 0 && shard < len(metrics.Shards) {
+		if stale := atomic.SwapInt64(&metrics.Shards[shard].IdleCount, 0); stale > 0 {
+			if atomic.AddInt64(&metrics.IdleCount, -stale) < 0 {
+				atomic.StoreInt64(&metrics.IdleCount, 0)
+			}
+		}
+	}
+}
+
+// poolSize mengembalikan jumlah objek idle dan objek yang sedang dipinjam pool menurut gauge
+// IdleCount dan InUseCount. Pool yang tidak terdaftar berukuran 0.
+func (pm *PoolManager) poolSize(poolName string) (idle, inUse int) {
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		return 0, 0
+	}
+	metrics := entry.metrics.Load()
+	return gaugeCount(&metrics.IdleCount), gaugeCount(&metrics.InUseCount)
+}
+
+// shardSize mengembalikan jumlah objek idle dan objek yang sedang dipinjam satu shard menurut gauge
+// shard. Shard yang tidak ada berukuran 0.
+func (pm *PoolManager) shardSize(poolName string, shard int) (idle, inUse int) {
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		return 0, 0
+	}
+	metrics := entry.metrics.Load()
+	if shard < 0 || shard >= len(metrics.Shards) {
+		return 0, 0
+	}
+	return gaugeCount(&metrics.Shards[shard].IdleCount), gaugeCount(&metrics.Shards[shard].InUseCount)
+}
+
+// gaugeCount membaca gauge sebagai jumlah objek yang tidak pernah negatif
+func gaugeCount(gauge *int64) int {
+	if n := atomic.LoadInt64(gauge); n > 0 {
+		return int(n)
+	}
+	return 0
+}
+
+// GetIdleCount mengembalikan jumlah objek idle yang diperkirakan berada di dalam pool.
+func (pm *PoolManager) GetIdleCount(poolName string) (int64, error) {
+	metrics, err := pm.loadMetrics(poolName)
+	if err != nil {
+		return 0, err
+	}
+	return atomic.LoadInt64(&metrics.IdleCount), nil
+}
+
+// GetInUseCount mengembalikan jumlah objek dari pool yang sedang dipinjam.
+func (pm *PoolManager) GetInUseCount(poolName string) (int64, error) {
+	metrics, err := pm.loadMetrics(poolName)
+	if err != nil {
+		return 0, err
+	}
+	return atomic.LoadInt64(&metrics.InUseCount), nil
+}
+
+// GetWaiterCount mengembalikan jumlah goroutine yang sedang menunggu unit MaxActive pada pool.
+func (pm *PoolManager) GetWaiterCount(poolName string) (int64, error) {
+	metrics, err := pm.loadMetrics(poolName)
+	if err != nil {
+		return 0, err
+	}
+	return atomic.LoadInt64(&metrics.Waiters), nil
+}
+
+// GetShardMetrics mengembalikan salinan gauge per shard untuk pool yang menggunakan sharding.
+// Mengembalikan slice kosong jika pool tidak menggunakan sharding.
+func (pm *PoolManager) GetShardMetrics(poolName string) ([]ShardMetrics, error) {
+	metrics, err := pm.loadMetrics(poolName)
+	if err != nil {
+		return nil, err
+	}
+	return metrics.snapshotShards(), nil
+}
+
+// snapshotShards membaca gauge setiap shard secara atomik.
+func (m *PoolMetrics) snapshotShards() []ShardMetrics {
+	if len(m.Shards) == 0 {
+		return nil
+	}
+	shards := make([]ShardMetrics, len(m.Shards))
+	for i := range m.Shards {
+		shards[i] = ShardMetrics{
+			IdleCount:  atomic.LoadInt64(&m.Shards[i].IdleCount),
+			InUseCount: atomic.LoadInt64(&m.Shards[i].InUseCount),
+		}
+	}
+	return shards
+}
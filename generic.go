@@ -0,0 +1,43 @@
+package poolmanager
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AcquireAs mengambil instance dari pool dan mengonversinya ke tipe T dalam satu langkah.
+// Jika instance bukan bertipe T, instance dikembalikan ke pool dan error yang membungkus
+// TypeMismatchError dikembalikan, sehingga pemanggil tidak perlu melakukan type assertion sendiri.
+func AcquireAs[T PoolAble](pm *PoolManager, name string, opts ...AcquireOption) (T, error) {
+	var zero T
+	instance, err := pm.AcquireInstance(name, opts...)
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := instance.(T)
+	if !ok {
+		if releaseErr := pm.ReleaseInstance(name, instance); releaseErr != nil {
+			pm.handleError(name, releaseErr)
+		}
+		return zero, NewPoolError(name, "get", &TypeMismatchError{
+			Expected: typeString[T](),
+			Actual:   fmt.Sprintf("%T", instance),
+		})
+	}
+	return typed, nil
+}
+
+// ReleaseAs mengembalikan instance bertipe T ke pool.
+// Instance nil (termasuk pointer nil bertipe) ditolak dengan error yang menyebutkan tipenya.
+func ReleaseAs[T PoolAble](pm *PoolManager, name string, instance T) error {
+	if v := reflect.ValueOf(instance); !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return NewPoolError(name, "put", fmt.Errorf("cannot put nil %s into pool", typeString[T]()))
+	}
+	return pm.ReleaseInstance(name, instance)
+}
+
+// typeString mengembalikan nama tipe T, termasuk untuk tipe interface
+func typeString[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
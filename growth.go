@@ -0,0 +1,163 @@
+package poolmanager
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// defaultGrowthWindow adalah jendela pengukuran laju pembuatan jika RateWindow tidak diatur
+const defaultGrowthWindow = time.Second
+
+// GrowthGuardConfig melindungi pool dari pertumbuhan tak terkendali, misalnya karena release
+// terlupa atau auto-tuning yang salah arah. Hanya instance yang dibuat factory saat acquire yang
+// dihitung; pengisian awal dan resize mengikuti konfigurasi sehingga tidak dibatasi.
+type GrowthGuardConfig struct {
+	MaxCreated    int64         // Batas keras total instance yang dibuat sejak pool didaftarkan atau ResetGrowthGuard (0 = tanpa batas)
+	MaxCreateRate int64         // Batas pembuatan per RateWindow sebelum peringatan dikirim (0 = tanpa batas)
+	RateWindow    time.Duration // Jendela pengukuran MaxCreateRate (0 = 1 detik)
+	FailFast      bool          // Pindahkan pool ke mode fail-fast saat guard terpicu hingga ResetGrowthGuard dipanggil
+}
+
+// GrowthReason menunjukkan batas GrowthGuardConfig yang terlampaui
+type GrowthReason string
+
+const (
+	GrowthHardCap GrowthReason = "hard_cap" // Total instance yang dibuat mencapai MaxCreated
+	GrowthRate    GrowthReason = "rate"     // Laju pembuatan melebihi MaxCreateRate
+)
+
+// GrowthAlert dikirim saat pool tumbuh melampaui batas GrowthGuardConfig
+type GrowthAlert struct {
+	PoolName      string        // Nama pool yang tumbuh tak terkendali
+	Reason        GrowthReason  // Batas yang terlampaui
+	Created       int64         // Total instance yang dibuat sejak pool didaftarkan atau ResetGrowthGuard
+	WindowCreated int64         // Jumlah permintaan pembuatan pada jendela saat ini, termasuk yang ditolak
+	Window        time.Duration // Panjang jendela pengukuran laju
+	FailFast      bool          // Apakah pool kini berada dalam mode fail-fast
+}
+
+// GrowthStatus adalah keadaan growth guard sebuah pool
+type GrowthStatus struct {
+	Created       int64 // Total instance yang dibuat sejak pool didaftarkan atau ResetGrowthGuard
+	WindowCreated int64 // Jumlah permintaan pembuatan pada jendela saat ini, termasuk yang ditolak
+	FailFast      bool  // Apakah pembuatan instance baru sedang ditolak
+}
+
+// growthGuard menyimpan penghitung pembuatan instance milik satu pool
+type growthGuard struct {
+	created     atomic.Int64 // Total pembuatan sejak pool didaftarkan atau di-reset
+	windowStart atomic.Int64 // Awal jendela laju dalam UnixNano
+	windowCount atomic.Int64 // Jumlah permintaan pembuatan pada jendela saat ini
+	failFast    atomic.Bool  // Pembuatan ditolak hingga operator memanggil ResetGrowthGuard
+	capAlerted  atomic.Bool  // Peringatan batas keras sudah dikirim sejak reset terakhir
+}
+
+// admit mencatat satu pembuatan instance dan memeriksa batas guard. Mengembalikan peringatan jika
+// batas baru saja terlampaui, dan error jika pembuatan harus ditolak.
+func (g *growthGuard) admit(poolName string, guard *GrowthGuardConfig, now time.Time) (*GrowthAlert, error) {
+	if g.failFast.Load() {
+		return nil, ErrPoolFailFast
+	}
+
+	created := g.created.Add(1)
+	if guard.MaxCreated > 0 && created > guard.MaxCreated {
+		g.created.Add(-1)
+		if guard.FailFast {
+			g.failFast.Store(true)
+		}
+		var alert *GrowthAlert
+		if g.capAlerted.CompareAndSwap(false, true) {
+			alert = g.alert(poolName, GrowthHardCap, guard, created-1)
+		}
+		return alert, ErrGrowthLimitExceeded
+	}
+
+	window := guard.RateWindow
+	if window <= 0 {
+		window = defaultGrowthWindow
+	}
+	if start := g.windowStart.Load(); now.UnixNano()-start >= int64(window) && g.windowStart.CompareAndSwap(start, now.UnixNano()) {
+		g.windowCount.Store(0)
+	}
+	windowCreated := g.windowCount.Add(1)
+	if guard.MaxCreateRate <= 0 || windowCreated <= guard.MaxCreateRate {
+		return nil, nil
+	}
+
+	// Peringatan laju hanya dikirim sekali per jendela, tepat saat batas pertama kali terlampaui
+	var alert *GrowthAlert
+	if guard.FailFast {
+		g.created.Add(-1)
+		if g.failFast.CompareAndSwap(false, true) {
+			alert = g.alert(poolName, GrowthRate, guard, created-1)
+		}
+		return alert, ErrPoolFailFast
+	}
+	if windowCreated == guard.MaxCreateRate+1 {
+		alert = g.alert(poolName, GrowthRate, guard, created)
+	}
+	return alert, nil
+}
+
+// alert membentuk GrowthAlert dari keadaan guard saat ini
+func (g *growthGuard) alert(poolName string, reason GrowthReason, guard *GrowthGuardConfig, created int64) *GrowthAlert {
+	window := guard.RateWindow
+	if window <= 0 {
+		window = defaultGrowthWindow
+	}
+	return &GrowthAlert{
+		PoolName:      poolName,
+		Reason:        reason,
+		Created:       created,
+		WindowCreated: g.windowCount.Load(),
+		Window:        window,
+		FailFast:      g.failFast.Load(),
+	}
+}
+
+// guardGrowth memeriksa growth guard pool sebelum factory dipanggil saat acquire dan mengirim
+// peringatan melalui log, EventRunawayGrowth, dan MonitoringConfig.OnRunawayGrowth.
+func (pm *PoolManager) guardGrowth(entry *poolEntry, conf PoolConfiguration) error {
+	if conf.GrowthGuard == nil {
+		return nil
+	}
+	alert, err := entry.growth.admit(entry.name, conf.GrowthGuard, time.Now())
+	if alert != nil {
+		pm.logPoolf(WarningLevel, entry.name, "Runaway growth detected in pool: %s, Reason: %s, created %d (%d in the last %s), fail-fast: %t",
+			entry.name, alert.Reason, alert.Created, alert.WindowCreated, alert.Window, alert.FailFast)
+		pm.triggerEvent(PoolEvent{Type: EventRunawayGrowth, PoolName: entry.name, Growth: alert})
+		if onRunawayGrowth := pm.monitoring().OnRunawayGrowth; onRunawayGrowth != nil {
+			onRunawayGrowth(*alert)
+		}
+	}
+	return err
+}
+
+// GrowthStatus mengembalikan keadaan growth guard pool
+func (pm *PoolManager) GrowthStatus(poolName string) (GrowthStatus, error) {
+	entry, err := pm.lookupEntry(poolName, "growth")
+	if err != nil {
+		return GrowthStatus{}, err
+	}
+	return GrowthStatus{
+		Created:       entry.growth.created.Load(),
+		WindowCreated: entry.growth.windowCount.Load(),
+		FailFast:      entry.growth.failFast.Load(),
+	}, nil
+}
+
+// ResetGrowthGuard mengeluarkan pool dari mode fail-fast dan mengosongkan penghitung pembuatan.
+// Dipanggil oleh operator setelah penyebab pertumbuhan tak terkendali ditangani.
+func (pm *PoolManager) ResetGrowthGuard(poolName string) error {
+	entry, err := pm.lookupEntry(poolName, "growth")
+	if err != nil {
+		return err
+	}
+	entry.growth.created.Store(0)
+	entry.growth.windowCount.Store(0)
+	entry.growth.windowStart.Store(0)
+	entry.growth.capAlerted.Store(false)
+	entry.growth.failFast.Store(false)
+	pm.logPoolf(InfoLevel, poolName, "Growth guard reset for pool: %s", poolName)
+	return nil
+}
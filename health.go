@@ -0,0 +1,182 @@
+package poolmanager
+
+import (
+	"errors"
+	"time"
+)
+
+// HealthScoringConfig memberi setiap instance skor kesehatan antara 0 dan 1 yang turun karena
+// kegagalan validasi, MarkBroken, dan usia, lalu naik kembali setiap kali instance dikembalikan
+// dalam keadaan sehat. Acquire mengutamakan instance dengan skor lebih tinggi, dan instance yang
+// skornya di bawah RetireBelow dihancurkan. Dengan HealthScoring, MarkBroken menurunkan skor
+// alih-alih langsung menghancurkan instance.
+type HealthScoringConfig struct {
+	Validate     func(instance PoolAble) error // Validasi instance saat dikembalikan; error menurunkan skor (nil = tanpa validasi)
+	ErrorPenalty float64                       // Penurunan skor per kegagalan validasi atau MarkBroken (0 = 0.25)
+	Recovery     float64                       // Kenaikan skor per pengembalian yang sehat (0 = 0.05)
+	MaxAge       time.Duration                 // Usia saat skor mencapai 0; skor turun linear seiring usia (0 = usia diabaikan)
+	RetireBelow  float64                       // Instance dengan skor di bawah nilai ini dihancurkan (0 = 0.25)
+	PreferAbove  float64                       // Acquire mencari kandidat lain jika skor instance di bawah nilai ini (0 = 0.75)
+	Candidates   int                           // Jumlah maksimal instance idle yang dibandingkan per acquire (0 = 3)
+}
+
+// Nilai bawaan HealthScoringConfig
+const (
+	defaultHealthErrorPenalty = 0.25
+	defaultHealthRecovery     = 0.05
+	defaultHealthRetireBelow  = 0.25
+	defaultHealthPreferAbove  = 0.75
+	defaultHealthCandidates   = 3
+)
+
+// withDefaults mengembalikan salinan konfigurasi dengan nilai bawaan untuk field yang kosong
+func (h HealthScoringConfig) withDefaults() HealthScoringConfig {
+	if h.ErrorPenalty == 0 {
+		h.ErrorPenalty = defaultHealthErrorPenalty
+	}
+	if h.Recovery == 0 {
+		h.Recovery = defaultHealthRecovery
+	}
+	if h.RetireBelow == 0 {
+		h.RetireBelow = defaultHealthRetireBelow
+	}
+	if h.PreferAbove == 0 {
+		h.PreferAbove = defaultHealthPreferAbove
+	}
+	if h.Candidates <= 0 {
+		h.Candidates = defaultHealthCandidates
+	}
+	return h
+}
+
+// score menghitung skor dari akumulasi penalti dan usia instance
+func (h HealthScoringConfig) score(penalty float64, created, now time.Time) float64 {
+	score := 1 - penalty
+	if h.MaxAge > 0 && !created.IsZero() {
+		score *= 1 - float64(now.Sub(created))/float64(h.MaxAge)
+	}
+	return clampScore(score)
+}
+
+// clampScore membatasi nilai pada rentang 0 hingga 1
+func clampScore(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return v
+}
+
+// healthScore mengembalikan skor kesehatan instance. Instance tanpa metadata dianggap sehat.
+func (pm *PoolManager) healthScore(poolName string, health HealthScoringConfig, instance PoolAble, now time.Time) float64 {
+	metadataVal, ok := pm.itemMetadata.Load(metadataKey(poolName, instance))
+	if !ok {
+		return 1
+	}
+	metadata := metadataVal.(*PoolItemMetadata)
+	return health.score(metadata.HealthPenalty, metadata.CreationTime, now)
+}
+
+// adjustHealth menambah (atau mengurangi jika negatif) penalti kesehatan instance
+func (pm *PoolManager) adjustHealth(poolName string, instance PoolAble, delta float64) {
+	pm.safelyUpdateMetadata(metadataKey(poolName, instance), func(metadata *PoolItemMetadata) {
+		metadata.HealthPenalty = clampScore(metadata.HealthPenalty + delta)
+	})
+}
+
+// assessHealth memperbarui skor instance yang dikembalikan berdasarkan hasil validasi, lalu
+// mengembalikan skor terbarunya. Instance yang ditandai rusak tidak mendapat pemulihan.
+func (pm *PoolManager) assessHealth(poolName string, health HealthScoringConfig, instance PoolAble, broken bool) float64 {
+	switch {
+	case health.Validate != nil:
+		if err := health.Validate(instance); err != nil {
+			pm.adjustHealth(poolName, instance, health.ErrorPenalty)
+			pm.logPoolf(DebugLevel, poolName, "Instance failed validation in pool: %s, Key: %s, Error: %v",
+				poolName, metadataKey(poolName, instance), err)
+		} else if !broken {
+			pm.adjustHealth(poolName, instance, -health.Recovery)
+		}
+	case !broken:
+		pm.adjustHealth(poolName, instance, -health.Recovery)
+	}
+	return pm.healthScore(poolName, health, instance, time.Now())
+}
+
+// heldCandidate adalah instance idle yang sedang dibandingkan oleh selectHealthy
+type heldCandidate struct {
+	instance PoolAble
+	shard    int
+}
+
+// selectHealthy membandingkan instance idle hasil getInstanceFromPool dengan hingga Candidates
+// instance idle lain dan mengembalikan yang skornya tertinggi beserta shard asalnya. Instance yang
+// skornya di bawah RetireBelow dihancurkan, dan kandidat lain dikembalikan ke shard asalnya.
+// Mengembalikan nil jika pool kosong atau seluruh instance idle dipensiunkan.
+func (pm *PoolManager) selectHealthy(entry *poolEntry, conf PoolConfiguration, value interface{}, shard int, shardKey string) (interface{}, int) {
+	poolName, health := entry.name, conf.Health.withDefaults()
+	now := time.Now()
+
+	var best heldCandidate
+	bestScore := -1.0
+	var spare []heldCandidate
+	for value != nil {
+		candidate, ok := value.(PoolAble)
+		if !ok {
+			break
+		}
+		if score := pm.healthScore(poolName, health, candidate, now); score < health.RetireBelow {
+			pm.logPoolf(InfoLevel, poolName, "Retired instance from pool: %s, Key: %s, Health score: %.2f",
+				poolName, metadataKey(poolName, candidate), score)
+			pm.recordMetric(poolName, "discard")
+			pm.discardFromPool(poolName, shard, candidate)
+			pm.itemMetadata.Delete(metadataKey(poolName, candidate))
+		} else {
+			if score > bestScore {
+				if best.instance != nil {
+					spare = append(spare, best)
+				}
+				best, bestScore = heldCandidate{instance: candidate, shard: shard}, score
+			} else {
+				spare = append(spare, heldCandidate{instance: candidate, shard: shard})
+			}
+			if bestScore >= health.PreferAbove || len(spare)+1 >= health.Candidates {
+				break
+			}
+		}
+
+		var err error
+		if value, shard, err = pm.getInstanceFromPool(poolName, entry.backend, conf, shardKey); err != nil {
+			break
+		}
+	}
+
+	// Kandidat dikembalikan setelah pemilihan selesai agar tidak terambil ulang dari sync.Pool
+	for _, held := range spare {
+		if _, err := pm.putInstanceToPool(poolName, entry.backend, conf, held.instance, held.shard); err != nil {
+			pm.handleError(poolName, err)
+		}
+	}
+	if best.instance == nil {
+		if value != nil {
+			// Nilai yang bukan PoolAble diteruskan agar ditangani seperti biasa oleh acquire
+			return value, shard
+		}
+		return nil, shard
+	}
+	return best.instance, best.shard
+}
+
+// InstanceHealth mengembalikan skor kesehatan instance antara 0 dan 1 pada pool dengan
+// HealthScoring.
+func (pm *PoolManager) InstanceHealth(poolName string, instance PoolAble) (float64, error) {
+	conf, err := pm.getPoolConfiguration(poolName)
+	if err != nil {
+		return 0, err
+	}
+	if conf.Health == nil {
+		return 0, NewPoolError(poolName, "health", errors.New("health scoring is not enabled"))
+	}
+	return pm.healthScore(poolName, conf.Health.withDefaults(), pm.originalInstance(poolName, instance), time.Now()), nil
+}
@@ -0,0 +1,82 @@
+package poolmanager
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// LatencyBucketBounds adalah batas atas (inklusif) setiap bucket LatencyHistogram.
+// Observasi yang melebihi batas terakhir dicatat pada bucket overflow.
+var LatencyBucketBounds = [...]time.Duration{
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+}
+
+// LatencyHistogram adalah histogram durasi dengan bucket tetap sesuai LatencyBucketBounds.
+// Buckets[i] menghitung observasi dengan durasi <= LatencyBucketBounds[i]; bucket terakhir
+// menghitung observasi yang lebih lama dari seluruh batas.
+type LatencyHistogram struct {
+	Buckets [len(LatencyBucketBounds) + 1]int64 // Jumlah observasi per bucket
+	Count   int64                               // Total jumlah observasi
+	Sum     time.Duration                       // Total durasi seluruh observasi
+}
+
+// observe mencatat satu durasi ke histogram secara atomik
+func (h *LatencyHistogram) observe(d time.Duration) {
+	bucket := len(LatencyBucketBounds)
+	for i, bound := range LatencyBucketBounds {
+		if d <= bound {
+			bucket = i
+			break
+		}
+	}
+	atomic.AddInt64(&h.Buckets[bucket], 1)
+	atomic.AddInt64(&h.Count, 1)
+	atomic.AddInt64((*int64)(&h.Sum), int64(d))
+}
+
+// snapshot membaca histogram secara atomik dan mengembalikan salinannya
+func (h *LatencyHistogram) snapshot() LatencyHistogram {
+	var snap LatencyHistogram
+	for i := range h.Buckets {
+		snap.Buckets[i] = atomic.LoadInt64(&h.Buckets[i])
+	}
+	snap.Count = atomic.LoadInt64(&h.Count)
+	snap.Sum = time.Duration(atomic.LoadInt64((*int64)(&h.Sum)))
+	return snap
+}
+
+// Mean mengembalikan rata-rata durasi observasi
+func (h LatencyHistogram) Mean() time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	return h.Sum / time.Duration(h.Count)
+}
+
+// Quantile mengembalikan perkiraan kuantil q (0..1) berupa batas atas bucket yang memuat
+// kuantil tersebut. Mengembalikan -1 jika kuantil jatuh pada bucket overflow.
+func (h LatencyHistogram) Quantile(q float64) time.Duration {
+	if h.Count == 0 {
+		return 0
+	}
+	target := int64(q * float64(h.Count))
+	if target < 1 {
+		target = 1
+	}
+	var seen int64
+	for i, count := range h.Buckets {
+		seen += count
+		if seen >= target {
+			if i < len(LatencyBucketBounds) {
+				return LatencyBucketBounds[i]
+			}
+			break
+		}
+	}
+	return -1
+}
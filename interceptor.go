@@ -0,0 +1,140 @@
+package poolmanager
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// InstanceInterceptor dapat membungkus atau mengubah instance sebelum diserahkan ke pemanggil,
+// misalnya membungkus koneksi pool dengan proxy yang mencatat metrik. PoolManager mencatat
+// hubungan antara pembungkus dan instance asli sehingga ReleaseInstance dengan pembungkus
+// tetap mengembalikan objek asli ke pool. Catatan ini dilepas saat peminjaman selesai dengan cara
+// apa pun (release, discard, lease berakhir, atau AcquireBound) dan saat pembungkus yang bocor di-GC.
+type InstanceInterceptor struct {
+	// Wrap dipanggil saat acquire dan mengembalikan instance yang diserahkan ke pemanggil.
+	// Pembungkus harus berupa pointer (atau tipe referensi lain) agar identitasnya dapat dilacak.
+	Wrap func(poolName string, instance PoolAble) PoolAble
+	// Unwrap dipanggil saat pembungkus dikembalikan, sebelum objek asli masuk ke pool (opsional)
+	Unwrap func(poolName string, wrapped PoolAble)
+}
+
+// wrappedInstance mencatat objek asli dari sebuah pembungkus beserta interseptor yang membuatnya
+type wrappedInstance struct {
+	poolName     string
+	original     PoolAble
+	interceptors []InstanceInterceptor
+	seq          uint64          // Nomor urut catatan, membedakan pembungkus berbeda pada alamat yang sama
+	cleanup      runtime.Cleanup // Cleanup yang menghapus catatan saat pembungkus di-GC (nol jika tidak terpasang)
+}
+
+// wrapperRef mengidentifikasi catatan pembungkus tanpa mereferensikan objek aslinya, sehingga cleanup
+// pembungkus tidak membuat objek asli tetap terjangkau
+type wrapperRef struct {
+	key string
+	seq uint64
+}
+
+// wrapperSeq adalah sumber nomor urut wrappedInstance
+var wrapperSeq atomic.Uint64
+
+// interceptAcquire menerapkan interseptor pool secara berurutan pada instance yang akan diserahkan.
+// Jika pembungkus akhir tidak dapat dilacak, instance asli diserahkan agar release tetap benar.
+func (pm *PoolManager) interceptAcquire(poolName string, conf PoolConfiguration, instance PoolAble) PoolAble {
+	if len(conf.Interceptors) == 0 {
+		return instance
+	}
+
+	wrapped := instance
+	for _, interceptor := range conf.Interceptors {
+		if interceptor.Wrap == nil {
+			continue
+		}
+		if next := interceptor.Wrap(poolName, wrapped); next != nil {
+			wrapped = next
+		}
+	}
+	if wrapped == instance {
+		return instance
+	}
+
+	key := instanceKey(poolName, wrapped)
+	if key == "" {
+		pm.logPoolf(WarningLevel, poolName, "Interceptor for pool: %s returned untrackable %T, handing out the original instance",
+			poolName, wrapped)
+		return instance
+	}
+	record := &wrappedInstance{poolName: poolName, original: instance, interceptors: conf.Interceptors, seq: wrapperSeq.Add(1)}
+	// Catatan menyimpan objek asli; cleanup menghapusnya saat pembungkus yang bocor di-GC agar
+	// objek asli ikut tidak terjangkau dan LeakFinalizer dapat melaporkannya
+	record.cleanup, _ = attachCleanup(wrapped, pm.forgetCollectedWrapper, wrapperRef{key: key, seq: record.seq})
+	pm.wrapped.Store(key, record)
+	if checkout := pm.checkoutFor(poolName, instance); checkout != nil {
+		checkout.wrapper.Store(&key)
+	}
+	return wrapped
+}
+
+// forgetWrapper menghapus catatan pembungkus milik peminjaman yang diselesaikan tanpa pembungkusnya,
+// misalnya saat lease berakhir, AcquireBound mengambil kembali instance, atau instance dibuang
+func (pm *PoolManager) forgetWrapper(checkout *checkoutRecord) {
+	key := checkout.wrapper.Load()
+	if key == nil {
+		return
+	}
+	if recordVal, ok := pm.wrapped.Load(*key); ok {
+		record := recordVal.(*wrappedInstance)
+		// Alamat pembungkus dapat dipakai ulang oleh pembungkus peminjaman lain
+		if instanceKey(record.poolName, record.original) == checkout.key && pm.wrapped.CompareAndDelete(*key, record) {
+			record.cleanup.Stop()
+		}
+	}
+}
+
+// forgetCollectedWrapper dipanggil dari cleanup saat pembungkus di-GC tanpa dikembalikan
+func (pm *PoolManager) forgetCollectedWrapper(ref wrapperRef) {
+	if recordVal, ok := pm.wrapped.Load(ref.key); ok && recordVal.(*wrappedInstance).seq == ref.seq {
+		pm.wrapped.CompareAndDelete(ref.key, recordVal)
+	}
+	pm.reclaimed.Delete(ref.key)
+}
+
+// unwrapInstance mengembalikan objek asli dari pembungkus yang dibuat oleh interceptAcquire.
+// Instance yang bukan pembungkus dikembalikan apa adanya.
+func (pm *PoolManager) unwrapInstance(poolName string, instance PoolAble) PoolAble {
+	key := instanceKey(poolName, instance)
+	if key == "" {
+		return instance
+	}
+	recordVal, ok := pm.wrapped.LoadAndDelete(key)
+	if !ok {
+		return instance
+	}
+
+	record := recordVal.(*wrappedInstance)
+	record.cleanup.Stop()
+	for i := len(record.interceptors) - 1; i >= 0; i-- {
+		if unwrap := record.interceptors[i].Unwrap; unwrap != nil {
+			unwrap(poolName, instance)
+		}
+	}
+	return record.original
+}
+
+// originalInstance mengembalikan objek asli dari pembungkus tanpa melepas catatannya.
+// Instance yang bukan pembungkus dikembalikan apa adanya.
+func (pm *PoolManager) originalInstance(poolName string, instance PoolAble) PoolAble {
+	if recordVal, ok := pm.wrapped.Load(instanceKey(poolName, instance)); ok {
+		return recordVal.(*wrappedInstance).original
+	}
+	return instance
+}
+
+// deleteWrappedInstances menghapus catatan pembungkus milik pool tertentu
+func (pm *PoolManager) deleteWrappedInstances(poolName string) {
+	pm.wrapped.Range(func(key, value interface{}) bool {
+		if record, ok := value.(*wrappedInstance); ok && record.poolName == poolName && pm.wrapped.CompareAndDelete(key, record) {
+			record.cleanup.Stop()
+		}
+		return true
+	})
+}
@@ -0,0 +1,88 @@
+package poolmanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// poolLabels mengembalikan label pool yang tersimpan. Map yang dikembalikan dipakai bersama
+// dan tidak boleh diubah; gunakan PoolLabels untuk mendapatkan salinan.
+func (pm *PoolManager) poolLabels(poolName string) map[string]string {
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		return nil
+	}
+	return entry.config.Load().Labels
+}
+
+// PoolLabels mengembalikan salinan label statis pool (misalnya service, component, atau tier).
+// Mengembalikan nil jika pool tidak memiliki label.
+func (pm *PoolManager) PoolLabels(poolName string) map[string]string {
+	return copyLabels(pm.poolLabels(poolName))
+}
+
+// snapshotMetrics mengambil salinan metrik pool beserta labelnya untuk diteruskan ke eksportir
+func (pm *PoolManager) snapshotMetrics(poolName string, metrics *PoolMetrics) PoolMetrics {
+	snap := metrics.snapshot()
+	snap.Labels = pm.poolLabels(poolName)
+	return snap
+}
+
+// logPoolf mencatat pesan yang berkaitan dengan pool tertentu. Nama dan label pool dikirim sebagai
+// field agar log dapat dipilah berdasarkan pemiliknya.
+func (pm *PoolManager) logPoolf(level LogLevel, poolName string, format string, args ...interface{}) {
+	monitoring := pm.monitoring()
+	if !monitoring.logs(level) {
+		return
+	}
+	message := fmt.Sprintf(format, args...)
+	pm.writeLog(monitoring, poolName, level, message, poolLogFields(poolName, pm.poolLabels(poolName))...)
+}
+
+// poolLogFields menyusun field log untuk pool: "pool", "namespace" jika ada, lalu label dengan
+// urutan kunci yang stabil
+func poolLogFields(poolName string, labels map[string]string) []LogField {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]LogField, 0, len(keys)+2)
+	fields = append(fields, LogField{Key: "pool", Value: poolName})
+	if namespace := PoolNamespace(poolName); namespace != "" {
+		fields = append(fields, LogField{Key: "namespace", Value: namespace})
+	}
+	for _, key := range keys {
+		fields = append(fields, LogField{Key: key, Value: labels[key]})
+	}
+	return fields
+}
+
+// formatLabels memformat label sebagai "{key=value, ...}" dengan urutan kunci yang stabil
+func formatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + labels[key]
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// copyLabels membuat salinan map label, mengembalikan nil untuk map kosong
+func copyLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(labels))
+	for key, value := range labels {
+		copied[key] = value
+	}
+	return copied
+}
@@ -0,0 +1,196 @@
+package poolmanager
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// checkoutRecord mencatat instance yang sedang dipinjam dari pool.
+// Record ini sengaja tidak menyimpan referensi ke instance agar instance yang
+// dibuang oleh pemanggil tetap dapat dikumpulkan oleh garbage collector.
+type checkoutRecord struct {
+	poolName   string          // Nama pool asal instance
+	key        string          // Kunci unik instance
+	acquiredAt time.Time       // Waktu instance dipinjam
+	owner      string          // ID pemilik yang meminjam instance (opsional)
+	leaseID    string          // ID korelasi peminjaman
+	loadKey    string          // Kunci AcquireOrLoad instance (kosong jika berasal dari acquire biasa)
+	priority   int             // Prioritas pemanggil saat meminjam instance
+	cleanup    runtime.Cleanup // Cleanup yang melaporkan kebocoran saat instance di-GC (nol jika tidak terpasang)
+	shard      int             // Indeks shard asal instance (-1 jika tidak diketahui atau tanpa sharding)
+	stack      string          // Stack trace saat acquire (kosong jika CaptureAcquireStacks dan LeakDetection tidak aktif)
+	leakTimer  *time.Timer     // Timer laporan dugaan kebocoran LeakDetection atau LeakFinalizer tanpa cleanup (nil jika tidak aktif)
+
+	semaphore *weightedSemaphore // Semaphore tempat unit peminjaman diambil (nil jika tanpa MaxActive)
+	weight    int64              // Jumlah unit semaphore yang dipegang peminjaman ini
+
+	broken atomic.Pointer[error] // Penyebab instance ditandai rusak dengan MarkBroken (nil jika tidak rusak)
+
+	settled    atomic.Bool                 // Peminjaman sudah diselesaikan oleh release atau pengambilan kembali (AcquireBound atau lease)
+	stopBound  atomic.Pointer[func() bool] // Menghentikan pengambilan kembali AcquireBound (nil jika tidak terikat context)
+	leaseUntil atomic.Int64                // Batas waktu lease dalam UnixNano (0 jika tanpa LeaseDuration)
+	leaseTimer atomic.Pointer[time.Timer]  // Timer pengambilan kembali saat lease berakhir (nil jika tanpa LeaseDuration)
+	wrapper    atomic.Pointer[string]      // Kunci pembungkus InstanceInterceptor yang diserahkan (nil jika tidak dibungkus)
+}
+
+// releaseUnits mengembalikan unit semaphore yang dipegang peminjaman, jika ada.
+func (r *checkoutRecord) releaseUnits() {
+	if r.semaphore != nil {
+		r.semaphore.Release(r.weight)
+	}
+}
+
+// instanceKey menghasilkan kunci unik untuk sebuah instance berdasarkan alamat memorinya.
+// Mengembalikan string kosong jika instance bukan tipe referensi sehingga tidak dapat dilacak.
+func instanceKey(poolName string, instance interface{}) string {
+	v := reflect.ValueOf(instance)
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer, reflect.Slice:
+		return poolName + "#" + strconv.FormatUint(uint64(v.Pointer()), 16)
+	default:
+		return ""
+	}
+}
+
+// trackCheckout mencatat bahwa instance sedang dipinjam dan, jika LeakFinalizer diaktifkan,
+// memasang cleanup yang akan melaporkan kebocoran bila instance di-GC sebelum dikembalikan; instance
+// yang tidak dapat dipantau garbage collector dilaporkan dengan timer seperti LeakDetection.
+// shard adalah indeks shard asal instance agar instance dapat dikembalikan ke shard yang sama.
+// Mengembalikan catatan baru, atau nil jika instance tidak dapat dilacak atau sudah tercatat dipinjam.
+func (pm *PoolManager) trackCheckout(poolName string, conf PoolConfiguration, instance PoolAble, o acquireOptions, shard int) *checkoutRecord {
+	key := instanceKey(poolName, instance)
+	if key == "" {
+		return nil
+	}
+
+	record := &checkoutRecord{
+		poolName:   poolName,
+		key:        key,
+		acquiredAt: time.Now(),
+		owner:      o.owner,
+		leaseID:    o.leaseID,
+		loadKey:    o.loadKey,
+		priority:   o.priority,
+		shard:      shard,
+		stack:      captureStack(conf),
+		semaphore:  o.semaphore,
+		weight:     o.weight,
+	}
+
+	watched := conf.LeakFinalizer && pm.watchLeak(record, instance)
+	if window := leakTimerWindow(conf, watched); window > 0 {
+		record.leakTimer = time.AfterFunc(window, func() {
+			pm.reportSuspectedLeak(record, false)
+		})
+	}
+	if conf.LeaseDuration > 0 {
+		pm.startLease(record, instance, conf.LeaseDuration)
+	}
+	// Instance yang sama bisa dipinjam lebih dari sekali (misalnya dari cache),
+	// hanya peminjaman pertama yang tercatat
+	if _, loaded := pm.checkouts.LoadOrStore(key, record); loaded {
+		record.stopLeakTimer()
+		record.stopLeaseTimer()
+		record.cleanup.Stop()
+		return nil
+	}
+	// Instance yang pernah diambil kembali oleh AcquireBound kini dipinjam ulang
+	pm.reclaimed.Delete(key)
+	return record
+}
+
+// fallbackLeakWindow adalah jangka laporan dugaan kebocoran untuk instance LeakFinalizer yang tidak
+// dapat dipantau garbage collector dan pool-nya tidak menetapkan LeakWindow
+const fallbackLeakWindow = 10 * time.Minute
+
+// watchLeak memasang cleanup yang melaporkan kebocoran bila instance di-GC sebelum dikembalikan.
+// Mengembalikan false jika cleanup tidak dapat dipasang (lihat attachCleanup).
+func (pm *PoolManager) watchLeak(record *checkoutRecord, instance PoolAble) bool {
+	var ok bool
+	record.cleanup, ok = attachCleanup(instance, pm.reportLeak, record)
+	return ok
+}
+
+// attachCleanup memasang cleanup(arg) yang dipanggil setelah objek yang ditunjuk instance di-GC.
+// Berbeda dengan runtime.SetFinalizer yang menghentikan proses, runtime.AddCleanup menerima pointer
+// ke tengah alokasi (misalnya &arr[i]) dan panik untuk pointer yang tidak didukung. Mengembalikan
+// false jika instance bukan pointer ke objek berukuran non-nol atau cleanup tidak dapat dipasang.
+// arg tidak boleh mereferensikan instance.
+func attachCleanup[T any](instance interface{}, cleanup func(T), arg T) (handle runtime.Cleanup, ok bool) {
+	v := reflect.ValueOf(instance)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Type().Elem().Size() == 0 {
+		return runtime.Cleanup{}, false
+	}
+	defer func() {
+		if recover() != nil {
+			handle, ok = runtime.Cleanup{}, false
+		}
+	}()
+	return runtime.AddCleanup((*byte)(v.UnsafePointer()), cleanup, arg), true
+}
+
+// leakTimerWindow mengembalikan jangka timer laporan dugaan kebocoran peminjaman: LeakWindow untuk
+// LeakDetection, atau LeakWindow (default fallbackLeakWindow) untuk instance LeakFinalizer yang
+// tidak dapat dipantau garbage collector. 0 berarti timer tidak dipasang.
+func leakTimerWindow(conf PoolConfiguration, watched bool) time.Duration {
+	switch {
+	case conf.LeakDetection:
+		return conf.LeakWindow
+	case conf.LeakFinalizer && !watched && conf.LeakWindow > 0:
+		return conf.LeakWindow
+	case conf.LeakFinalizer && !watched:
+		return fallbackLeakWindow
+	default:
+		return 0
+	}
+}
+
+// untrackCheckout menghapus catatan peminjaman instance dan melepas cleanup kebocoran jika ada.
+// Mengembalikan catatan peminjaman, atau nil jika instance tidak sedang tercatat dipinjam.
+func (pm *PoolManager) untrackCheckout(poolName string, instance PoolAble) *checkoutRecord {
+	key := instanceKey(poolName, instance)
+	if key == "" {
+		return nil
+	}
+
+	recordVal, ok := pm.checkouts.LoadAndDelete(key)
+	if !ok {
+		return nil
+	}
+	record := recordVal.(*checkoutRecord)
+	record.stopLeakTimer()
+	record.stopLeaseTimer()
+	record.cleanup.Stop()
+	pm.forgetWrapper(record)
+	pm.observeOwnerHold(poolName, record.owner, time.Since(record.acquiredAt))
+	return record
+}
+
+// reportLeak dipanggil dari cleanup ketika instance yang masih tercatat dipinjam
+// dikumpulkan oleh garbage collector tanpa pernah dikembalikan ke pool.
+func (pm *PoolManager) reportLeak(record *checkoutRecord) {
+	if !pm.checkouts.CompareAndDelete(record.key, record) {
+		return
+	}
+	record.stopLeakTimer()
+	record.stopLeaseTimer()
+	record.releaseUnits()
+	pm.forgetWrapper(record)
+	// Instance yang bocor tidak akan kembali, sehingga slot objek hidup pool berbatas dilepas
+	pm.releaseLive(record.poolName)
+	heldFor := time.Since(record.acquiredAt)
+
+	pm.recordMetric(record.poolName, "leak")
+	pm.observeOwnerFailure(record.poolName, record.owner)
+	pm.adjustGauges(record.poolName, record.shard, 0, -1)
+	pm.logPoolf(WarningLevel, record.poolName, "Leak detected in pool: %s, Lease: %s, instance was garbage collected while checked out (held for %s)",
+		record.poolName, record.leaseID, heldFor)
+	pm.triggerEvent(PoolEvent{Type: EventLeak, PoolName: record.poolName, LeaseID: record.leaseID})
+
+	if conf, err := pm.getPoolConfiguration(record.poolName); err == nil && conf.OnLeak != nil {
+		conf.OnLeak(record.poolName, heldFor)
+	}
+}
@@ -0,0 +1,103 @@
+package poolmanager
+
+import (
+	"sort"
+	"time"
+)
+
+// LeakReport menjelaskan instance yang dilaporkan LeakDetection sebagai dugaan kebocoran
+type LeakReport struct {
+	PoolName string        // Nama pool asal instance
+	Key      string        // Kunci unik instance
+	Owner    string        // ID pemilik yang meminjam instance (kosong jika tidak diatur)
+	LeaseID  string        // ID korelasi peminjaman
+	HeldFor  time.Duration // Lama instance dipinjam saat dilaporkan
+	Stack    string        // Stack trace saat acquire
+	AtClose  bool          // Dilaporkan oleh Close, bukan karena LeakWindow terlampaui
+}
+
+// stopLeakTimer menghentikan timer laporan dugaan kebocoran peminjaman, jika ada
+func (r *checkoutRecord) stopLeakTimer() {
+	if r.leakTimer != nil {
+		r.leakTimer.Stop()
+	}
+}
+
+// leakReport membentuk LeakReport dari catatan peminjaman
+func (r *checkoutRecord) leakReport(now time.Time, atClose bool) LeakReport {
+	return LeakReport{
+		PoolName: r.poolName,
+		Key:      r.key,
+		Owner:    r.owner,
+		LeaseID:  r.leaseID,
+		HeldFor:  now.Sub(r.acquiredAt),
+		Stack:    r.stack,
+		AtClose:  atClose,
+	}
+}
+
+// reportSuspectedLeak melaporkan peminjaman yang belum dikembalikan melalui log, EventLeakSuspected,
+// dan MonitoringConfig.OnLeakSuspected. Peminjaman tetap tercatat sehingga release berikutnya tetap
+// diproses seperti biasa. Peminjaman yang sudah dikembalikan saat timer berjalan diabaikan.
+func (pm *PoolManager) reportSuspectedLeak(record *checkoutRecord, atClose bool) {
+	if current, ok := pm.checkouts.Load(record.key); !ok || current != record {
+		return
+	}
+	report := record.leakReport(time.Now(), atClose)
+
+	if atClose {
+		pm.logPoolf(WarningLevel, record.poolName, "Suspected leak in pool: %s, Lease: %s, instance still checked out at Close (held for %s), acquired at:\n%s",
+			record.poolName, record.leaseID, report.HeldFor, report.Stack)
+	} else {
+		pm.logPoolf(WarningLevel, record.poolName, "Suspected leak in pool: %s, Lease: %s, instance not released within leak window (held for %s), acquired at:\n%s",
+			record.poolName, record.leaseID, report.HeldFor, report.Stack)
+	}
+	pm.triggerEvent(PoolEvent{Type: EventLeakSuspected, PoolName: record.poolName, LeaseID: record.leaseID, Leak: &report})
+	if onLeakSuspected := pm.monitoring().OnLeakSuspected; onLeakSuspected != nil {
+		onLeakSuspected(report)
+	}
+}
+
+// SuspectedLeaks mengembalikan peminjaman pool yang dipinjam lebih lama dari LeakWindow beserta stack
+// acquire-nya, dari yang paling lama. Dengan LeakWindow 0, seluruh peminjaman yang masih berjalan
+// dikembalikan. Membutuhkan LeakDetection.
+func (pm *PoolManager) SuspectedLeaks(poolName string) ([]LeakReport, error) {
+	entry, err := pm.lookupEntry(poolName, "leaks")
+	if err != nil {
+		return nil, err
+	}
+	conf := entry.config.Load()
+	if !conf.LeakDetection {
+		return nil, nil
+	}
+
+	var reports []LeakReport
+	now := time.Now()
+	pm.checkouts.Range(func(key, value interface{}) bool {
+		if record, ok := value.(*checkoutRecord); ok && record.poolName == poolName {
+			if report := record.leakReport(now, false); report.HeldFor >= conf.LeakWindow {
+				reports = append(reports, report)
+			}
+		}
+		return true
+	})
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].HeldFor > reports[j].HeldFor
+	})
+	return reports, nil
+}
+
+// reportOutstandingLeaks melaporkan seluruh peminjaman yang masih berjalan pada pool dengan
+// LeakDetection, dipanggil oleh Close sebelum pool dihapus
+func (pm *PoolManager) reportOutstandingLeaks() {
+	pm.checkouts.Range(func(key, value interface{}) bool {
+		record, ok := value.(*checkoutRecord)
+		if !ok {
+			return true
+		}
+		if conf, err := pm.getPoolConfiguration(record.poolName); err == nil && conf.LeakDetection {
+			pm.reportSuspectedLeak(record, true)
+		}
+		return true
+	})
+}
@@ -0,0 +1,123 @@
+package poolmanager
+
+import (
+	"errors"
+	"time"
+)
+
+// LeaseHandle mengidentifikasi satu peminjaman pada pool dengan LeaseDuration, digunakan untuk
+// memperpanjang lease dengan RenewLease
+type LeaseHandle struct {
+	PoolName string    // Nama pool asal instance (pool shadow selama failover)
+	LeaseID  string    // ID korelasi peminjaman
+	Deadline time.Time // Batas waktu lease saat handle dibuat
+
+	key string // Kunci unik instance
+}
+
+// leaseDeadline mengembalikan batas waktu lease peminjaman, nol jika peminjaman tanpa lease
+func (r *checkoutRecord) leaseDeadline() time.Time {
+	if deadline := r.leaseUntil.Load(); deadline != 0 {
+		return time.Unix(0, deadline)
+	}
+	return time.Time{}
+}
+
+// stopLeaseTimer menghentikan timer lease peminjaman, jika ada
+func (r *checkoutRecord) stopLeaseTimer() {
+	if timer := r.leaseTimer.Load(); timer != nil {
+		timer.Stop()
+	}
+}
+
+// startLease memberi batas waktu LeaseDuration pada peminjaman baru dan memasang timer yang
+// mengambil kembali instance saat lease berakhir. Dipanggil sebelum catatan dipublikasikan.
+// Timer memegang referensi ke instance hingga peminjaman selesai.
+func (pm *PoolManager) startLease(record *checkoutRecord, instance PoolAble, duration time.Duration) {
+	record.leaseUntil.Store(record.acquiredAt.Add(duration).UnixNano())
+	record.leaseTimer.Store(time.AfterFunc(duration, func() {
+		pm.expireLease(record, instance)
+	}))
+}
+
+// expireLease dipanggil oleh timer lease. Lease yang sudah diperpanjang dijadwalkan ulang; lease
+// yang berakhir diambil kembali jika peminjaman yang sama masih berjalan.
+func (pm *PoolManager) expireLease(record *checkoutRecord, instance PoolAble) {
+	if remaining := time.Until(record.leaseDeadline()); remaining > 0 {
+		if timer := record.leaseTimer.Load(); timer != nil {
+			timer.Reset(remaining)
+			return
+		}
+	}
+	if current, ok := pm.checkouts.Load(record.key); !ok || current != record {
+		return
+	}
+	if !record.settled.CompareAndSwap(false, true) {
+		return
+	}
+	entry, ok := pm.entryFor(record.poolName)
+	if !ok {
+		return
+	}
+
+	poolName, conf := entry.name, entry.configuration()
+	heldFor := time.Since(record.acquiredAt)
+	pm.recordMetric(poolName, "reclaim")
+	pm.markReclaimed(poolName, instance, record.leaseID)
+	pm.logPoolf(WarningLevel, poolName, "Reclaimed instance from pool: %s, Key: %s, Lease: %s, lease expired after %s",
+		poolName, record.key, record.leaseID, heldFor)
+
+	// Pemegang lama mungkin masih memegang instance, sehingga instance dihancurkan alih-alih dipakai ulang
+	if err := pm.discardInstance(poolName, conf, instance, "lease expired"); err != nil {
+		pm.handleError(poolName, err)
+	}
+	if conf.OnLeaseExpired != nil {
+		conf.OnLeaseExpired(poolName, record.leaseID, heldFor)
+	}
+}
+
+// LeaseFor mengembalikan handle lease untuk instance yang sedang dipinjam dari pool dengan
+// LeaseDuration. Mengembalikan ErrNotCheckedOut jika instance tidak sedang dipinjam.
+func (pm *PoolManager) LeaseFor(poolName string, instance PoolAble) (LeaseHandle, error) {
+	entry, err := pm.lookupEntry(poolName, "lease")
+	if err != nil {
+		return LeaseHandle{}, err
+	}
+	// Selama failover instance berasal dari pool shadow
+	poolName = pm.routeRelease(entry, instance).name
+	record := pm.checkoutFor(poolName, pm.originalInstance(poolName, instance))
+	if record == nil {
+		return LeaseHandle{}, NewPoolError(poolName, "lease", ErrNotCheckedOut)
+	}
+	deadline := record.leaseDeadline()
+	if deadline.IsZero() {
+		return LeaseHandle{}, NewPoolError(poolName, "lease", errors.New("pool has no LeaseDuration"))
+	}
+	return LeaseHandle{PoolName: poolName, LeaseID: record.leaseID, Deadline: deadline, key: record.key}, nil
+}
+
+// RenewLease memperpanjang lease hingga LeaseDuration pool sejak saat ini dan mengembalikan batas
+// waktu barunya. Mengembalikan ErrLeaseExpired jika lease sudah berakhir dan instance diambil
+// kembali, atau jika peminjaman sudah dikembalikan.
+func (pm *PoolManager) RenewLease(handle LeaseHandle) (time.Time, error) {
+	entry, err := pm.lookupEntry(handle.PoolName, "lease")
+	if err != nil {
+		return time.Time{}, err
+	}
+	recordVal, ok := pm.checkouts.Load(handle.key)
+	if !ok {
+		return time.Time{}, NewPoolError(handle.PoolName, "lease", ErrLeaseExpired)
+	}
+	record := recordVal.(*checkoutRecord)
+	if record.leaseID != handle.LeaseID || record.settled.Load() || record.leaseDeadline().IsZero() {
+		return time.Time{}, NewPoolError(handle.PoolName, "lease", ErrLeaseExpired)
+	}
+	duration := entry.configuration().LeaseDuration
+	if duration <= 0 {
+		return time.Time{}, NewPoolError(handle.PoolName, "lease", errors.New("pool has no LeaseDuration"))
+	}
+	deadline := time.Now().Add(duration)
+	record.leaseUntil.Store(deadline.UnixNano())
+	pm.tracef("lease pool=%s key=%s lease=%s action=renew deadline=%s", handle.PoolName, handle.key, handle.LeaseID, deadline.Format(time.RFC3339Nano))
+	return deadline, nil
+}
@@ -0,0 +1,155 @@
+package poolmanager
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// leaseExpiry adalah satu pemanggilan OnLeaseExpired
+type leaseExpiry struct {
+	poolName string
+	leaseID  string
+	heldFor  time.Duration
+}
+
+// expireLeasedInstance meminjam instance dari pool dengan lease singkat, menunggu hingga lease
+// berakhir, dan mengembalikan instance beserta handle dan pemanggilan OnLeaseExpired-nya
+func expireLeasedInstance(t *testing.T, pm *PoolManager) (PoolAble, LeaseHandle, leaseExpiry) {
+	t.Helper()
+	const duration = 20 * time.Millisecond
+	expired := make(chan leaseExpiry, 1)
+	_, err := pm.AddPoolWithOptions("leased", func() PoolAble { return &tuneTestObject{} },
+		WithLease(duration, func(poolName, leaseID string, heldFor time.Duration) {
+			expired <- leaseExpiry{poolName: poolName, leaseID: leaseID, heldFor: heldFor}
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	instance, err := pm.AcquireInstance("leased")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handle, err := pm.LeaseFor("leased", instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case expiry := <-expired:
+		if expiry.heldFor < duration {
+			t.Fatalf("OnLeaseExpired heldFor = %s, want at least %s", expiry.heldFor, duration)
+		}
+		return instance, handle, expiry
+	case <-time.After(time.Second):
+		t.Fatal("OnLeaseExpired not called")
+	}
+	return nil, LeaseHandle{}, leaseExpiry{}
+}
+
+func TestLeaseExpiryReclaimsInstance(t *testing.T) {
+	pm := newSilentManager(t)
+	_, handle, expiry := expireLeasedInstance(t, pm)
+
+	if expiry.poolName != "leased" || expiry.leaseID != handle.LeaseID {
+		t.Fatalf("OnLeaseExpired(%q, %q), want (%q, %q)", expiry.poolName, expiry.leaseID, "leased", handle.LeaseID)
+	}
+	if inUse, _ := pm.GetInUseCount("leased"); inUse != 0 {
+		t.Fatalf("InUseCount after expiry = %d, want 0", inUse)
+	}
+	if _, err := pm.RenewLease(handle); !errors.Is(err, ErrLeaseExpired) {
+		t.Fatalf("RenewLease after expiry returned %v, want ErrLeaseExpired", err)
+	}
+}
+
+func TestLateReleaseAfterLeaseExpiry(t *testing.T) {
+	tests := []struct {
+		name    string
+		release func(pm *PoolManager, instance PoolAble) error
+		want    error
+	}{
+		{
+			name: "settle",
+			release: func(pm *PoolManager, instance PoolAble) error {
+				conf, err := pm.getPoolConfiguration("leased")
+				if err != nil {
+					return err
+				}
+				return pm.settleRelease("leased", conf, instance)
+			},
+			want: errReclaimedRelease,
+		},
+		// ReleaseInstance dan DiscardInstance menelan errReclaimedRelease karena peminjaman sudah selesai
+		{
+			name:    "release instance",
+			release: func(pm *PoolManager, instance PoolAble) error { return pm.ReleaseInstance("leased", instance) },
+		},
+		{
+			name:    "discard instance",
+			release: func(pm *PoolManager, instance PoolAble) error { return pm.DiscardInstance("leased", instance) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newSilentManager(t)
+			instance, _, _ := expireLeasedInstance(t, pm)
+			idleBefore, _ := pm.GetIdleCount("leased")
+
+			err := tt.release(pm, instance)
+			if tt.want == nil && err != nil {
+				t.Fatalf("late release returned %v, want nil", err)
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("late release returned %v, want %v", err, tt.want)
+			}
+			if inUse, _ := pm.GetInUseCount("leased"); inUse != 0 {
+				t.Fatalf("InUseCount after late release = %d, want 0", inUse)
+			}
+			// Instance yang lease-nya berakhir sudah dihancurkan dan tidak kembali ke pool
+			if idle, _ := pm.GetIdleCount("leased"); idle != idleBefore {
+				t.Fatalf("IdleCount after late release = %d, want %d", idle, idleBefore)
+			}
+		})
+	}
+}
+
+func TestLeasedPoolRejectsCoalescing(t *testing.T) {
+	tests := []struct {
+		name    string
+		acquire func(pm *PoolManager) (PoolAble, error)
+	}{
+		{
+			name: "coalesce key",
+			acquire: func(pm *PoolManager) (PoolAble, error) {
+				return pm.AcquireInstance("leased", WithCoalesceKey("shared"))
+			},
+		},
+		{
+			name: "acquire or load",
+			acquire: func(pm *PoolManager) (PoolAble, error) {
+				return pm.AcquireOrLoad("leased", "shared", func() (PoolAble, error) { return &tuneTestObject{}, nil })
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newSilentManager(t)
+			if _, err := pm.AddPoolWithOptions("leased", func() PoolAble { return &tuneTestObject{} }, WithLease(time.Minute, nil)); err != nil {
+				t.Fatal(err)
+			}
+			instance, err := tt.acquire(pm)
+			if !errors.Is(err, errLeasedCoalesce) {
+				t.Fatalf("acquire returned %v, want errLeasedCoalesce", err)
+			}
+			if instance != nil {
+				t.Fatalf("acquire returned instance %v alongside error", instance)
+			}
+			if inUse, _ := pm.GetInUseCount("leased"); inUse != 0 {
+				t.Fatalf("InUseCount after rejected acquire = %d, want 0", inUse)
+			}
+		})
+	}
+}
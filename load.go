@@ -0,0 +1,167 @@
+package poolmanager
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// loadedKey adalah kunci instance AcquireOrLoad yang sedang idle
+type loadedKey struct {
+	pool string
+	key  string
+}
+
+// loadCallKey membentuk kunci call AcquireOrLoad. Nama pool tidak boleh mengandung '#', sehingga
+// kunci ini tidak dapat bertabrakan dengan kunci WithCoalesceKey.
+func loadCallKey(poolName, key string) string {
+	return "#load#" + coalesceKey(poolName, key)
+}
+
+// AcquireOrLoad meminjam instance milik kunci tertentu. Jika instance untuk key sedang idle,
+// instance tersebut dikembalikan; jika tidak, loader dijalankan satu kali untuk seluruh pemanggil
+// yang bersamaan (singleflight) dan hasilnya dibagi selama masih dipegang, seperti WithCoalesceKey.
+// Setelah pemegang terakhir memanggil ReleaseInstance, instance disimpan untuk key tanpa Reset
+// hingga dipinjam lagi atau dilupakan dengan ForgetLoaded. Instance harus bertipe referensi, dan
+// instance dari loader tidak dibatasi MaxActive. AcquireOrLoad tidak dapat dipakai pada pool dengan
+// LeaseDuration.
+func (pm *PoolManager) AcquireOrLoad(poolName, key string, loader func() (PoolAble, error), opts ...AcquireOption) (PoolAble, error) {
+	if loader == nil {
+		return nil, NewPoolError(poolName, "load", errors.New("loader must not be nil"))
+	}
+	if err := pm.checkOpen(poolName, "load"); err != nil {
+		return nil, err
+	}
+	entry, err := pm.lookupEntry(poolName, "load")
+	if err != nil {
+		pm.handleError(poolName, err)
+		return nil, err
+	}
+	entry = pm.routeAcquire(entry)
+	if entry.configuration().LeaseDuration > 0 {
+		err := NewPoolError(entry.name, "load", errLeasedCoalesce)
+		pm.handleError(entry.name, err)
+		return nil, err
+	}
+
+	o := newAcquireOptions(opts)
+	if o.leaseID == "" {
+		o.leaseID = newLeaseID()
+	}
+	o.loadKey = key
+	return pm.acquireCoalesced(entry, o, loadCallKey(entry.name, key), func(o acquireOptions) (PoolAble, error) {
+		return pm.loadInstance(entry, o, loader)
+	})
+}
+
+// loadInstance mengambil instance idle milik o.loadKey atau membuatnya dengan loader
+func (pm *PoolManager) loadInstance(entry *poolEntry, o acquireOptions, loader func() (PoolAble, error)) (PoolAble, error) {
+	poolName, conf := entry.name, entry.configuration()
+
+	source := "loaded"
+	var instance PoolAble
+	if value, ok := pm.loaded.LoadAndDelete(loadedKey{pool: poolName, key: o.loadKey}); ok {
+		instance = value.(PoolAble)
+		pm.recordMetric(poolName, "cache_hit")
+		pm.adjustGauges(poolName, -1, -1, 0)
+		pm.adjustRetainedBytes(poolName, -estimateSize(instance))
+	} else {
+		// Pool berbatas membutuhkan slot objek hidup untuk instance hasil loader
+		err := pm.reserveLoaded(entry, conf, o)
+		if err == nil {
+			if instance, err = loader(); err == nil && instance == nil {
+				err = errors.New("loader returned nil instance")
+			}
+			if err != nil {
+				pm.releaseLive(poolName)
+			}
+		}
+		if err != nil {
+			err = NewPoolError(poolName, "load", err)
+			pm.handleError(poolName, err)
+			return nil, err
+		}
+		source = "loader"
+		pm.recordMetric(poolName, "get")
+	}
+	pm.adjustGauges(poolName, -1, 0, 1)
+
+	if pm.trackCheckout(poolName, conf, instance, o, -1) == nil {
+		// Tanpa catatan peminjaman, instance tidak dapat dikenali kembali saat dikembalikan
+		_ = pm.discardInstance(poolName, conf, instance, "loaded instance is not trackable")
+		err := NewPoolError(poolName, "load", errors.New("AcquireOrLoad requires an instance of reference type"))
+		pm.handleError(poolName, err)
+		return nil, err
+	}
+	pm.updateMetadata(poolName, instance, "Active", o)
+	pm.observeOwnerAcquire(poolName, o.owner)
+	pm.triggerCallback(conf.OnGet, poolName)
+	pm.triggerInstanceCallback(conf.OnGetInstance, poolName, instance)
+	pm.triggerLifecycle(conf, "get", poolName, instance)
+	pm.observeOperation(PoolEvent{Type: EventAcquire, PoolName: poolName, Item: instance, LeaseID: o.leaseID},
+		"acquire pool=%s key=%s lease=%s source=%s load_key=%s", poolName, metadataKey(poolName, instance), o.leaseID, source, o.loadKey)
+	return pm.interceptAcquire(poolName, conf, instance), nil
+}
+
+// storeLoaded menyimpan instance AcquireOrLoad yang dikembalikan sebagai instance idle milik
+// kuncinya. Jika kunci sudah memiliki instance idle, instance yang dikembalikan dihancurkan.
+func (pm *PoolManager) storeLoaded(entry *poolEntry, key string, instance PoolAble, lease string) error {
+	poolName, conf := entry.name, entry.configuration()
+	if _, exists := pm.loaded.LoadOrStore(loadedKey{pool: poolName, key: key}, instance); exists {
+		return pm.discardInstance(poolName, conf, instance, "load key already has an idle instance")
+	}
+
+	pm.recordMetric(poolName, "put")
+	pm.adjustGauges(poolName, -1, 1, -1)
+	pm.adjustRetainedBytes(poolName, estimateSize(instance))
+	pm.triggerCallback(conf.OnPut, poolName)
+	pm.triggerInstanceCallback(conf.OnPutInstance, poolName, instance)
+	pm.triggerLifecycle(conf, "put", poolName, instance)
+	pm.observeOperation(PoolEvent{Type: EventRelease, PoolName: poolName, Item: instance, LeaseID: lease},
+		"release pool=%s key=%s lease=%s action=loaded load_key=%s", poolName, metadataKey(poolName, instance), lease, key)
+	return nil
+}
+
+// ForgetLoaded menghancurkan instance idle milik key, misalnya saat data sumbernya berubah.
+// Instance yang sedang dipinjam tidak terpengaruh dan tetap disimpan saat dikembalikan.
+// Mengembalikan false jika key tidak memiliki instance idle.
+func (pm *PoolManager) ForgetLoaded(poolName, key string) (bool, error) {
+	entry, err := pm.lookupEntry(poolName, "load")
+	if err != nil {
+		return false, err
+	}
+	value, ok := pm.loaded.LoadAndDelete(loadedKey{pool: poolName, key: key})
+	if !ok {
+		return false, nil
+	}
+	return true, pm.destroyLoaded(entry.name, entry.configuration(), value.(PoolAble))
+}
+
+// purgeLoaded menghancurkan seluruh instance idle AcquireOrLoad milik pool
+func (pm *PoolManager) purgeLoaded(poolName string, conf PoolConfiguration) error {
+	var errs []error
+	pm.loaded.Range(func(key, value interface{}) bool {
+		if key.(loadedKey).pool != poolName {
+			return true
+		}
+		if _, ok := pm.loaded.LoadAndDelete(key); ok {
+			errs = append(errs, pm.destroyLoaded(poolName, conf, value.(PoolAble)))
+		}
+		return true
+	})
+	return errors.Join(errs...)
+}
+
+// destroyLoaded menghancurkan instance idle AcquireOrLoad yang sudah dilepas dari kuncinya
+func (pm *PoolManager) destroyLoaded(poolName string, conf PoolConfiguration, instance PoolAble) error {
+	pm.recordMetric(poolName, "evict")
+	if metrics, err := pm.loadMetrics(poolName); err == nil && atomic.LoadInt64(&metrics.IdleCount) > 0 {
+		pm.adjustGauges(poolName, -1, -1, 0)
+	}
+	pm.adjustRetainedBytes(poolName, -estimateSize(instance))
+	if err := pm.destroyInstance(poolName, conf, instance); err != nil {
+		err = NewPoolError(poolName, "load", err)
+		pm.handleError(poolName, err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package poolmanager_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	poolmanager "github.com/hibbannn/pool-manager"
+	"github.com/hibbannn/pool-manager/poolmanagertest"
+)
+
+func TestAcquireOrLoadConcurrent(t *testing.T) {
+	pm := newTestManager(t)
+	_, err := pm.AddPoolWithOptions("load", func() poolmanager.PoolAble { return &testObject{} },
+		poolmanager.WithMaxIdleTime(time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var loads atomic.Int64
+	loader := func() (poolmanager.PoolAble, error) {
+		loads.Add(1)
+		return &testObject{value: 1}, nil
+	}
+
+	stop := make(chan struct{})
+	inspected := inspectMetadata(pm, "load", stop)
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				instance, err := pm.AcquireOrLoad("load", "key", loader, poolmanager.WithOwner("worker"))
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if value := instance.(*testObject).value; value != 1 {
+					t.Errorf("loaded instance value = %d, want 1", value)
+				}
+				if err := pm.ReleaseInstance("load", instance); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	<-inspected
+
+	if loads.Load() == 0 {
+		t.Fatal("loader was never called")
+	}
+	poolmanagertest.AssertAllReleased(t, pm, "load")
+}
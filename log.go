@@ -10,7 +10,106 @@ const (
 	ErrorLevel
 )
 
+// LogField adalah pasangan kunci/nilai yang menyertai pesan log terstruktur
+type LogField struct {
+	Key   string
+	Value string
+}
+
+// Logger adalah tujuan log PoolManager dengan satu method per level. Pesan pool menyertakan nama
+// dan label pool sebagai field (pool, namespace jika ada, lalu setiap label). EnableLogging dan
+// LogLevel pada MonitoringConfig tetap berlaku. Implementasi bawaan tersedia sebagai NewSlogLogger,
+// NewStdLogger, NewFuncLogger, dan DiscardLogger; adapter untuk zap dan zerolog tersedia di modul
+// zapadapter dan zerologadapter. Lihat loggerFor untuk urutan prioritas tujuan log.
+type Logger interface {
+	Debug(message string, fields ...LogField)
+	Info(message string, fields ...LogField)
+	Warn(message string, fields ...LogField)
+	Error(message string, fields ...LogField)
+}
+
+// String mengembalikan nama level log
+func (l LogLevel) String() string {
+	switch l {
+	case DebugLevel:
+		return "DEBUG"
+	case InfoLevel:
+		return "INFO"
+	case WarningLevel:
+		return "WARN"
+	case ErrorLevel:
+		return "ERROR"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// SetLogger menetapkan MonitoringConfig.Logger tanpa mengganti nilai monitoring lainnya, misalnya
+// DiscardLogger agar PoolManager senyap. nil mengembalikan LogFunc atau logger bawaan. Aman dipanggil
+// saat tugas latar belakang sedang mencatat log.
+func (pm *PoolManager) SetLogger(logger Logger) {
+	pm.updateMonitoringConfig(func(config *MonitoringConfig) {
+		config.Logger = logger
+	})
+}
+
+// loggerFor mengembalikan tujuan log untuk pesan pool poolName (kosong untuk pesan PoolManager).
+// Urutan prioritasnya:
+//  1. Logger pool (PoolConfiguration.Logger), hanya untuk pesan pool tersebut
+//  2. MonitoringConfig.Logger, yang diatur oleh SetLogger, WithLogger pada NewPoolManager atau
+//     NewPool, atau SetMonitoringConfig
+//  3. MonitoringConfig.LogFunc melalui NewFuncLogger
+//  4. logger bawaan yang menulis ke os.Stdout
+func (pm *PoolManager) loggerFor(monitoring *MonitoringConfig, poolName string) Logger {
+	if entry, ok := pm.entryFor(poolName); ok {
+		if logger := entry.config.Load().Logger; logger != nil {
+			return logger
+		}
+	}
+	switch {
+	case monitoring.Logger != nil:
+		return monitoring.Logger
+	case monitoring.LogFunc != nil:
+		return NewFuncLogger(monitoring.LogFunc)
+	default:
+		return defaultLogger
+	}
+}
+
+// logAt meneruskan pesan ke method Logger yang sesuai dengan level
+func logAt(logger Logger, level LogLevel, message string, fields ...LogField) {
+	switch level {
+	case DebugLevel:
+		logger.Debug(message, fields...)
+	case InfoLevel:
+		logger.Info(message, fields...)
+	case WarningLevel:
+		logger.Warn(message, fields...)
+	default:
+		logger.Error(message, fields...)
+	}
+}
+
+// logs memeriksa apakah pesan pada level dicatat menurut EnableLogging dan LogLevel
+func (config *MonitoringConfig) logs(level LogLevel) bool {
+	return config.EnableLogging && level >= config.LogLevel
+}
+
 // SetLogLevel mengatur tingkat log untuk PoolManager
 func (pm *PoolManager) SetLogLevel(level LogLevel) {
-	pm.monitoringConfig.LogLevel = level
+	pm.updateMonitoringConfig(func(config *MonitoringConfig) {
+		config.LogLevel = level
+	})
+}
+
+// SetDebugTracing mengaktifkan atau menonaktifkan mode tracing yang mencatat setiap keputusan
+// acquire, release, dan evict beserta alasannya. Tracing dicatat pada DebugLevel sehingga
+// level log juga diturunkan ke DebugLevel saat tracing diaktifkan.
+func (pm *PoolManager) SetDebugTracing(enabled bool) {
+	pm.updateMonitoringConfig(func(config *MonitoringConfig) {
+		config.TraceOperations = enabled
+		if enabled {
+			config.LogLevel = DebugLevel
+		}
+	})
 }
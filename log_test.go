@@ -0,0 +1,154 @@
+package poolmanager
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// recordingLogger menyimpan pesan yang diterima beserta field-nya
+type recordingLogger struct {
+	messages []string
+	fields   [][]LogField
+}
+
+func (l *recordingLogger) record(message string, fields []LogField) {
+	l.messages = append(l.messages, message)
+	l.fields = append(l.fields, fields)
+}
+
+func (l *recordingLogger) Debug(message string, fields ...LogField) { l.record(message, fields) }
+func (l *recordingLogger) Info(message string, fields ...LogField)  { l.record(message, fields) }
+func (l *recordingLogger) Warn(message string, fields ...LogField)  { l.record(message, fields) }
+func (l *recordingLogger) Error(message string, fields ...LogField) { l.record(message, fields) }
+
+type logTestObject struct{}
+
+func (*logTestObject) Reset() {}
+
+func TestLoggerPrecedence(t *testing.T) {
+	tests := []struct {
+		name        string
+		poolLogger  bool
+		monitoring  bool
+		logFunc     bool
+		disabled    bool
+		wantPool    string // Tujuan pesan pool
+		wantManager string // Tujuan pesan PoolManager
+	}{
+		{name: "default", wantPool: "default", wantManager: "default"},
+		{name: "log func", logFunc: true, wantPool: "func", wantManager: "func"},
+		{name: "monitoring logger over log func", monitoring: true, logFunc: true, wantPool: "monitoring", wantManager: "monitoring"},
+		{name: "pool logger first", poolLogger: true, monitoring: true, logFunc: true, wantPool: "pool", wantManager: "monitoring"},
+		{name: "pool logger over log func", poolLogger: true, logFunc: true, wantPool: "pool", wantManager: "func"},
+		{name: "pool logger over default", poolLogger: true, wantPool: "pool", wantManager: "default"},
+		{name: "logging disabled", poolLogger: true, monitoring: true, logFunc: true, disabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loggers := map[string]*recordingLogger{
+				"pool":       {},
+				"monitoring": {},
+				"default":    {},
+			}
+			var lines []string
+			saved := defaultLogger
+			defaultLogger = loggers["default"]
+			t.Cleanup(func() { defaultLogger = saved })
+
+			pm := NewPoolManager(PoolConfiguration{})
+			t.Cleanup(func() { _ = pm.Close(context.Background()) })
+			config := MonitoringConfig{EnableLogging: !tt.disabled, LogLevel: DebugLevel}
+			if tt.monitoring {
+				config.Logger = loggers["monitoring"]
+			}
+			if tt.logFunc {
+				config.LogFunc = func(message string) { lines = append(lines, message) }
+			}
+			pm.SetMonitoringConfig(config)
+
+			var opts []PoolOption
+			if tt.poolLogger {
+				opts = append(opts, WithLogger(loggers["pool"]))
+			}
+			if _, err := pm.AddPoolWithOptions("logged", func() PoolAble { return &logTestObject{} }, opts...); err != nil {
+				t.Fatal(err)
+			}
+			for _, logger := range loggers {
+				logger.messages, logger.fields = nil, nil
+			}
+			lines = nil
+
+			pm.logPoolf(WarningLevel, "logged", "pool message")
+			pm.logMessage(WarningLevel, "manager message")
+
+			received := func(message string) []string {
+				var got []string
+				for name, logger := range loggers {
+					if slices.Contains(logger.messages, message) {
+						got = append(got, name)
+					}
+				}
+				for _, line := range lines {
+					if line == "[WARN] "+message || line == "[WARN] "+message+" pool=logged" {
+						got = append(got, "func")
+					}
+				}
+				return got
+			}
+			for message, want := range map[string]string{"pool message": tt.wantPool, "manager message": tt.wantManager} {
+				got := received(message)
+				if want == "" {
+					if len(got) != 0 {
+						t.Errorf("%q delivered to %v, want nowhere", message, got)
+					}
+					continue
+				}
+				if len(got) != 1 || got[0] != want {
+					t.Errorf("%q delivered to %v, want [%s]", message, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPoolLogFields(t *testing.T) {
+	logger := &recordingLogger{}
+	pm := NewPoolManager(PoolConfiguration{Logger: logger})
+	t.Cleanup(func() { _ = pm.Close(context.Background()) })
+	_, err := pm.AddPoolWithOptions("labelled", func() PoolAble { return &logTestObject{} },
+		WithLabels(map[string]string{"tier": "hot", "service": "api"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.messages, logger.fields = nil, nil
+
+	pm.logPoolf(InfoLevel, "labelled", "hello")
+	want := []LogField{{Key: "pool", Value: "labelled"}, {Key: "service", Value: "api"}, {Key: "tier", Value: "hot"}}
+	if len(logger.fields) != 1 || !slices.Equal(logger.fields[0], want) {
+		t.Fatalf("fields = %v, want %v", logger.fields, want)
+	}
+}
+
+func TestSetLoggerKeepsMonitoringConfig(t *testing.T) {
+	logger := &recordingLogger{}
+	pm := NewPoolManager(PoolConfiguration{})
+	t.Cleanup(func() { _ = pm.Close(context.Background()) })
+	pm.SetLogLevel(ErrorLevel)
+	pm.SetLogger(logger)
+
+	pm.logMessage(WarningLevel, "filtered")
+	pm.logMessage(ErrorLevel, "kept")
+	if !slices.Equal(logger.messages, []string{"kept"}) {
+		t.Fatalf("messages = %v, want [kept]", logger.messages)
+	}
+}
+
+func TestFuncLoggerFormat(t *testing.T) {
+	var line string
+	NewFuncLogger(func(message string) { line = message }).Warn("slow", LogField{Key: "pool", Value: "p"})
+	if want := "[WARN] slow pool=p"; line != want {
+		t.Fatalf("line = %q, want %q", line, want)
+	}
+}
@@ -0,0 +1,137 @@
+package poolmanager
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// defaultLogger adalah logger bawaan PoolManager yang menulis ke os.Stdout dengan prefix "POOL_MANAGER: "
+var defaultLogger = NewStdLogger(log.New(os.Stdout, "POOL_MANAGER: ", log.LstdFlags))
+
+// DiscardLogger adalah Logger yang membuang semua pesan. Gunakan dengan WithLogger atau SetLogger
+// agar PoolManager senyap tanpa mengubah konfigurasi monitoring lainnya.
+var DiscardLogger Logger = discardLogger{}
+
+// discardLogger membuang semua pesan log
+type discardLogger struct{}
+
+func (discardLogger) Debug(string, ...LogField) {}
+func (discardLogger) Info(string, ...LogField)  {}
+func (discardLogger) Warn(string, ...LogField)  {}
+func (discardLogger) Error(string, ...LogField) {}
+
+// slogLogger meneruskan pesan poolmanager ke slog.Logger
+type slogLogger struct {
+	slog *slog.Logger
+}
+
+// NewSlogLogger membuat Logger yang menulis ke slog.Logger. Field poolmanager dikirim sebagai
+// atribut string dan level dipetakan ke level slog yang setara. logger nil berarti slog.Default().
+func NewSlogLogger(logger *slog.Logger) Logger {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &slogLogger{slog: logger}
+}
+
+func (l *slogLogger) Debug(message string, fields ...LogField) {
+	l.log(slog.LevelDebug, message, fields)
+}
+
+func (l *slogLogger) Info(message string, fields ...LogField) {
+	l.log(slog.LevelInfo, message, fields)
+}
+
+func (l *slogLogger) Warn(message string, fields ...LogField) {
+	l.log(slog.LevelWarn, message, fields)
+}
+
+func (l *slogLogger) Error(message string, fields ...LogField) {
+	l.log(slog.LevelError, message, fields)
+}
+
+// log mencatat pesan beserta field-nya sebagai atribut string
+func (l *slogLogger) log(level slog.Level, message string, fields []LogField) {
+	attrs := make([]slog.Attr, len(fields))
+	for i, field := range fields {
+		attrs[i] = slog.String(field.Key, field.Value)
+	}
+	l.slog.LogAttrs(context.Background(), level, message, attrs...)
+}
+
+// stdLogger meneruskan pesan poolmanager ke log.Logger dari pustaka standar
+type stdLogger struct {
+	std *log.Logger
+}
+
+// NewStdLogger membuat Logger yang menulis ke log.Logger dengan format "[LEVEL] pesan key=value".
+// Logger bawaan PoolManager adalah NewStdLogger yang menulis ke os.Stdout dengan prefix
+// "POOL_MANAGER: ". logger nil berarti log.Default().
+func NewStdLogger(logger *log.Logger) Logger {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &stdLogger{std: logger}
+}
+
+func (l *stdLogger) Debug(message string, fields ...LogField) {
+	l.std.Println(formatLogLine(DebugLevel, message, fields))
+}
+
+func (l *stdLogger) Info(message string, fields ...LogField) {
+	l.std.Println(formatLogLine(InfoLevel, message, fields))
+}
+
+func (l *stdLogger) Warn(message string, fields ...LogField) {
+	l.std.Println(formatLogLine(WarningLevel, message, fields))
+}
+
+func (l *stdLogger) Error(message string, fields ...LogField) {
+	l.std.Println(formatLogLine(ErrorLevel, message, fields))
+}
+
+// funcLogger meneruskan pesan poolmanager ke fungsi yang menerima satu baris teks
+type funcLogger struct {
+	fn func(message string)
+}
+
+// NewFuncLogger membuat Logger yang memanggil fn dengan satu baris berformat "[LEVEL] pesan key=value".
+// MonitoringConfig.LogFunc dicatat melalui adapter ini.
+func NewFuncLogger(fn func(message string)) Logger {
+	return funcLogger{fn: fn}
+}
+
+func (l funcLogger) Debug(message string, fields ...LogField) {
+	l.fn(formatLogLine(DebugLevel, message, fields))
+}
+
+func (l funcLogger) Info(message string, fields ...LogField) {
+	l.fn(formatLogLine(InfoLevel, message, fields))
+}
+
+func (l funcLogger) Warn(message string, fields ...LogField) {
+	l.fn(formatLogLine(WarningLevel, message, fields))
+}
+
+func (l funcLogger) Error(message string, fields ...LogField) {
+	l.fn(formatLogLine(ErrorLevel, message, fields))
+}
+
+// formatLogLine memformat pesan beserta field-nya sebagai satu baris "[LEVEL] pesan key=value"
+func formatLogLine(level LogLevel, message string, fields []LogField) string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	sb.WriteString(level.String())
+	sb.WriteString("] ")
+	sb.WriteString(message)
+	for _, field := range fields {
+		sb.WriteString(" ")
+		sb.WriteString(field.Key)
+		sb.WriteString("=")
+		sb.WriteString(field.Value)
+	}
+	return sb.String()
+}
@@ -0,0 +1,134 @@
+package poolmanager
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Tugas latar belakang yang tunduk pada MaintenanceConfig
+const (
+	maintenanceEviction = "eviction"  // Sapuan eviksi terjadwal beserta pemadatan metadata
+	maintenanceAutoTune = "auto-tune" // Resize pool oleh auto-tuning
+)
+
+// MaintenanceWindow adalah rentang waktu harian tempat tugas berat boleh berjalan, dinyatakan
+// sebagai offset sejak tengah malam. End yang tidak lebih besar dari Start berarti window
+// melewati tengah malam, misalnya Start 22 jam dan End 2 jam.
+type MaintenanceWindow struct {
+	Start time.Duration // Awal window sejak tengah malam
+	End   time.Duration // Akhir window sejak tengah malam (eksklusif)
+}
+
+// contains memeriksa apakah offset sejak tengah malam berada di dalam window
+func (w MaintenanceWindow) contains(offset time.Duration) bool {
+	if w.Start < w.End {
+		return offset >= w.Start && offset < w.End
+	}
+	return offset >= w.Start || offset < w.End
+}
+
+// MaintenanceConfig menunda tugas latar belakang yang mahal (sapuan eviksi terjadwal dan resize
+// oleh auto-tuning) hingga pool berada di dalam salah satu Windows atau bebannya di bawah
+// MaxUtilization. Operasi yang dipanggil langsung oleh pemanggil, seperti ResizePool atau
+// EvictWhere, tidak ditunda.
+type MaintenanceConfig struct {
+	Windows        []MaintenanceWindow // Window harian tempat tugas boleh berjalan (kosong = hanya berdasarkan beban)
+	Location       *time.Location      // Zona waktu Windows (nil = time.Local)
+	MaxUtilization float64             // Tugas juga boleh berjalan jika in-use / (in-use + idle) di bawah nilai ini (0 = tidak dipakai)
+	MaxDeferral    time.Duration       // Tugas tetap dijalankan jika sudah ditunda selama ini (0 = tunda tanpa batas)
+}
+
+// open memeriksa apakah tugas berat boleh berjalan pada waktu dan utilisasi tertentu
+func (m *MaintenanceConfig) open(now time.Time, utilization float64) bool {
+	if len(m.Windows) == 0 && m.MaxUtilization <= 0 {
+		return true
+	}
+	if m.MaxUtilization > 0 && utilization < m.MaxUtilization {
+		return true
+	}
+	if m.Location != nil {
+		now = now.In(m.Location)
+	}
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	offset := now.Sub(midnight)
+	for _, window := range m.Windows {
+		if window.contains(offset) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaintenanceStatus adalah keadaan maintenance window sebuah pool
+type MaintenanceStatus struct {
+	Open                  bool      // Apakah tugas berat saat ini boleh berjalan
+	Utilization           float64   // in-use / (in-use + idle) saat ini
+	EvictionDeferredSince time.Time // Awal penundaan sapuan eviksi (nol jika tidak ditunda)
+	AutoTuneDeferredSince time.Time // Awal penundaan auto-tuning (nol jika tidak ditunda)
+}
+
+// utilization mengembalikan rasio objek dipinjam terhadap seluruh objek pool
+func (pm *PoolManager) utilization(entry *poolEntry) float64 {
+	metrics := entry.metrics.Load()
+	inUse, idle := atomic.LoadInt64(&metrics.InUseCount), atomic.LoadInt64(&metrics.IdleCount)
+	if inUse <= 0 {
+		return 0
+	}
+	if idle < 0 {
+		idle = 0
+	}
+	return float64(inUse) / float64(inUse+idle)
+}
+
+// maintenanceAllowed memeriksa apakah tugas latar belakang pool boleh berjalan sekarang. Tugas
+// yang ditunda mencatat awal penundaannya agar MaxDeferral dapat memaksanya berjalan.
+func (pm *PoolManager) maintenanceAllowed(poolName, task string) bool {
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		return true
+	}
+	maintenance := entry.configuration().Maintenance
+	if maintenance == nil {
+		return true
+	}
+
+	deferred := &entry.state.evictionDeferred
+	if task == maintenanceAutoTune {
+		deferred = &entry.state.autoTuneDeferred
+	}
+	now := time.Now()
+	if maintenance.open(now, pm.utilization(entry)) {
+		deferred.Store(0)
+		return true
+	}
+
+	deferred.CompareAndSwap(0, now.UnixNano())
+	since := time.Unix(0, deferred.Load())
+	if maintenance.MaxDeferral > 0 && now.Sub(since) >= maintenance.MaxDeferral {
+		deferred.Store(0)
+		pm.logPoolf(InfoLevel, poolName, "Running %s for pool: %s outside maintenance window after deferring for %s",
+			task, poolName, now.Sub(since).Round(time.Second))
+		return true
+	}
+	pm.logPoolf(DebugLevel, poolName, "Deferred %s for pool: %s until maintenance window", task, poolName)
+	return false
+}
+
+// MaintenanceStatus mengembalikan keadaan maintenance window pool. Pool tanpa MaintenanceConfig
+// selalu terbuka.
+func (pm *PoolManager) MaintenanceStatus(poolName string) (MaintenanceStatus, error) {
+	entry, err := pm.lookupEntry(poolName, "maintenance")
+	if err != nil {
+		return MaintenanceStatus{}, err
+	}
+	status := MaintenanceStatus{
+		Open:                  true,
+		Utilization:           pm.utilization(entry),
+		EvictionDeferredSince: unixNanoToTime(entry.state.evictionDeferred.Load()),
+		AutoTuneDeferredSince: unixNanoToTime(entry.state.autoTuneDeferred.Load()),
+	}
+	if maintenance := entry.configuration().Maintenance; maintenance != nil {
+		status.Open = maintenance.open(time.Now(), status.Utilization)
+	}
+	return status, nil
+}
@@ -1,13 +1,13 @@
 package poolmanager
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
 	"hash/fnv"
-	"log"
 	"math/big"
-	"os"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -16,145 +16,268 @@ import (
 // PoolManager adalah struct untuk mengelola pooling objek
 // Menyediakan fitur seperti auto-tuning, sharding, caching, dan eviksi
 type PoolManager struct {
-	pools             sync.Map         // Menyimpan pool berdasarkan tipe objek
-	poolConfig        sync.Map         // Menyimpan konfigurasi untuk setiap pool
-	instanceFactories sync.Map         // Menyimpan factory function untuk membuat objek baru
-	metrics           sync.Map         // Menyimpan metrik penggunaan pool
-	itemMetadata      sync.Map         // Metadata untuk setiap item di pool
-	autoTuneTicker    *time.Ticker     // Ticker untuk auto-tuning pool
-	autoTuneStop      chan struct{}    // Channel untuk menghentikan auto-tuning
-	logger            *log.Logger      // Logger untuk mencatat log pool
-	monitoringConfig  MonitoringConfig // Konfigurasi monitoring untuk mencatat metrik
-	evictionPolicy    EvictionPolicy   // Kebijakan eviksi yang digunakan untuk pool
-	shardingStrategy  ShardingStrategy // Strategi sharding untuk membagi pool
-	shardCounter      int64            // Counter untuk round-robin sharding
-	cache             sync.Map         // Menyimpan cache untuk objek yang sering digunakan
+	entries          sync.Map                         // Menyimpan poolEntry (pool, konfigurasi, factory, metrik) setiap pool
+	itemMetadata     sync.Map                         // Metadata untuk setiap item di pool
+	autoTuneMu       sync.Mutex                       // Melindungi autoTuneCancel
+	autoTuneCancel   context.CancelFunc               // Menghentikan auto-tuning global (nil jika tidak berjalan)
+	monitoringConfig atomic.Pointer[MonitoringConfig] // Konfigurasi monitoring untuk mencatat metrik, diganti utuh oleh setter
+	evictionPolicy   atomic.Pointer[EvictionPolicy]   // Kebijakan eviksi default untuk pool tanpa kebijakan sendiri
+	shardingStrategy atomic.Pointer[ShardingStrategy] // Strategi sharding default untuk pool tanpa strategi sendiri (nil jika tidak diatur)
+	shardCounter     int64                            // Counter untuk round-robin sharding
+	cache            sync.Map                         // Menyimpan cache untuk objek yang sering digunakan
+	checkouts        sync.Map                         // Menyimpan catatan instance yang sedang dipinjam
+	poolStates       sync.Map                         // Menyimpan status tugas latar belakang untuk setiap pool
+	sampler          telemetrySampler                 // Sampler untuk telemetri operasi frekuensi tinggi
+	operations       sync.Map                         // Menyimpan riwayat operasi terakhir setiap pool untuk laporan drift
+	waits            sync.Map                         // Menyimpan statistik waktu tunggu acquire setiap pool
+	coldTiers        sync.Map                         // Menyimpan kunci objek setiap pool yang berada di cold tier
+	capacity         sync.Map                         // Menyimpan observasi kapasitas per menit setiap pool
+	ownerUsage       sync.Map                         // Menyimpan penggunaan per pemilik setiap pool
+	wrapped          sync.Map                         // Menyimpan instance asli dari pembungkus yang dibuat InstanceInterceptor
+	middlewareMu     sync.Mutex                       // Melindungi pendaftaran middleware
+	middlewares      []PoolMiddleware                 // Middleware yang terdaftar, sesuai urutan Use
+	middleware       atomic.Pointer[middlewareChain]  // Rantai middleware yang sudah disusun (nil jika kosong)
+	coalesceMu       sync.Mutex                       // Melindungi coalesced dan coalescedHeld
+	coalesced        map[string]*coalescedCall        // Acquire berkunci yang sedang berjalan atau dipegang, per pool dan kunci
+	coalescedHeld    map[string]*coalescedCall        // Acquire berkunci yang sedang dipegang, per instanceKey
+	reclaimed        sync.Map                         // Lease instance yang diambil kembali oleh AcquireBound, per instanceKey
+	loaded           sync.Map                         // Instance idle AcquireOrLoad, per loadedKey
+	closed           atomic.Bool                      // Apakah Close sudah dipanggil
 }
 
 // InitializePool menginisialisasi pool baru dengan konfigurasi yang diberikan.
 // poolName: tipe objek pool yang ingin dibuat.
 // config: konfigurasi pool yang digunakan.
 // factory: fungsi untuk membuat objek baru yang akan dimasukkan ke dalam pool.
-// InitializePool menginisialisasi pool baru dengan konfigurasi yang diberikan.
+//
+// Deprecated: gunakan AddPool. InitializePool kini hanya membungkus AddPool sehingga
+// sharding, eviksi, auto-tuning, dan callback berperilaku sama.
 func (pm *PoolManager) InitializePool(poolName string, config PoolConfiguration, factory func() interface{}) error {
-	// Periksa apakah pool sudah ada
-	if _, exists := pm.pools.Load(poolName); exists {
-		return errors.New("pool already exists: " + poolName)
-	}
-
-	// Membuat sync.Pool baru
-	newPool := &sync.Pool{
-		New: factory,
+	if factory == nil {
+		return NewPoolError(poolName, "add", errors.New(ErrInvalidFactoryType))
 	}
+	_, err := pm.AddPool(poolName, func() PoolAble {
+		instance, _ := factory().(PoolAble)
+		return instance
+	}, config)
+	return err
+}
 
-	// Simpan konfigurasi dan pool ke dalam map
-	pm.pools.Store(poolName, newPool)
-	pm.poolConfig.Store(poolName, config)
-	pm.instanceFactories.Store(poolName, factory)
-
-	// Log inisialisasi pool
-	pm.logger.Println("Initializing pool:", poolName)
-	pm.logger.Println("Pool configuration:", config)
+// NewPoolManager membuat instance PoolManager baru dengan logger default, atau dengan config.Logger
+// sebagai MonitoringConfig.Logger jika diatur
+func NewPoolManager(config PoolConfiguration) *PoolManager {
+	pm := newManager(config)
 
-	// Inisialisasi auto-tuning jika diaktifkan dan intervalnya positif
+	// Jika AutoTune diaktifkan, mulai ticker untuk auto-tuning
 	if config.AutoTune && config.AutoTuneInterval > 0 {
-		pm.autoTuneTicker = time.NewTicker(config.AutoTuneInterval)
-		go pm.autoTune(poolName, config)
-	} else if config.AutoTune {
-		// Log jika AutoTuneInterval tidak valid
-		pm.logger.Println("Invalid AutoTuneInterval, auto-tuning not started for pool:", poolName)
-	}
-
-	// Mengisi pool dengan objek berdasarkan initialSize dari konfigurasi
-	for i := 0; i < config.InitialSize; i++ {
-		newPool.Put(factory())
+		pm.startAutoTune(config.Name, config)
 	}
 
-	// Mengatur sharding jika diaktifkan
-	if config.ShardingEnabled {
-		pm.shardingStrategy = config.ShardStrategy
-		pm.shardCounter = int64(config.ShardCount)
-		pm.logger.Println("Sharding enabled for pool:", poolName, "Shard count:", config.ShardCount)
+	// Jika TTL atau MaxIdleTime diatur, jalankan kebijakan eviksi
+	if config.evictionEnabled() {
+		pm.startEviction(config.Name, config.EvictionInterval)
 	}
 
-	// Mengatur kebijakan eviction
-	pm.evictionPolicy = config.Eviction
-	if config.TTL > 0 {
-		go pm.runEviction(poolName, config.EvictionInterval)
-		pm.logger.Println("Eviction policy set for pool:", poolName, "TTL:", config.TTL)
-	}
-
-	return nil
+	return pm
 }
 
-// NewPoolManager membuat instance PoolManager baru dengan logger default
-// Menginisialisasi channel autoTuneStop dan logger
-func NewPoolManager(config PoolConfiguration) *PoolManager {
+// newManager membuat PoolManager dengan logger, strategi sharding, dan kebijakan eviksi dari config
+// tanpa menjalankan tugas latar belakang untuk config.Name
+func newManager(config PoolConfiguration) *PoolManager {
 	// Membuat PoolManager baru dengan konfigurasi yang diberikan
-	pm := &PoolManager{
-		autoTuneStop:     make(chan struct{}),                                 // Channel untuk menghentikan auto-tuning
-		logger:           log.New(os.Stdout, "POOL_MANAGER: ", log.LstdFlags), // Logger default
-		shardingStrategy: config.ShardStrategy,                                // Gunakan strategi sharding dari konfigurasi
-		evictionPolicy:   config.Eviction,                                     // Kebijakan eviksi dari konfigurasi
-		monitoringConfig: MonitoringConfig{},                                  // Konfigurasi monitoring default
+	pm := &PoolManager{}
+	// Konfigurasi monitoring default; logger dari konfigurasi menggantikan logger bawaan
+	pm.monitoringConfig.Store(&MonitoringConfig{EnableLogging: true, LogLevel: InfoLevel, Logger: config.Logger})
+
+	// Strategi sharding dan kebijakan eviksi dari konfigurasi menjadi default untuk pool tanpa milik sendiri
+	if config.ShardStrategy != nil {
+		pm.shardingStrategy.Store(&config.ShardStrategy)
+	}
+	if config.Eviction != nil {
+		pm.evictionPolicy.Store(&config.Eviction)
 	}
 
 	// Inisialisasi peta (sync.Map) lainnya untuk memastikan siap digunakan
-	pm.pools = sync.Map{}
-	pm.poolConfig = sync.Map{}
-	pm.instanceFactories = sync.Map{}
-	pm.metrics = sync.Map{}
+	pm.entries = sync.Map{}
 	pm.itemMetadata = sync.Map{}
 	pm.cache = sync.Map{}
 
-	// Jika AutoTune diaktifkan, mulai ticker untuk auto-tuning
-	if config.AutoTune && config.AutoTuneInterval > 0 {
-		pm.autoTuneTicker = time.NewTicker(config.AutoTuneInterval)
-		go pm.autoTune(config.Name, config)
-	}
-
-	// Jika TTL diatur, jalankan kebijakan eviksi
-	if config.TTL > 0 {
-		go pm.runEviction(config.Name, config.EvictionInterval)
-	}
-
 	return pm
 }
 
 // SetMonitoringConfig menetapkan konfigurasi monitoring untuk PoolManager
-// MonitoringConfig digunakan untuk mengatur bagaimana log dan metrik dicatat
+// MonitoringConfig digunakan untuk mengatur bagaimana log dan metrik dicatat.
+// Konfigurasi menggantikan seluruh nilai sebelumnya; atur EnableLogging ke true
+// jika log masih ingin dicatat, atau ke false agar PoolManager sepenuhnya senyap.
+// Konfigurasi dipublikasikan secara atomik sehingga aman diganti saat tugas latar belakang berjalan.
 func (pm *PoolManager) SetMonitoringConfig(config MonitoringConfig) {
-	pm.monitoringConfig = config
+	pm.monitoringConfig.Store(&config)
+}
+
+// monitoring mengembalikan konfigurasi monitoring saat ini. Nilai yang dikembalikan tidak boleh
+// diubah; pemanggil membacanya sekali per operasi agar seluruh field berasal dari konfigurasi yang sama.
+func (pm *PoolManager) monitoring() *MonitoringConfig {
+	if config := pm.monitoringConfig.Load(); config != nil {
+		return config
+	}
+	return &MonitoringConfig{}
+}
+
+// updateMonitoringConfig menerapkan update pada salinan konfigurasi monitoring lalu mempublikasikannya.
+// Pembaruan yang bersamaan diterapkan satu per satu tanpa saling menimpa.
+func (pm *PoolManager) updateMonitoringConfig(update func(config *MonitoringConfig)) {
+	for {
+		current := pm.monitoringConfig.Load()
+		next := MonitoringConfig{}
+		if current != nil {
+			next = *current
+		}
+		update(&next)
+		if pm.monitoringConfig.CompareAndSwap(current, &next) {
+			return
+		}
+	}
+}
+
+// UpdatePoolConfig mengganti konfigurasi pool yang sedang berjalan dan menjalankan ulang
+// tugas latar belakangnya (auto-tuning dan eviksi) sesuai konfigurasi baru.
+// Tata letak sharding tidak dapat diubah tanpa membuat ulang pool sehingga perubahan
+// ShardingEnabled atau ShardCount ditolak. Konfigurasi baru dipublikasikan secara atomik:
+// operasi yang sedang berjalan tetap memakai konfigurasi lama secara utuh, dan pembaruan yang
+// bersamaan diterapkan satu per satu.
+func (pm *PoolManager) UpdatePoolConfig(poolName string, config PoolConfiguration) error {
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		return NewPoolError(poolName, "config", errors.New(ErrInvalidPoolConfigType))
+	}
+	entry.updateMu.Lock()
+	defer entry.updateMu.Unlock()
+
+	current := entry.configuration()
+	if err := config.Validate(); err != nil {
+		return NewPoolError(poolName, "update", err)
+	}
+	if config.ShardingEnabled != current.ShardingEnabled || (config.ShardingEnabled && config.ShardCount != current.ShardCount) {
+		return NewPoolError(poolName, "update", errors.New("sharding layout cannot be changed on a running pool"))
+	}
+	if config.Backend != current.Backend || (config.Backend == BackendBounded && config.boundedLimit() != current.boundedLimit()) {
+		return NewPoolError(poolName, "update", errors.New("backend and bounded limit cannot be changed on a running pool"))
+	}
+
+	config.Name = poolName
+	entry.setConfiguration(config)
+
+	// Jalankan ulang tugas latar belakang agar konfigurasi baru langsung berlaku
+	state := entry.state
+	state.setCapacity(config.MaxActive, config.MaxWaiters)
+	state.stopAutoTune()
+	if config.AutoTune {
+		pm.startAutoTune(poolName, config)
+	}
+	if config.evictionEnabled() {
+		pm.startEviction(poolName, config.EvictionInterval)
+	} else {
+		state.stopEviction()
+	}
+
+	pm.logPoolf(InfoLevel, poolName, "Updated configuration for pool: %s", poolName)
+	return nil
 }
 
 // AddPool menambahkan pool baru dengan tipe tertentu dan konfigurasi yang ditentukan
 // poolName: tipe pool yang ditambahkan
 // factory: fungsi untuk membuat objek baru dalam pool
 // config: konfigurasi untuk pool yang ditambahkan
-func (pm *PoolManager) AddPool(poolName string, factory func() PoolAble, config PoolConfiguration) error {
-	if _, exists := pm.pools.Load(poolName); exists {
-		return NewPoolError(poolName, "add", errors.New(ErrPoolDoesNotExist+poolName))
+// AddPool adalah satu-satunya jalur pembuatan pool: penyimpanan (sharded atau tidak), pengisian
+// awal, serta tugas latar belakang auto-tuning dan eviksi semuanya diatur di sini.
+// Mengembalikan PoolRef yang terikat langsung ke pool sehingga Acquire dan Release pada handle
+// tidak perlu mencari pool berdasarkan nama.
+func (pm *PoolManager) AddPool(poolName string, factory func() PoolAble, config PoolConfiguration) (*PoolRef, error) {
+	return pm.addPool(poolName, factory, config, nil)
+}
+
+// addPool adalah implementasi AddPool. adopted diisi oleh AdoptPool untuk pool yang dibangun
+// di atas sync.Pool milik pemanggil.
+func (pm *PoolManager) addPool(poolName string, factory func() PoolAble, config PoolConfiguration, adopted *adoptedPool) (*PoolRef, error) {
+	if err := pm.checkOpen(poolName, "add"); err != nil {
+		return nil, err
+	}
+	if err := ValidatePoolName(poolName); err != nil {
+		return nil, NewPoolError(poolName, "add", err)
+	}
+	if factory == nil {
+		return nil, NewPoolError(poolName, "add", errors.New(ErrInvalidFactoryType))
+	}
+	config.Name = poolName
+	if err := config.validateBackend(); err != nil {
+		return nil, NewPoolError(poolName, "add", err)
 	}
 
 	var pool interface{}
-
-	if config.ShardingEnabled && config.ShardCount > 1 {
+	if config.Backend == BackendBounded {
+		pool = newBoundedStore(config.boundedLimit())
+	} else if config.ShardingEnabled && config.ShardCount > 1 {
 		shardedPools := make([]*sync.Pool, config.ShardCount)
 		for i := 0; i < config.ShardCount; i++ {
-			shardedPools[i] = &sync.Pool{New: func() interface{} { return factory() }}
+			// New sengaja dikosongkan agar pool yang kosong dapat dibedakan dari objek baru
+			shardedPools[i] = &sync.Pool{}
 		}
 		pool = shardedPools
 	} else {
-		pool = &sync.Pool{New: func() interface{} { return factory() }}
+		pool = &sync.Pool{}
+	}
+
+	entry := &poolEntry{name: poolName, backend: pool, factory: factory, state: pm.poolStateFor(poolName), adopted: adopted}
+	entry.setConfiguration(config)
+	entry.metrics.Store(newPoolMetrics(config.ShardCount))
+	if _, exists := pm.entries.LoadOrStore(poolName, entry); exists {
+		return nil, NewPoolError(poolName, "add", errors.New("pool already exists: "+poolName))
+	}
+	if config.MaxActive > 0 {
+		entry.state.setCapacity(config.MaxActive, config.MaxWaiters)
 	}
 
-	pm.pools.Store(poolName, pool)
-	pm.poolConfig.Store(poolName, config)
-	pm.instanceFactories.Store(poolName, factory)
+	pm.logPoolf(InfoLevel, poolName, "Initializing pool: %s", poolName)
+	pm.logPoolf(DebugLevel, poolName, "Pool configuration: %+v", config.Describe())
+
+	if err := pm.seedPool(poolName, pool, config, factory); err != nil {
+		// Pool yang gagal diisi tidak boleh tertinggal setengah jadi
+		_ = pm.RemovePool(poolName)
+		return nil, err
+	}
+
+	if config.ShardingEnabled && config.ShardCount > 1 {
+		pm.logPoolf(InfoLevel, poolName, "Sharding enabled for pool: %s, Shard count: %d", poolName, config.ShardCount)
+	}
+
+	// Jalankan tugas latar belakang sesuai konfigurasi
+	if config.AutoTune {
+		pm.startAutoTune(poolName, config)
+	}
+	if config.evictionEnabled() {
+		pm.startEviction(poolName, config.EvictionInterval)
+		pm.logPoolf(InfoLevel, poolName, "Eviction policy set for pool: %s, TTL: %s, MaxIdleTime: %s", poolName, config.TTL, config.MaxIdleTime)
+	}
+
+	return &PoolRef{pm: pm, name: poolName, entry: entry}, nil
+}
 
+// seedPool mengisi pool dengan objek baru sebanyak InitialSize dari konfigurasi
+// poolName: tipe pool yang diisi
+// pool: referensi ke pool (sharded atau non-sharded)
+// config: konfigurasi pool
+// factory: fungsi untuk membuat objek baru
+func (pm *PoolManager) seedPool(poolName string, pool interface{}, config PoolConfiguration, factory func() PoolAble) error {
 	if config.InitialSize > 0 {
 		for i := 0; i < config.InitialSize; i++ {
-			instance := factory()
+			// Pool berbatas tidak diisi melebihi batas objek hidupnya
+			if !reserveLive(pool) {
+				break
+			}
+			instance := pm.construct(poolName, config, factory)
+			if instance == nil {
+				return NewPoolError(poolName, "seed", errors.New("factory returned nil instance"))
+			}
 
 			// Panggil callback OnCreate jika ada
 			if config.OnCreate != nil {
@@ -164,85 +287,283 @@ func (pm *PoolManager) AddPool(poolName string, factory func() PoolAble, config
 			if config.ShardingEnabled && config.ShardCount > 1 {
 				shardedPools, ok := pool.([]*sync.Pool)
 				if !ok {
-					return NewPoolError(poolName, "add", errors.New(ErrInvalidShardedPoolName))
+					return NewPoolError(poolName, "seed", errors.New(ErrInvalidShardedPoolName))
 				}
 
 				// Menggunakan generator nomor acak yang aman
 				shardIndex, err := rand.Int(rand.Reader, big.NewInt(int64(config.ShardCount)))
 				if err != nil {
 					// Tangani kesalahan jika generator nomor acak gagal
-					pm.logger.Printf("Failed to generate secure random number for sharding: %v", err)
+					pm.logf(WarningLevel, "Failed to generate secure random number for sharding: %v", err)
 					shardIndex = big.NewInt(0) // Fallback ke indeks shard 0 jika terjadi kesalahan
 				}
 
 				shardedPools[int(shardIndex.Int64())].Put(instance)
+				pm.adjustGauges(poolName, int(shardIndex.Int64()), 1, 0)
 			} else {
-				nonShardedPool, ok := pool.(*sync.Pool)
+				nonShardedPool, ok := pool.(idleStore)
 				if !ok {
-					return NewPoolError(poolName, "add", errors.New(ErrInvalidNonShardedPoolName))
+					return NewPoolError(poolName, "seed", errors.New(ErrInvalidNonShardedPoolName))
 				}
 				nonShardedPool.Put(instance)
+				pm.adjustGauges(poolName, -1, 1, 0)
 			}
+			pm.adjustRetainedBytes(poolName, estimateSize(instance))
 		}
 	}
-	pm.initMetrics(poolName)
 	return nil
 }
 
 // AcquireInstance mengambil instance dari pool dengan tipe tertentu
 // poolName: tipe pool tempat mengambil instance
-// Mengembalikan objek PoolAble dan error jika terjadi kesalahan
-func (pm *PoolManager) AcquireInstance(poolName string) (PoolAble, error) {
-	// Ambil konfigurasi pool
-	conf, err := pm.getPoolConfiguration(poolName)
+// opts: opsi per pemanggilan seperti WithShardKey, WithOwner, atau WithNoCreate
+// Mengembalikan objek PoolAble dan error jika terjadi kesalahan.
+// Middleware yang didaftarkan dengan Use dijalankan sebelum acquire inti.
+func (pm *PoolManager) AcquireInstance(poolName string, opts ...AcquireOption) (PoolAble, error) {
+	if chain := pm.middleware.Load(); chain != nil {
+		return chain.acquire(poolName, opts...)
+	}
+	return pm.acquireInstance(poolName, opts...)
+}
+
+// AcquireInstanceContext mengambil instance seperti AcquireInstance, namun menunggu hingga instance
+// tersedia atau ctx selesai. Pada pool yang dibatasi MaxActive, pemanggil menunggu unit in-use
+// dilepas alih-alih menunggu tanpa batas waktu; jika ctx dibatalkan atau melewati tenggat, error
+// yang dikembalikan membungkus ctx.Err() sehingga dapat diperiksa dengan errors.Is.
+func (pm *PoolManager) AcquireInstanceContext(ctx context.Context, poolName string, opts ...AcquireOption) (PoolAble, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, NewPoolError(poolName, "get", err)
+	}
+	return pm.AcquireInstance(poolName, append(opts, withAcquireContext(ctx))...)
+}
+
+// errPoolExhausted menandai acquire TryAcquireInstance yang harus menunggu atau melampaui MaxSize
+var errPoolExhausted = errors.New("pool exhausted")
+
+// TryAcquireInstance mengambil instance tanpa pernah menunggu. Instance idle diserahkan seperti
+// AcquireInstance, dan instance baru dibuat selama MaxSize belum tercapai. Jika pool kosong dan
+// MaxSize (atau batas pool berbatas) sudah tercapai, atau unit MaxActive habis, TryAcquireInstance
+// langsung mengembalikan false tanpa memanggil factory, misalnya untuk jalur load-shedding.
+// Error hanya dikembalikan untuk kegagalan lain seperti pool yang tidak terdaftar.
+func (pm *PoolManager) TryAcquireInstance(poolName string, opts ...AcquireOption) (PoolAble, bool, error) {
+	instance, err := pm.AcquireInstance(poolName, append(opts, withTryAcquire())...)
+	if errors.Is(err, errPoolExhausted) || errors.Is(err, errBoundedFull) {
+		return nil, false, nil
+	}
 	if err != nil {
+		return nil, false, err
+	}
+	return instance, true, nil
+}
+
+// sizeLimitReached melaporkan apakah pool tanpa batas keras sudah memiliki MaxSize instance yang
+// dipinjam. Dipanggil saat pool kosong sehingga jumlah objek hidup sama dengan jumlah peminjaman.
+// Pool berbatas menegakkan batasnya sendiri melalui awaitBounded.
+func sizeLimitReached(entry *poolEntry, conf PoolConfiguration) bool {
+	if _, bounded := entry.backend.(*boundedStore); bounded || conf.MaxSize <= 0 {
+		return false
+	}
+	return atomic.LoadInt64(&entry.metrics.Load().InUseCount) >= int64(conf.MaxSize)
+}
+
+// acquireInstance adalah implementasi inti AcquireInstance tanpa middleware
+func (pm *PoolManager) acquireInstance(poolName string, opts ...AcquireOption) (PoolAble, error) {
+	if err := pm.checkOpen(poolName, "get"); err != nil {
+		return nil, err
+	}
+	// Ambil entri pool sekali untuk konfigurasi, backend, factory, dan semaphore
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		err := errors.New("pool does not exist: " + poolName)
 		pm.handleError(poolName, err)
 		return nil, err
 	}
+	return pm.acquireFrom(entry, opts...)
+}
+
+// acquireFrom mengambil instance dari entri pool yang sudah di-resolve
+func (pm *PoolManager) acquireFrom(entry *poolEntry, opts ...AcquireOption) (PoolAble, error) {
+	// Handle PoolRef tetap dapat mencapai pool selama Close menunggu instance dikembalikan
+	if err := pm.checkOpen(entry.name, "get"); err != nil {
+		return nil, err
+	}
+	// Selama profil pool diambil, acquire diberi label pprof
+	if entry.state.profiling.Load() > 0 {
+		return pm.acquireProfiled(entry, opts)
+	}
+	return pm.acquireEntry(entry, opts...)
+}
+
+// acquireEntry mengambil instance dari entri pool dengan menerapkan opsi acquire
+func (pm *PoolManager) acquireEntry(entry *poolEntry, opts ...AcquireOption) (PoolAble, error) {
+	// Selama failover, acquire dilayani oleh pool shadow
+	entry = pm.routeAcquire(entry)
+	o := newAcquireOptions(opts)
+	if o.leaseID == "" {
+		o.leaseID = newLeaseID()
+	}
+	if o.coalesceKey != "" {
+		if entry.configuration().LeaseDuration > 0 {
+			err := NewPoolError(entry.name, "get", errLeasedCoalesce)
+			pm.handleError(entry.name, err)
+			return nil, err
+		}
+		return pm.acquireCoalesced(entry, o, coalesceKey(entry.name, o.coalesceKey), func(o acquireOptions) (PoolAble, error) {
+			return pm.acquireWith(entry, o)
+		})
+	}
+	return pm.acquireWith(entry, o)
+}
+
+// acquireWith mengambil instance dari entri pool dengan opsi yang sudah diterapkan
+func (pm *PoolManager) acquireWith(entry *poolEntry, o acquireOptions) (PoolAble, error) {
+	poolName, conf := entry.name, entry.configuration()
+
+	// Tunggu hingga unit in-use tersedia jika pool dibatasi MaxActive. Unit dipegang oleh
+	// catatan peminjaman; jika instance tidak dapat dilacak, unit dikembalikan saat fungsi selesai.
+	if sem, poolCtx := entry.state.semaphore.Load(), entry.state.ctx; sem != nil {
+		if err := pm.acquireUnits(poolName, conf, sem, poolCtx, o); err != nil {
+			if errors.Is(err, errPoolExhausted) {
+				pm.tracef("acquire pool=%s rejected reason=MaxActive exhausted", poolName)
+				return nil, NewPoolError(poolName, "get", err)
+			}
+			if poolCtx.Err() != nil {
+				err = errors.New(ErrPoolDoesNotExist + poolName)
+			}
+			err = NewPoolError(poolName, "get", err)
+			pm.handleError(poolName, err)
+			pm.observeOwnerFailure(poolName, o.owner)
+			return nil, err
+		}
+		o.semaphore = sem
+		defer func() {
+			if o.semaphore != nil {
+				o.semaphore.Release(o.weight)
+			}
+		}()
+	}
 
 	// Coba mengambil dari cache terlebih dahulu jika caching diaktifkan
 	if conf.EnableCaching {
 		if cachedInstance, found := pm.cache.Load(poolName); found {
 			if poolAbleInstance, ok := cachedInstance.(PoolAble); ok {
 				// Perbarui metadata saat instance diambil dari cache
-				pm.updateMetadata(poolName, "Active")
+				pm.updateMetadata(poolName, poolAbleInstance, "Active", o)
+				pm.observeOwnerAcquire(poolName, o.owner)
 				pm.recordMetric(poolName, "cache_hit")
+				pm.adjustGauges(poolName, -1, 0, 1)
+				if pm.trackCheckout(poolName, conf, poolAbleInstance, o, -1) != nil {
+					o.semaphore = nil
+				}
+				pm.triggerCallback(conf.OnCacheHit, poolName)
 				pm.triggerCallback(conf.OnGet, poolName)
-				return poolAbleInstance, nil
+				pm.triggerInstanceCallback(conf.OnGetInstance, poolName, poolAbleInstance)
+				pm.triggerLifecycle(conf, "get", poolName, poolAbleInstance)
+				pm.observeOperation(PoolEvent{Type: EventAcquire, PoolName: poolName, Item: poolAbleInstance, LeaseID: o.leaseID},
+					"acquire pool=%s key=%s lease=%s source=cache", poolName, metadataKey(poolName, poolAbleInstance), o.leaseID)
+				return pm.interceptAcquire(poolName, conf, poolAbleInstance), nil
 			}
 		}
 	}
 
-	// Jika tidak ada di cache, lanjutkan dengan pengambilan dari pool
-	pool, ok := pm.pools.Load(poolName)
-	if !ok {
-		err := errors.New("pool does not exist: " + poolName)
+	// Jika tidak ada di cache, ambil instance dari pool, dengan dukungan untuk sharding jika diaktifkan
+	var stale PoolAble // Item kedaluwarsa yang ditahan untuk ServeStale
+	instance, shard, err := pm.getInstanceFromPool(poolName, entry.backend, conf, o.shardKey)
+	if err != nil {
 		pm.handleError(poolName, err)
 		return nil, err
 	}
+	// Dengan HealthScoring, instance idle yang lebih sehat diutamakan
+	if instance != nil && conf.Health != nil {
+		instance, shard = pm.selectHealthy(entry, conf, instance, shard, o.shardKey)
+	}
 
-	// Ambil instance dari pool, dengan dukungan untuk sharding jika diaktifkan
-	instance, err := pm.getInstanceFromPool(poolName, pool, conf)
-	if err != nil {
-		pm.handleError(poolName, err)
-		return nil, err
+	if instance == nil {
+		// Pool kosong: objek idle yang tercatat mungkin sudah dibuang oleh GC. Penyimpanan berbatas
+		// tidak kehilangan objek sehingga gauge-nya tidak perlu dikoreksi.
+		if _, bounded := entry.backend.(*boundedStore); !bounded {
+			pm.resetIdleGauge(poolName, shard)
+		}
+	} else {
+		// Instance berasal dari pool sehingga tidak lagi ditahan sebagai objek idle
+		if poolAbleInstance, ok := instance.(PoolAble); ok {
+			pm.adjustRetainedBytes(poolName, -estimateSize(poolAbleInstance))
+			// Item yang ExpirationTime-nya sudah lewat atau gagal Validate dihancurkan dan diganti seperti
+			// pool kosong. Dengan ServeStale, item kedaluwarsa ditahan hingga diketahui apakah penggantinya
+			// berhasil dibuat.
+			if stale = pm.takeStale(poolName, conf, poolAbleInstance); stale != nil ||
+				pm.discardExpired(poolName, conf, poolAbleInstance) || pm.discardInvalid(poolName, conf, poolAbleInstance) {
+				instance = nil
+			}
+		}
+		pm.adjustGauges(poolName, shard, -1, 0)
 	}
 
-	// Jika instance tidak ada di pool, buat instance baru menggunakan factory
+	source := "pool"
 	if instance == nil {
-		factoryVal, _ := pm.instanceFactories.Load(poolName)
-		factory, ok := factoryVal.(func() PoolAble)
-		if !ok {
-			err := errors.New("invalid factory for pool: " + poolName)
-			pm.handleError(poolName, err)
-			return nil, err
+		if cold := pm.rehydrateFromCold(poolName, conf); cold != nil {
+			// Pool kosong namun cold tier masih menyimpan objek yang dapat dihidrasi kembali
+			source = "cold"
+			instance = cold
+		} else if o.noCreate {
+			// Pemanggil tidak mengizinkan pembuatan instance baru
+			if stale != nil {
+				pm.discardExpired(poolName, conf, stale)
+			}
+			pm.tracef("acquire pool=%s rejected reason=pool empty and creation disabled", poolName)
+			return nil, NewPoolError(poolName, "get", ErrNoIdleInstance)
+		} else if o.tryOnly && stale == nil && sizeLimitReached(entry, conf) {
+			// TryAcquireInstance tidak membuat instance melampaui MaxSize
+			pm.tracef("acquire pool=%s rejected reason=pool empty and MaxSize reached", poolName)
+			return nil, NewPoolError(poolName, "get", errPoolExhausted)
+		} else {
+			// Growth guard dapat menolak pembuatan saat pool tumbuh tak terkendali
+			guardErr := pm.guardGrowth(entry, conf)
+			var returned PoolAble
+			if guardErr == nil {
+				// Pool berbatas menunggu instance dikembalikan atau slot objek hidup tersedia.
+				// Dengan item kedaluwarsa yang ditahan, item tersebut diserahkan alih-alih menunggu.
+				returned, guardErr = pm.awaitBounded(entry, conf, o, stale != nil || o.tryOnly)
+			}
+			if returned != nil {
+				instance = returned
+			} else if guardErr == nil {
+				source = "factory"
+				// Jika instance tidak ada di pool, buat instance baru menggunakan factory
+				instance = pm.construct(poolName, conf, entry.factory)
+				pm.observeMissCapacity(poolName)
+				if instance == nil {
+					pm.releaseLive(poolName)
+				}
+			}
+			if instance == nil && stale != nil {
+				// Pembuatan gagal: serahkan item kedaluwarsa sebagai instance degraded
+				cause := guardErr
+				if cause == nil {
+					cause = errors.New("factory returned nil instance")
+				}
+				pm.serveStale(poolName, stale, cause)
+				source, instance, stale = "stale", stale, nil
+				o.degraded = true
+			} else if guardErr != nil {
+				pm.tracef("acquire pool=%s rejected reason=%v", poolName, guardErr)
+				return nil, NewPoolError(poolName, "get", guardErr)
+			}
 		}
-		instance = factory()
+	}
+	if stale != nil {
+		// Pengganti tersedia sehingga item kedaluwarsa tetap dihancurkan seperti biasa
+		pm.discardExpired(poolName, conf, stale)
 	}
 
 	// Cast instance menjadi PoolAble dan lakukan proses tambahan
 	if poolAbleInstance, ok := instance.(PoolAble); ok {
 		pm.recordMetric(poolName, "get")
+		pm.adjustGauges(poolName, shard, 0, 1)
+		if pm.trackCheckout(poolName, conf, poolAbleInstance, o, shard) != nil {
+			// Unit semaphore kini dipegang oleh catatan peminjaman hingga instance dikembalikan
+			o.semaphore = nil
+		}
 
 		// Tambahkan instance ke cache jika caching diaktifkan
 		if conf.EnableCaching {
@@ -250,10 +571,15 @@ func (pm *PoolManager) AcquireInstance(poolName string) (PoolAble, error) {
 		}
 
 		// Perbarui metadata saat instance diambil dari pool
-		pm.updateMetadata(poolName, "Active")
+		pm.updateMetadata(poolName, poolAbleInstance, "Active", o)
+		pm.observeOwnerAcquire(poolName, o.owner)
 		pm.triggerCallback(conf.OnGet, poolName)
+		pm.triggerInstanceCallback(conf.OnGetInstance, poolName, poolAbleInstance)
+		pm.triggerLifecycle(conf, "get", poolName, poolAbleInstance)
+		pm.observeOperation(PoolEvent{Type: EventAcquire, PoolName: poolName, Item: poolAbleInstance, LeaseID: o.leaseID},
+			"acquire pool=%s key=%s lease=%s source=%s", poolName, metadataKey(poolName, poolAbleInstance), o.leaseID, source)
 
-		return poolAbleInstance, nil
+		return pm.interceptAcquire(poolName, conf, poolAbleInstance), nil
 	}
 
 	// Jika cast gagal, kembalikan error
@@ -266,75 +592,183 @@ func (pm *PoolManager) AcquireInstance(poolName string) (PoolAble, error) {
 // poolName: tipe pool tempat mengambil instance
 // pool: referensi ke pool yang digunakan
 // conf: konfigurasi untuk pool yang digunakan
-// Mengembalikan instance dan error jika terjadi kesalahan
-func (pm *PoolManager) getInstanceFromPool(poolName string, pool interface{}, conf PoolConfiguration) (interface{}, error) {
+// shardKey: kunci untuk memilih shard, kosong jika pemanggil tidak menentukannya
+// Mengembalikan instance (nil jika pool kosong), indeks shard yang dipilih (-1 tanpa sharding),
+// dan error jika terjadi kesalahan
+func (pm *PoolManager) getInstanceFromPool(poolName string, pool interface{}, conf PoolConfiguration, shardKey string) (interface{}, int, error) {
 	if conf.ShardingEnabled && conf.ShardCount > 1 {
 		shardedPools, ok := pool.([]*sync.Pool)
 		if !ok {
-			return nil, NewPoolError(poolName, "get", errors.New(ErrInvalidShardedPoolName))
+			return nil, -1, NewPoolError(poolName, "get", errors.New(ErrInvalidShardedPoolName))
 		}
 
 		// Pastikan jumlah shard sesuai dengan konfigurasi
 		if len(shardedPools) != conf.ShardCount {
-			return nil, NewPoolError(poolName, "get", errors.New("shard count mismatch with configuration"))
+			return nil, -1, NewPoolError(poolName, "get", errors.New("shard count mismatch with configuration"))
 		}
 
-		// Hitung indeks shard
-		shardIndex := pm.getShardIndex(poolName, conf, time.Now().String())
+		// Hitung indeks shard dengan kunci yang stabil: kunci per-acquire, lalu KeyGenerator
+		if shardKey == "" && conf.KeyGenerator != nil {
+			shardKey = conf.KeyGenerator()
+		}
+		shardIndex := pm.getShardIndex(poolName, conf, shardKey)
 
 		// Pastikan indeks shard dalam batas array
 		if shardIndex < 0 || shardIndex >= len(shardedPools) {
-			return nil, NewPoolError(poolName, "get", errors.New("shard index out of range"))
+			return nil, -1, NewPoolError(poolName, "get", errors.New("shard index out of range"))
 		}
-
-		// Ambil instance dari shard yang dipilih
-		instance := shardedPools[shardIndex].Get()
-		if instance == nil {
-			return nil, NewPoolError(poolName, "get", errors.New("no instance available in the selected shard"))
+		if conf.OnShard != nil {
+			conf.OnShard(poolName, shardIndex)
 		}
-		return instance, nil
+
+		// Ambil instance dari shard yang dipilih, nil berarti shard sedang kosong
+		return shardedPools[shardIndex].Get(), shardIndex, nil
 	}
 
 	// Pengambilan dari pool yang tidak menggunakan sharding
-	nonShardedPool, ok := pool.(*sync.Pool)
+	nonShardedPool, ok := pool.(idleStore)
 	if !ok {
-		return nil, NewPoolError(poolName, "get", errors.New(ErrInvalidNonShardedPoolName))
+		return nil, -1, NewPoolError(poolName, "get", errors.New(ErrInvalidNonShardedPoolName))
 	}
 
-	// Ambil instance dari pool
-	instance := nonShardedPool.Get()
-	if instance == nil {
-		return nil, NewPoolError(poolName, "get", errors.New("no instance available in the non-sharded pool"))
-	}
-	return instance, nil
+	// Ambil instance dari pool, nil berarti pool sedang kosong
+	return nonShardedPool.Get(), -1, nil
 }
 
 // ReleaseInstance mengembalikan instance ke pool dengan tipe tertentu
 // poolName: tipe pool tempat mengembalikan instance
 // instance: objek yang akan dikembalikan ke pool
+// Middleware yang didaftarkan dengan Use dijalankan sebelum release inti.
 func (pm *PoolManager) ReleaseInstance(poolName string, instance PoolAble) error {
-	if instance == nil {
-		err := errors.New("cannot put nil instance into pool")
+	if chain := pm.middleware.Load(); chain != nil {
+		return chain.release(poolName, instance)
+	}
+	return pm.releaseInstance(poolName, instance)
+}
+
+// releaseInstance adalah implementasi inti ReleaseInstance tanpa middleware
+func (pm *PoolManager) releaseInstance(poolName string, instance PoolAble) error {
+	// Ambil pool dan konfigurasi dari entri pool
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		err := errors.New("pool does not exist: " + poolName)
 		pm.handleError(poolName, err)
 		return err
 	}
+	return pm.releaseTo(entry, instance)
+}
 
-	// Perbarui metadata saat instance dikembalikan
-	pm.updateMetadata(poolName, "Idle")
+// releaseTo mengembalikan instance ke entri pool yang sudah di-resolve
+func (pm *PoolManager) releaseTo(entry *poolEntry, instance PoolAble) error {
+	// Selama profil pool diambil, release diberi label pprof
+	if entry.state.profiling.Load() > 0 {
+		return pm.releaseProfiled(entry, instance)
+	}
+	return pm.releaseEntry(entry, instance)
+}
 
-	// Ambil pool dan konfigurasi
-	poolVal, ok := pm.pools.Load(poolName)
-	if !ok {
-		err := errors.New("pool does not exist: " + poolName)
+// releaseEntry mengembalikan instance ke entri pool, atau ke pool shadow asalnya
+func (pm *PoolManager) releaseEntry(entry *poolEntry, instance PoolAble) error {
+	// Instance yang dipinjam dari pool shadow dikembalikan ke shadow
+	entry = pm.routeRelease(entry, instance)
+	poolName := entry.name
+	if instance == nil {
+		err := errors.New("cannot put nil instance into pool")
 		pm.handleError(poolName, err)
 		return err
 	}
 
-	conf, err := pm.getPoolConfiguration(poolName)
-	if err != nil {
+	// Instance hasil acquire berkunci baru kembali ke pool saat pemegang terakhir melepasnya
+	if pm.releaseShared(poolName, instance) {
+		return nil
+	}
+
+	// Pembungkus dari InstanceInterceptor dilepas agar objek asli yang dikembalikan ke pool
+	instance = pm.unwrapInstance(poolName, instance)
+
+	// Peminjaman diselesaikan oleh pihak pertama: release, atau pembatalan context AcquireBound.
+	// Release ganda dan instance asing ditolak agar pool dan metrik tidak rusak.
+	if err := pm.settleRelease(poolName, entry.configuration(), instance); err != nil {
+		if errors.Is(err, errReclaimedRelease) {
+			pm.tracef("release pool=%s key=%s action=ignored reason=%v", poolName, metadataKey(poolName, instance), err)
+			return nil
+		}
+		pm.tracef("release pool=%s key=%s action=rejected reason=%v", poolName, metadataKey(poolName, instance), err)
+		err = NewPoolError(poolName, "put", err)
 		pm.handleError(poolName, err)
 		return err
 	}
+	return pm.releaseSettled(entry, instance)
+}
+
+// releaseSettled mengembalikan instance yang peminjamannya sudah diselesaikan ke pool
+func (pm *PoolManager) releaseSettled(entry *poolEntry, instance PoolAble) error {
+	poolName, conf := entry.name, entry.configuration()
+
+	// Perbarui metadata saat instance dikembalikan; ID lease terakhir tetap tersimpan di metadata
+	pm.updateMetadata(poolName, instance, "Idle", acquireOptions{})
+	lease := pm.lastLeaseID(poolName, instance)
+
+	// Instance yang ditandai rusak dengan MarkBroken dihancurkan alih-alih dikembalikan ke pool
+	broken := false
+	if record := pm.checkoutFor(poolName, instance); record != nil {
+		if cause := record.brokenCause(); cause != nil {
+			if conf.Health == nil {
+				return pm.discardInstance(poolName, conf, instance, "broken: "+cause.Error())
+			}
+			broken = true
+		}
+	}
+
+	// Dengan HealthScoring, instance dengan skor di bawah RetireBelow dipensiunkan
+	if conf.Health != nil {
+		health := conf.Health.withDefaults()
+		if score := pm.assessHealth(poolName, health, instance, broken); score < health.RetireBelow {
+			pm.logPoolf(InfoLevel, poolName, "Retired instance from pool: %s, Lease: %s, Health score: %.2f", poolName, lease, score)
+			return pm.discardInstance(poolName, conf, instance, fmt.Sprintf("health score %.2f below %.2f", score, health.RetireBelow))
+		}
+	}
+
+	// Instance yang melebihi MaxLifetime dirotasi alih-alih dikembalikan ke pool
+	if conf.MaxLifetime > 0 {
+		if age, ok := pm.objectAge(poolName, instance); ok && age > conf.MaxLifetime {
+			return pm.discardInstance(poolName, conf, instance, fmt.Sprintf("lifetime %s exceeds MaxLifetime %s", age, conf.MaxLifetime))
+		}
+	}
+
+	// Instance sudah kembali, lepaskan pelacakan peminjaman dan ingat shard asalnya
+	shard := -1
+	if record := pm.untrackCheckout(poolName, instance); record != nil {
+		shard = record.shard
+		// Unit in-use dikembalikan setelah instance masuk ke pool agar waiter mendapat instance idle
+		defer record.releaseUnits()
+		// Instance AcquireOrLoad disimpan untuk kuncinya alih-alih di-reset dan dikembalikan ke pool
+		if record.loadKey != "" {
+			return pm.storeLoaded(entry, record.loadKey, instance, lease)
+		}
+	}
+
+	// Objek yang melebihi batas ukuran tidak dikembalikan ke pool, melainkan dihancurkan
+	size := estimateSize(instance)
+	if conf.MaxPooledObjectBytes > 0 && size > conf.MaxPooledObjectBytes {
+		// Jika cold tier aktif, objek diserialisasi ke cold tier alih-alih dihancurkan
+		if pm.spillToCold(poolName, conf, instance) {
+			pm.adjustGauges(poolName, shard, 0, -1)
+			pm.tracef("release pool=%s key=%s lease=%s action=spill reason=size %d exceeds limit %d",
+				poolName, metadataKey(poolName, instance), lease, size, conf.MaxPooledObjectBytes)
+			return nil
+		}
+		pm.recordMetric(poolName, "discard")
+		pm.adjustGauges(poolName, shard, 0, -1)
+		if err := pm.destroyInstance(poolName, conf, instance); err != nil {
+			pm.handleError(poolName, err)
+		}
+		pm.logPoolf(InfoLevel, poolName, "Discarded oversized instance from pool: %s, Lease: %s, Size: %d bytes, Limit: %d bytes",
+			poolName, lease, size, conf.MaxPooledObjectBytes)
+		pm.tracef("release pool=%s key=%s lease=%s action=discard reason=size %d exceeds limit %d",
+			poolName, metadataKey(poolName, instance), lease, size, conf.MaxPooledObjectBytes)
+		return nil
+	}
 
 	// Reset instance sebelum mengembalikan ke pool
 	instance.Reset()
@@ -343,13 +777,16 @@ func (pm *PoolManager) ReleaseInstance(poolName string, instance PoolAble) error
 	pm.triggerCallbackWithInstance(conf.OnReset, poolName, instance)
 
 	// Masukkan instance kembali ke pool
-	err = pm.putInstanceToPool(poolName, poolVal, conf, instance)
+	putShard, err := pm.putInstanceToPool(poolName, entry.backend, conf, instance, shard)
 	if err != nil {
 		pm.handleError(poolName, err)
 		return err
 	}
 
 	pm.recordMetric(poolName, "put")
+	pm.adjustGauges(poolName, shard, 0, -1)
+	pm.adjustGauges(poolName, putShard, 1, 0)
+	pm.adjustRetainedBytes(poolName, size)
 
 	// Update cache jika caching diaktifkan
 	if conf.EnableCaching {
@@ -358,6 +795,10 @@ func (pm *PoolManager) ReleaseInstance(poolName string, instance PoolAble) error
 
 	// Panggil callback OnPut jika ada
 	pm.triggerCallback(conf.OnPut, poolName)
+	pm.triggerInstanceCallback(conf.OnPutInstance, poolName, instance)
+	pm.triggerLifecycle(conf, "put", poolName, instance)
+	pm.observeOperation(PoolEvent{Type: EventRelease, PoolName: poolName, Item: instance, LeaseID: lease},
+		"release pool=%s key=%s lease=%s action=pooled", poolName, metadataKey(poolName, instance), lease)
 
 	return nil
 }
@@ -367,32 +808,72 @@ func (pm *PoolManager) ReleaseInstance(poolName string, instance PoolAble) error
 // pool: referensi ke pool yang digunakan
 // conf: konfigurasi untuk pool yang digunakan
 // instance: objek yang akan dikembalikan ke pool
-func (pm *PoolManager) putInstanceToPool(poolName string, pool interface{}, conf PoolConfiguration, instance interface{}) error {
+// shard: indeks shard asal instance, -1 jika tidak diketahui sehingga shard dipilih oleh strategi
+// Mengembalikan indeks shard tempat instance disimpan (-1 tanpa sharding) dan error jika terjadi kesalahan
+func (pm *PoolManager) putInstanceToPool(poolName string, pool interface{}, conf PoolConfiguration, instance interface{}, shard int) (int, error) {
 	if conf.ShardingEnabled && conf.ShardCount > 1 {
 		shardedPools, ok := pool.([]*sync.Pool)
 		// reset instance
 		if !ok {
-			return NewPoolError(poolName, "put", errors.New(ErrInvalidShardedPoolName))
+			return -1, NewPoolError(poolName, "put", errors.New(ErrInvalidShardedPoolName))
 		}
-		shardIndex := pm.getShardIndex(poolName, conf, time.Now().String())
-		shardedPools[shardIndex].Put(instance)
-	} else {
-		nonShardedPool, ok := pool.(*sync.Pool)
-		if !ok {
-			return NewPoolError(poolName, "put", errors.New(ErrInvalidNonShardedPoolName))
+		// Kembalikan ke shard asal agar distribusi antar shard tetap seimbang
+		if shard >= 0 && shard < len(shardedPools) {
+			shardedPools[shard].Put(instance)
+			return shard, nil
 		}
-		nonShardedPool.Put(instance)
+
+		// Shard asal tidak diketahui: gunakan identitas instance sebagai kunci yang stabil
+		key := instanceKey(poolName, instance)
+		if key == "" && conf.KeyGenerator != nil {
+			key = conf.KeyGenerator()
+		}
+		shardIndex := pm.getShardIndex(poolName, conf, key)
+		shardedPools[shardIndex].Put(instance)
+		return shardIndex, nil
 	}
-	return nil
+
+	nonShardedPool, ok := pool.(idleStore)
+	if !ok {
+		return -1, NewPoolError(poolName, "put", errors.New(ErrInvalidNonShardedPoolName))
+	}
+	nonShardedPool.Put(instance)
+	return -1, nil
 }
 
 // getShardIndex menghitung indeks shard berdasarkan strategi sharding yang ditentukan
 // poolName: tipe pool yang digunakan
 // conf: konfigurasi untuk pool yang digunakan
 // key: kunci yang digunakan untuk menghitung indeks shard
+// Strategi diambil dari konfigurasi pool, lalu dari strategi global PoolManager. Jika keduanya
+// tidak diatur, kunci di-hash; tanpa kunci, shard dipilih secara round-robin.
 func (pm *PoolManager) getShardIndex(poolName string, conf PoolConfiguration, key string) int {
-	hashValue := hashString(key)
-	return int(hashValue) % conf.ShardCount
+	if conf.ShardCount <= 1 {
+		return 0
+	}
+
+	strategy := conf.ShardStrategy
+	if strategy == nil {
+		if fallback := pm.shardingStrategy.Load(); fallback != nil {
+			strategy = *fallback
+		}
+	}
+
+	var index int
+	switch {
+	case strategy != nil:
+		index = strategy.GetShardIndex(poolName, conf.ShardCount, key)
+	case key != "":
+		index = int(hashString(key) % uint32(conf.ShardCount))
+	default:
+		index = int(atomic.AddInt64(&pm.shardCounter, 1) % int64(conf.ShardCount))
+	}
+
+	// Strategi kustom bisa mengembalikan nilai di luar rentang, jadi normalisasi ke [0, ShardCount)
+	if index < 0 || index >= conf.ShardCount {
+		index = ((index % conf.ShardCount) + conf.ShardCount) % conf.ShardCount
+	}
+	return index
 }
 
 // hashString menghitung nilai hash dari string menggunakan algoritma hash FNV-1a
@@ -408,250 +889,312 @@ func hashString(s string) uint32 {
 }
 
 // RemovePool menghapus pool tertentu berdasarkan tipe
+// Hook OnPoolShutdown dijalankan lebih dulu, lalu tugas latar belakang pool dihentikan dan seluruh
+// objek idle dihancurkan melalui OnDestroy (dan Close jika objek mengimplementasikan io.Closer).
+// Error dari setiap hook dan objek digabungkan.
 func (pm *PoolManager) RemovePool(poolName string) error {
-	// Hapus pool yang terkait dengan tipe yang diberikan
-	pm.pools.Delete(poolName)
-	// Hapus konfigurasi pool
-	pm.poolConfig.Delete(poolName)
-	// Hapus factory instance yang terkait
-	pm.instanceFactories.Delete(poolName)
-	// Hapus metrik yang terkait dengan pool tersebut
-	pm.metrics.Delete(poolName)
+	// Hapus entri pool beserta konfigurasi, factory, metrik, dan labelnya
+	var conf PoolConfiguration
+	var hookErr error
+	entryVal, loaded := pm.entries.LoadAndDelete(poolName)
+	if loaded {
+		entryVal.(*poolEntry).removed.Store(true)
+		conf = entryVal.(*poolEntry).configuration()
+		// Pool tidak lagi menerima acquire baru, namun objeknya belum dihancurkan
+		hookErr = pm.runShutdownHooks(entryVal.(*poolEntry), conf)
+	}
+	pm.operations.Delete(poolName)
+	pm.waits.Delete(poolName)
+	pm.capacity.Delete(poolName)
+	pm.ownerUsage.Delete(poolName)
+	pm.deleteWrappedInstances(poolName)
 	// Hapus cache yang terkait
 	pm.cache.Delete(poolName)
 	// Hapus metadata item
-	pm.itemMetadata.Delete(poolName)
+	pm.deletePoolMetadata(poolName)
+	// Hentikan tugas latar belakang dan hapus statusnya
+	pm.stopPoolState(poolName)
 
-	return nil
+	// Hapus objek pool yang masih tersimpan di cold tier dan instance idle AcquireOrLoad
+	coldErr := errors.Join(hookErr, pm.purgeColdTier(poolName, conf), pm.purgeLoaded(poolName, conf))
+
+	if !loaded {
+		return coldErr
+	}
+	if adopted := entryVal.(*poolEntry).adopted; adopted != nil {
+		// sync.Pool yang diadopsi dikembalikan ke pemiliknya beserta objek idle-nya
+		adopted.restore(entryVal.(*poolEntry).backend)
+		return coldErr
+	}
+	// Hancurkan objek idle yang masih tersimpan di pool
+	return errors.Join(pm.drainPool(poolName, entryVal.(*poolEntry).backend, conf), coldErr)
+}
+
+// ListPools mengembalikan nama seluruh pool yang terdaftar, diurutkan secara alfabetis.
+func (pm *PoolManager) ListPools() []string {
+	var names []string
+	pm.forEachEntry(func(entry *poolEntry) bool {
+		names = append(names, entry.name)
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+// HasPool memeriksa apakah pool dengan nama tertentu sudah terdaftar.
+func (pm *PoolManager) HasPool(poolName string) bool {
+	_, ok := pm.entryFor(poolName)
+	return ok
 }
 
-// GetPoolSize mengembalikan ukuran pool saat ini
+// GetPoolSize mengembalikan ukuran pool saat ini, yaitu jumlah objek idle ditambah objek yang sedang
+// dipinjam menurut gauge IdleCount dan InUseCount
 func (pm *PoolManager) GetPoolSize(poolName string) int {
-	return pm.getPoolCurrentSize(poolName)
+	idle, inUse := pm.poolSize(poolName)
+	return idle + inUse
 }
 
-// GetShardSize mengembalikan ukuran shard tertentu
+// GetShardSize mengembalikan ukuran shard tertentu, yaitu jumlah objek idle ditambah objek yang
+// sedang dipinjam dari shard tersebut
 func (pm *PoolManager) GetShardSize(poolName string, shardIndex int) int {
-	return pm.getShardCurrentSize(poolName, shardIndex)
+	idle, inUse := pm.shardSize(poolName, shardIndex)
+	return idle + inUse
 }
 
+// StartAutoTuning menjalankan auto-tuning global yang menyesuaikan ukuran semua pool setiap menit.
+// Pemanggilan berulang saat auto-tuning sudah berjalan tidak berpengaruh.
 func (pm *PoolManager) StartAutoTuning() {
-	if pm.autoTuneTicker == nil {
-		pm.autoTuneTicker = time.NewTicker(time.Minute) // Set interval auto-tuning
-		go func() {
-			for {
-				select {
-				case <-pm.autoTuneTicker.C:
-					pm.autoTunePoolSize()
-				case <-pm.autoTuneStop:
-					if pm.autoTuneTicker != nil {
-						pm.autoTuneTicker.Stop() // Pastikan autoTuneTicker dihentikan
-						pm.autoTuneTicker = nil
-					}
-					return
-				}
-			}
-		}()
+	pm.autoTuneMu.Lock()
+	defer pm.autoTuneMu.Unlock()
+
+	if pm.autoTuneCancel != nil {
+		return
 	}
+	ctx, cancel := context.WithCancel(context.Background())
+	pm.autoTuneCancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(time.Minute) // Interval auto-tuning global
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				pm.autoTunePoolSize()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
 }
 
-// StopAutoTuning menghentikan proses auto-tuning pada PoolManager
+// StopAutoTuning menghentikan proses auto-tuning pada PoolManager, baik auto-tuning global
+// maupun auto-tuning per pool. Aman dipanggil berulang kali dan secara bersamaan dengan StartAutoTuning.
 func (pm *PoolManager) StopAutoTuning() {
-	if pm.autoTuneTicker != nil {
-		// Kirim sinyal untuk menghentikan auto-tuning
-		select {
-		case pm.autoTuneStop <- struct{}{}:
-			// Channel belum tertutup, kirim sinyal
-		default:
-			// Channel sudah tertutup, abaikan
-		}
-
-		// Hentikan ticker dan pastikan `autoTuneTicker` benar-benar dihentikan
-		pm.autoTuneTicker.Stop()
-		pm.autoTuneTicker = nil
+	pm.autoTuneMu.Lock()
+	stopped := pm.autoTuneCancel != nil
+	if stopped {
+		pm.autoTuneCancel()
+		pm.autoTuneCancel = nil
+	}
+	pm.autoTuneMu.Unlock()
 
-		// Tutup channel autoTuneStop dengan aman
-		defer func() {
-			if r := recover(); r == nil {
-				close(pm.autoTuneStop)
-			}
-		}()
+	pm.poolStates.Range(func(key, value interface{}) bool {
+		if value.(*poolState).stopAutoTune() {
+			stopped = true
+		}
+		return true
+	})
 
-		// Inisialisasi kembali untuk penggunaan di masa mendatang
-		pm.autoTuneStop = make(chan struct{})
-		pm.logger.Println("Auto-tuning stopped")
+	if stopped {
+		pm.logf(InfoLevel, "Auto-tuning stopped")
 	} else {
-		pm.logger.Println("Auto-tuning is not running")
+		pm.logf(WarningLevel, "Auto-tuning is not running")
 	}
 }
 
-// getCurrentPoolSize menghitung ukuran pool saat ini berdasarkan poolName dan nilai pool.
-func (pm *PoolManager) getCurrentPoolSize(poolName string, value interface{}) int {
-	if shardedPools, isSharded := value.([]*sync.Pool); isSharded {
-		// Jika pool adalah array dari sync.Pool (sharded), hitung total ukuran dari semua shard
-		totalSize := 0
-		for shardIndex := range shardedPools {
-			totalSize += pm.getShardSize(poolName, shardIndex)
-		}
-		return totalSize
-	} else if _, isNonSharded := value.(*sync.Pool); isNonSharded {
-		// Jika pool adalah sync.Pool biasa (non-sharded), hitung ukuran pool
-		return pm.getNonShardedPoolSize(poolName)
+// StopPoolAutoTuning menghentikan auto-tuning untuk satu pool tanpa memengaruhi pool lain.
+func (pm *PoolManager) StopPoolAutoTuning(poolName string) {
+	if stateVal, ok := pm.poolStates.Load(poolName); ok && stateVal.(*poolState).stopAutoTune() {
+		pm.logPoolf(InfoLevel, poolName, "Auto-tuning stopped for pool: %s", poolName)
 	}
-	// Jika tipe tidak diketahui, gunakan metode default
-	return int(pm.getCurrentUsage(poolName))
 }
 
+// ResizePool menambah atau menghancurkan objek idle agar ukuran pool (objek idle ditambah objek yang
+// sedang dipinjam) mendekati newSize. Objek yang sedang dipinjam tidak disentuh, sehingga ukuran pool
+// tetap lebih besar dari newSize jika objek yang dipinjam sudah melebihinya. Pada pool sharding,
+// newSize dibagi rata ke setiap shard.
 func (pm *PoolManager) ResizePool(poolName string, newSize int) {
 	// Ambil konfigurasi pool saat ini
-	poolVal, ok := pm.pools.Load(poolName)
-	if !ok {
-		pm.logger.Printf("Pool %s does not exist, cannot resize", poolName)
-		return
-	}
-
-	configVal, _ := pm.poolConfig.Load(poolName)
-	conf, ok := configVal.(PoolConfiguration)
+	entry, ok := pm.entryFor(poolName)
 	if !ok {
-		pm.logger.Printf("Invalid pool configuration for %s", poolName)
+		pm.logPoolf(WarningLevel, poolName, "Pool %s does not exist, cannot resize", poolName)
 		return
 	}
+	poolVal, conf := entry.backend, entry.configuration()
 
 	// Cek apakah sharding diaktifkan
 	if conf.ShardingEnabled && conf.ShardCount > 1 {
 		// Mengubah ukuran sharded pool
 		shardedPools, ok := poolVal.([]*sync.Pool)
 		if !ok {
-			pm.logger.Printf("Invalid sharded pool type for %s", poolName)
+			pm.logPoolf(ErrorLevel, poolName, "Invalid sharded pool type for %s", poolName)
 			return
 		}
 
 		for i := 0; i < len(shardedPools); i++ {
-			currentSize := pm.getShardCurrentSize(poolName, i)
-			if currentSize < newSize {
+			idle, inUse := pm.shardSize(poolName, i)
+			targetIdle := idleTarget(shardShare(newSize, len(shardedPools), i), inUse)
+			if idle < targetIdle {
 				// Tambah objek ke shard untuk mencapai ukuran baru
-				for j := currentSize; j < newSize; j++ {
+				for j := idle; j < targetIdle; j++ {
 					instance := pm.createInstance(poolName)
 					shardedPools[i].Put(instance)
+					pm.adjustRetainedBytes(poolName, estimateSize(instance))
+					pm.adjustGauges(poolName, i, 1, 0)
 				}
-			} else if currentSize > newSize {
+			} else if idle > targetIdle {
 				// Kurangi objek dari shard untuk mencapai ukuran baru
-				for j := currentSize; j > newSize; j-- {
-					shardedPools[i].Get() // Ambil dan buang objek
+				for j := idle; j > targetIdle; j-- {
+					pm.discardFromPool(poolName, i, shardedPools[i].Get()) // Ambil dan buang objek
 				}
 			}
 		}
 	} else {
 		// Mengubah ukuran non-sharded pool
-		nonShardedPool, ok := poolVal.(*sync.Pool)
+		nonShardedPool, ok := poolVal.(idleStore)
 		if !ok {
-			pm.logger.Printf("Invalid non-sharded pool type for %s", poolName)
+			pm.logPoolf(ErrorLevel, poolName, "Invalid non-sharded pool type for %s", poolName)
 			return
 		}
 
-		currentSize := pm.getPoolCurrentSize(poolName)
-		if currentSize < newSize {
-			// Tambah objek ke pool untuk mencapai ukuran baru
-			for i := currentSize; i < newSize; i++ {
+		idle, inUse := pm.poolSize(poolName)
+		targetIdle := idleTarget(newSize, inUse)
+		if idle < targetIdle {
+			// Tambah objek ke pool untuk mencapai ukuran baru, tanpa melebihi batas pool berbatas
+			for i := idle; i < targetIdle && reserveLive(poolVal); i++ {
 				instance := pm.createInstance(poolName)
+				if instance == nil {
+					pm.releaseLive(poolName)
+					break
+				}
 				nonShardedPool.Put(instance)
+				pm.adjustRetainedBytes(poolName, estimateSize(instance))
+				pm.adjustGauges(poolName, -1, 1, 0)
 			}
-		} else if currentSize > newSize {
+		} else if idle > targetIdle {
 			// Kurangi objek dari pool untuk mencapai ukuran baru
-			for i := currentSize; i > newSize; i-- {
-				nonShardedPool.Get() // Ambil dan buang objek
+			for i := idle; i > targetIdle; i-- {
+				pm.discardFromPool(poolName, -1, nonShardedPool.Get()) // Ambil dan buang objek
 			}
 		}
 	}
 
-	pm.logger.Printf("Resizing pool %s to new size: %d", poolName, newSize)
+	pm.logPoolf(InfoLevel, poolName, "Resizing pool %s to new size: %d", poolName, newSize)
 }
 
-func (pm *PoolManager) createInstance(poolName string) PoolAble {
-	factoryVal, _ := pm.instanceFactories.Load(poolName)
-	factory, ok := factoryVal.(func() PoolAble)
-	if !ok {
-		pm.logger.Printf("Invalid factory for pool type %s", poolName)
-		return nil
+// idleTarget mengembalikan jumlah objek idle yang membuat ukuran pool mencapai size, dengan
+// inUse objek sedang dipinjam. Tidak pernah negatif.
+func idleTarget(size, inUse int) int {
+	if size <= inUse {
+		return 0
 	}
-	return factory()
+	return size - inUse
 }
 
-func (pm *PoolManager) getPoolCurrentSize(poolName string) int {
-	size := 0
-	// Hitung jumlah objek di pool
-	pm.cache.Range(func(key, value interface{}) bool {
-		if key.(string) == poolName {
-			size++
-		}
-		return true
-	})
-	return size
+// shardShare mengembalikan bagian shard ke-index dari size yang dibagi rata ke shards shard
+func shardShare(size, shards, index int) int {
+	share := size / shards
+	if index < size%shards {
+		share++
+	}
+	return share
 }
 
-func (pm *PoolManager) getShardCurrentSize(poolName string, shardIndex int) int {
-	// Ambil pool dan konfigurasinya
-	poolVal, ok := pm.pools.Load(poolName)
-	if !ok {
-		pm.logger.Printf("Pool %s does not exist", poolName)
-		return 0
+// discardFromPool menghancurkan objek yang diambil dari pool saat pool diperkecil
+// dan mengurangi perkiraan byte serta gauge idle pool.
+func (pm *PoolManager) discardFromPool(poolName string, shard int, value interface{}) {
+	if value == nil {
+		pm.resetIdleGauge(poolName, shard)
+		return
 	}
-
-	configVal, _ := pm.poolConfig.Load(poolName)
-	conf, ok := configVal.(PoolConfiguration)
-	if !ok || !conf.ShardingEnabled || conf.ShardCount <= shardIndex {
-		pm.logger.Printf("Invalid configuration for shard %d of pool %s", shardIndex, poolName)
-		return 0
+	if instance, ok := value.(PoolAble); ok {
+		pm.adjustRetainedBytes(poolName, -estimateSize(instance))
+		if conf, err := pm.getPoolConfiguration(poolName); err == nil {
+			if err := pm.destroyInstance(poolName, conf, instance); err != nil {
+				pm.handleError(poolName, err)
+			}
+		}
 	}
+	pm.adjustGauges(poolName, shard, -1, 0)
+}
 
-	// Ambil sharded pool
-	shardedPools, ok := poolVal.([]*sync.Pool)
-	if !ok || len(shardedPools) <= shardIndex {
-		pm.logger.Printf("Invalid sharded pool type for %s", poolName)
-		return 0
+func (pm *PoolManager) createInstance(poolName string) PoolAble {
+	entry, ok := pm.entryFor(poolName)
+	if !ok {
+		pm.logPoolf(ErrorLevel, poolName, "Invalid factory for pool type %s", poolName)
+		return nil
 	}
-
-	// Dapatkan ukuran cache yang sesuai dengan shardIndex
-	size := 0
-	pm.cache.Range(func(key, value interface{}) bool {
-		if keyStr, ok := key.(string); ok && keyStr == poolName {
-			if shardVal, ok := value.(int); ok && shardVal == shardIndex {
-				size++
-			}
-		}
-		return true
-	})
-	return size
+	return pm.construct(poolName, entry.configuration(), entry.factory)
 }
 
 // Reset mengatur ulang objek dalam pool
+//
+// Deprecated: gunakan ReinitializePool. Reset kini memanggil ReinitializePool sehingga pool
+// tetap dapat digunakan setelahnya.
 func (pm *PoolManager) Reset(poolName string) error {
-	if _, ok := pm.pools.Load(poolName); ok {
-		pm.pools.Delete(poolName)
-		return nil
+	return pm.ReinitializePool(poolName)
+}
+
+// ReinitializePool mengosongkan pool lalu mengisinya kembali hingga InitialSize
+// menggunakan factory dan konfigurasi yang tersimpan.
+// Objek idle dihancurkan melalui OnDestroy, dan metadata, cache, serta metrik pool dihapus.
+// Gauge objek yang sedang dipinjam dipertahankan agar pengembalian berikutnya tetap tercatat dengan benar.
+func (pm *PoolManager) ReinitializePool(poolName string) error {
+	entry, err := pm.lookupEntry(poolName, "reinitialize")
+	if err != nil {
+		return err
+	}
+	poolVal, conf := entry.backend, entry.configuration()
+
+	// Kosongkan objek idle terlebih dahulu, lalu bersihkan data turunan pool
+	drainErr := errors.Join(pm.drainPool(poolName, poolVal, conf), pm.purgeColdTier(poolName, conf), pm.purgeLoaded(poolName, conf))
+	pm.cache.Delete(poolName)
+	pm.deletePoolMetadata(poolName)
+	pm.operations.Delete(poolName)
+	entry.resetMetrics(conf.ShardCount)
+
+	if err := pm.seedPool(poolName, poolVal, conf, entry.factory); err != nil {
+		return errors.Join(drainErr, err)
 	}
-	return errors.New("pool does not exist: " + poolName)
+
+	pm.logPoolf(InfoLevel, poolName, "Reinitialized pool: %s, InitialSize: %d", poolName, conf.InitialSize)
+	return drainErr
 }
 
-// Clear membersihkan semua pool
-func (pm *PoolManager) Clear() {
-	pm.pools.Range(func(key, value interface{}) bool {
-		pm.pools.Delete(key)
-		return true
-	})
+// Clear membersihkan semua pool beserta tugas latar belakangnya
+// Setiap pool dihapus dengan RemovePool. Error dari setiap pool digabungkan dengan errors.Join
+// sehingga pembersihan sumber daya dapat diverifikasi oleh pemanggil.
+func (pm *PoolManager) Clear() error {
+	var errs []error
+	for _, poolName := range pm.ListPools() {
+		if err := pm.RemovePool(poolName); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // AddShard menambahkan shard baru ke PoolManager
 func (pm *PoolManager) AddShard() {
 	atomic.AddInt64(&pm.shardCounter, 1)
-	pm.logMessage(InfoLevel, "Shard added. Total shards: "+fmt.Sprint(pm.shardCounter))
+	pm.logf(InfoLevel, "Shard added. Total shards: %d", atomic.LoadInt64(&pm.shardCounter))
 }
 
 // RemoveShard menghapus shard jika jumlah shard lebih dari 0
 func (pm *PoolManager) RemoveShard() error {
 	if pm.shardCounter > 0 {
 		atomic.AddInt64(&pm.shardCounter, -1)
-		pm.logMessage(InfoLevel, "Shard removed. Total shards: "+fmt.Sprint(pm.shardCounter))
+		pm.logf(InfoLevel, "Shard removed. Total shards: %d", atomic.LoadInt64(&pm.shardCounter))
 		return nil
 	}
 	return errors.New("no shard available to remove")
@@ -659,64 +1202,88 @@ func (pm *PoolManager) RemoveShard() error {
 
 // HandleError mengatur bagaimana error diproses
 func (pm *PoolManager) HandleError(err error) {
-	if pm.logger != nil {
-		pm.logger.Println("Error:", err)
-	} else {
-		log.Println("Error:", err)
-	}
+	pm.logf(ErrorLevel, "Error: %v", err)
 }
 
-// autoTune menyesuaikan ukuran pool secara otomatis berdasarkan konfigurasi.
-func (pm *PoolManager) autoTune(poolName string, config PoolConfiguration) {
+// autoTune menyesuaikan ukuran pool secara otomatis berdasarkan konfigurasi
+// hingga ctx dibatalkan oleh StopAutoTuning atau penghapusan pool.
+func (pm *PoolManager) autoTune(ctx context.Context, poolName string, config PoolConfiguration) {
+	ticker := time.NewTicker(config.AutoTuneInterval)
+	defer ticker.Stop()
+
 	for {
 		select {
-		case <-pm.autoTuneTicker.C:
-			currentSize := pm.GetPoolSize(poolName)
-			if currentSize == 0 {
-				pm.logger.Println("Auto-tuning skipped, pool is empty:", poolName)
+		case <-ticker.C:
+			if !pm.maintenanceAllowed(poolName, maintenanceAutoTune) {
 				continue
 			}
-
-			newSize := int(float64(currentSize) * config.AutoTuneFactor)
-			if newSize > config.MaxSize {
-				newSize = config.MaxSize
-			} else if newSize < config.MinSize {
-				newSize = config.MinSize
+			if entry, ok := pm.entryFor(poolName); ok {
+				pm.tunePool(entry)
 			}
-
-			// Hanya ubah ukuran pool jika ada perubahan
-			if newSize != currentSize {
-				pm.ResizePool(poolName, newSize)
-				if config.OnAutoTune != nil {
-					config.OnAutoTune(poolName, newSize)
-				}
-				pm.logger.Printf("Auto-tuned pool %s to new size: %d", poolName, newSize)
-			}
-		case <-pm.autoTuneStop:
+		case <-ctx.Done():
 			return
 		}
 	}
 }
 
-// runEviction menjalankan kebijakan eviksi pada interval tertentu.
-func (pm *PoolManager) runEviction(poolName string, interval time.Duration) {
+// evictionPolicyFor mengembalikan kebijakan eviksi dari konfigurasi pool,
+// atau kebijakan default PoolManager jika pool tidak menentukannya.
+func (pm *PoolManager) evictionPolicyFor(poolName string) EvictionPolicy {
+	if conf, err := pm.getPoolConfiguration(poolName); err == nil && conf.Eviction != nil {
+		return conf.Eviction
+	}
+	if policy := pm.evictionPolicy.Load(); policy != nil {
+		return *policy
+	}
+	return nil
+}
+
+// runEviction menjalankan kebijakan eviksi pada interval tertentu hingga ctx dibatalkan.
+func (pm *PoolManager) runEviction(ctx context.Context, poolName string, interval time.Duration) {
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
-			// Jalankan kebijakan eviksi
-			if pm.evictionPolicy != nil {
-				pm.evictionPolicy.Evict(poolName, pm)
+			if !pm.maintenanceAllowed(poolName, maintenanceEviction) {
+				continue
 			}
-		case <-pm.autoTuneStop:
-			// Hentikan eviksi jika auto-tuning dihentikan
+			pm.evictionSweep(ctx, poolName)
+		case <-ctx.Done():
+			// Hentikan eviksi jika pool dihapus atau eviksi dijalankan ulang
 			return
 		}
 	}
 }
 
+// evictionSweep menjalankan satu putaran eviksi sebagai task runtime/trace: kebijakan eviksi, lalu
+// penghancuran objek idle yang melebihi MaxIdleTime pool, lalu pemadatan metadata
+func (pm *PoolManager) evictionSweep(ctx context.Context, poolName string) {
+	ctx, endTask := startTraceTask(ctx, poolName, TraceTaskEviction)
+	defer endTask()
+
+	evicted := false
+	if policy := pm.evictionPolicyFor(poolName); policy != nil {
+		endRegion := startTraceRegion(ctx, poolName, TraceRegionEvictPolicy)
+		policy.Evict(poolName, pm)
+		endRegion()
+		evicted = true
+	}
+	if conf, err := pm.getPoolConfiguration(poolName); err == nil && conf.MaxIdleTime > 0 {
+		endRegion := startTraceRegion(ctx, poolName, TraceRegionEvictIdle)
+		pm.evictIdle(poolName, conf.MaxIdleTime)
+		endRegion()
+		evicted = true
+	}
+	if evicted {
+		pm.markEvicted(poolName)
+	}
+	endRegion := startTraceRegion(ctx, poolName, TraceRegionCompact)
+	pm.CompactMetadata(poolName)
+	endRegion()
+}
+
 // evictOldestCacheItem menghapus item cache tertua atau yang paling jarang digunakan
 // poolName: tipe pool dari mana item akan dihapus
 // Fungsi ini mencari item dengan waktu terakhir digunakan paling lama dan menghapusnya dari cache dan metadata.
@@ -746,24 +1313,65 @@ func (pm *PoolManager) evictOldestCacheItem(poolName string) {
 	}
 }
 
-// SetEvictionPolicy mengganti kebijakan eviksi yang digunakan oleh PoolManager
+// SetEvictionPolicy mengganti kebijakan eviksi default PoolManager. Kebijakan ini hanya dipakai oleh
+// pool yang tidak menentukan kebijakan sendiri; gunakan SetPoolEvictionPolicy untuk satu pool.
 func (pm *PoolManager) SetEvictionPolicy(policy EvictionPolicy) {
-	pm.evictionPolicy = policy
+	if policy == nil {
+		pm.evictionPolicy.Store(nil)
+		return
+	}
+	pm.evictionPolicy.Store(&policy)
+}
+
+// SetPoolEvictionPolicy mengganti kebijakan eviksi satu pool tanpa memengaruhi pool lain. Kebijakan
+// nil mengembalikan pool ke kebijakan default PoolManager. Eviksi dijalankan setiap EvictionInterval
+// pool, sehingga kebijakan baru berlaku pada putaran berikutnya.
+func (pm *PoolManager) SetPoolEvictionPolicy(poolName string, policy EvictionPolicy) error {
+	entry, err := pm.lookupEntry(poolName, "update")
+	if err != nil {
+		return err
+	}
+	entry.updateMu.Lock()
+	defer entry.updateMu.Unlock()
+
+	config := entry.configuration()
+	config.Eviction = policy
+	entry.setConfiguration(config)
+	if config.evictionEnabled() {
+		pm.startEviction(poolName, config.EvictionInterval)
+	} else {
+		entry.state.stopEviction()
+	}
+	pm.logPoolf(InfoLevel, poolName, "Updated eviction policy for pool: %s, Policy: %s", poolName, typeName(policy))
+	return nil
 }
 
 // ForceEvict secara paksa menghapus objek dari pool berdasarkan kunci
+// Objek idle dikeluarkan dari pool dan dihancurkan; objek yang sedang dipinjam hanya kehilangan metadatanya.
 func (pm *PoolManager) ForceEvict(poolName, key string) error {
 	// Cek apakah metadata untuk item tersebut ada
 	if metadataVal, ok := pm.itemMetadata.Load(key); ok {
 		// Pastikan metadata tersebut terkait dengan poolName yang diberikan
 		if metadata, ok := metadataVal.(*PoolItemMetadata); ok && metadata.PoolName == poolName {
-			// Hapus item dari metadata
+			// Objek idle dikeluarkan dari pool dan dihancurkan melalui OnDestroy dan OnEvictInstance
+			evicted := pm.evictIdleWhere(poolName, "forced", func(itemKey string, _ *PoolItemMetadata) bool {
+				return itemKey == key
+			})
+			if evicted > 0 {
+				return nil
+			}
+
+			// Objek tidak ditemukan di pool (misalnya sedang dipinjam), hapus metadatanya saja
 			pm.itemMetadata.Delete(key)
 			// Hapus item dari cache juga
 			pm.cache.Delete(key)
 
 			// Tambahkan log untuk melacak eviksi
-			pm.logger.Printf("Force evicted item from pool: %s, Key: %s", poolName, key)
+			pm.logPoolf(InfoLevel, poolName, "Force evicted item from pool: %s, Key: %s", poolName, key)
+			pm.tracef("evict pool=%s key=%s lease=%s reason=forced", poolName, key, metadata.LeaseID)
+			if conf, err := pm.getPoolConfiguration(poolName); err == nil {
+				pm.triggerCallback(conf.OnEvict, poolName)
+			}
 			return nil
 		}
 	}
@@ -771,11 +1379,17 @@ func (pm *PoolManager) ForceEvict(poolName, key string) error {
 	return errors.New("item does not exist in metadata for pool: " + poolName + ", key: " + key)
 }
 
-// SetShardingStrategy menetapkan strategi sharding yang akan digunakan oleh PoolManager.
+// SetShardingStrategy menetapkan strategi sharding yang akan digunakan oleh PoolManager untuk pool
+// tanpa ShardStrategy sendiri. Strategi dipublikasikan secara atomik sehingga aman diganti saat
+// acquire dan release sedang berjalan. nil mengembalikan pemilihan shard bawaan.
 // strategy: strategi sharding yang diimplementasikan oleh pengguna.
 func (pm *PoolManager) SetShardingStrategy(strategy ShardingStrategy) {
-	pm.shardingStrategy = strategy
-	pm.logMessage(InfoLevel, "Sharding strategy set.")
+	if strategy == nil {
+		pm.shardingStrategy.Store(nil)
+		return
+	}
+	pm.shardingStrategy.Store(&strategy)
+	pm.logf(InfoLevel, "Sharding strategy set.")
 }
 
 // addToCache menambahkan instance ke dalam cache pool
@@ -784,17 +1398,9 @@ func (pm *PoolManager) SetShardingStrategy(strategy ShardingStrategy) {
 // Fungsi ini akan memeriksa konfigurasi pool untuk melihat apakah caching diaktifkan. Jika ukuran cache
 // melebihi batas yang ditetapkan, fungsi ini akan menghapus item cache yang paling lama atau jarang digunakan.
 func (pm *PoolManager) addToCache(poolName string, instance PoolAble) {
-	// Load the pool configuration for the given pool type
-	configVal, ok := pm.poolConfig.Load(poolName)
-	if !ok {
-		// Jika konfigurasi tidak ada, keluar dari fungsi
-		return
-	}
-
-	// Melakukan type assertion untuk mendapatkan konfigurasi PoolConfiguration
-	conf, ok := configVal.(PoolConfiguration)
-	if !ok {
-		// Jika type assertion gagal, keluar dari fungsi
+	// Ambil konfigurasi pool, keluar jika pool tidak terdaftar
+	conf, err := pm.getPoolConfiguration(poolName)
+	if err != nil {
 		return
 	}
 
@@ -834,17 +1440,47 @@ func (pm *PoolManager) getCacheSize(poolName string) int {
 // Jika konfigurasi pool memiliki callback OnError, fungsi ini akan memanggil callback tersebut
 // dengan parameter poolName dan error yang terjadi.
 func (pm *PoolManager) handleError(poolName string, err error) {
-	config, _ := pm.poolConfig.Load(poolName)
-	if conf, ok := config.(PoolConfiguration); ok && conf.OnError != nil {
+	if conf, err := pm.getPoolConfiguration(poolName); err == nil && conf.OnError != nil {
 		conf.OnError(poolName, err)
 	}
 }
 
 // logMessage mencatat pesan dengan level log yang ditentukan
-func (pm *PoolManager) logMessage(level LogLevel, message string) {
-	if level >= pm.monitoringConfig.LogLevel {
-		pm.logger.Println(message)
+// Pesan dibuang jika EnableLogging dinonaktifkan, dan diteruskan ke tujuan log yang dipilih loggerFor.
+func (pm *PoolManager) logMessage(level LogLevel, message string, fields ...LogField) {
+	pm.writeLog(pm.monitoring(), "", level, message, fields...)
+}
+
+// writeLog mencatat pesan pool poolName (kosong untuk pesan PoolManager) menurut konfigurasi
+// monitoring yang sudah dimuat pemanggil
+func (pm *PoolManager) writeLog(monitoring *MonitoringConfig, poolName string, level LogLevel, message string, fields ...LogField) {
+	if !monitoring.logs(level) {
+		return
 	}
+	logAt(pm.loggerFor(monitoring, poolName), level, message, fields...)
+}
+
+// logf memformat pesan lalu mencatatnya melalui logMessage sehingga LogLevel selalu dihormati.
+// Pemformatan dilewati jika level pesan berada di bawah LogLevel yang dikonfigurasi.
+func (pm *PoolManager) logf(level LogLevel, format string, args ...interface{}) {
+	monitoring := pm.monitoring()
+	if !monitoring.logs(level) {
+		return
+	}
+	pm.writeLog(monitoring, "", level, fmt.Sprintf(format, args...))
+}
+
+// tracef mencatat keputusan acquire/release/evict pada DebugLevel ketika TraceOperations diaktifkan.
+func (pm *PoolManager) tracef(format string, args ...interface{}) {
+	pm.traceTo(pm.monitoring(), format, args...)
+}
+
+// traceTo mencatat trace menurut konfigurasi monitoring yang sudah dimuat pemanggil
+func (pm *PoolManager) traceTo(monitoring *MonitoringConfig, format string, args ...interface{}) {
+	if !monitoring.TraceOperations || !monitoring.logs(DebugLevel) {
+		return
+	}
+	pm.writeLog(monitoring, "", DebugLevel, fmt.Sprintf("trace: "+format, args...))
 }
 
 func (pm *PoolManager) AddItemMetadata(poolName, key string) {
@@ -904,6 +1540,8 @@ func (pm *PoolManager) ResetItemMetadata(key string) {
 // GetItemMetadata mengambil metadata item jika tersedia
 // key: kunci unik yang mengidentifikasi item dalam metadata map
 // Mengembalikan metadata item dan boolean yang menunjukkan apakah metadata ditemukan.
+// Metadata yang dikembalikan hanya boleh dibaca; gunakan GetItemMetadataSnapshot jika salinan
+// perlu diubah.
 func (pm *PoolManager) GetItemMetadata(key string) (*PoolItemMetadata, bool) {
 	metadata, ok := pm.itemMetadata.Load(key)
 	if !ok {
@@ -923,22 +1561,29 @@ func (pm *PoolManager) UpdateIdleDuration(key string) {
 // safelyUpdateMetadata memperbarui metadata item secara aman menggunakan fungsi pembaruan yang diberikan
 // key: kunci unik yang mengidentifikasi item dalam metadata map
 // updateFunc: fungsi yang mendefinisikan bagaimana metadata harus diperbarui
-// Fungsi ini memastikan bahwa metadata selalu diperbarui dengan cara yang aman
-// menggunakan fungsi yang diberikan untuk memodifikasi metadata.
+// Metadata yang tersimpan tidak pernah diubah di tempat: updateFunc menerima salinan yang lalu
+// dipublikasikan dengan CompareAndSwap, sehingga pembaca yang memegang pointer lama (eviksi,
+// CompactMetadata, snapshot) tidak berlomba dengan penulis. updateFunc dapat dipanggil ulang jika
+// metadata diubah bersamaan, dan map Tag harus diganti, bukan diubah di tempat.
 func (pm *PoolManager) safelyUpdateMetadata(key string, updateFunc func(*PoolItemMetadata)) {
-	metadataVal, _ := pm.itemMetadata.LoadOrStore(key, &PoolItemMetadata{
-		CreationTime: time.Now(),
-		LastUsed:     time.Now(),
-		Status:       "Active",
-	})
-
-	metadata := metadataVal.(*PoolItemMetadata)
-
-	// Update metadata menggunakan fungsi yang diberikan
-	updateFunc(metadata)
+	for {
+		metadataVal, ok := pm.itemMetadata.Load(key)
+		if !ok {
+			now := time.Now()
+			metadataVal, _ = pm.itemMetadata.LoadOrStore(key, &PoolItemMetadata{
+				CreationTime: now,
+				LastUsed:     now,
+				Status:       "Active",
+			})
+		}
 
-	// Simpan kembali hasil perubahan metadata ke dalam map
-	pm.itemMetadata.Store(key, metadata)
+		current := metadataVal.(*PoolItemMetadata)
+		updated := *current
+		updateFunc(&updated)
+		if pm.itemMetadata.CompareAndSwap(key, current, &updated) {
+			return
+		}
+	}
 }
 
 func (pm *PoolManager) evictBatch(poolName string, batchSize int) {
@@ -966,13 +1611,13 @@ func (pm *PoolManager) processEvictionBatch(poolName string, batch []string) {
 		pm.cache.Delete(key)
 		pm.itemMetadata.Delete(key)
 	}
-	pm.logger.Printf("Evicted batch of items from pool: %s", poolName)
+	pm.logPoolf(DebugLevel, poolName, "Evicted batch of items from pool: %s", poolName)
 }
 
 func (pm *PoolManager) removeItem(poolName, key string) {
 	pm.cache.Delete(key)
 	pm.itemMetadata.Delete(key)
-	pm.logger.Printf("Removed item from pool: %s, Key: %s", poolName, key)
+	pm.logPoolf(DebugLevel, poolName, "Removed item from pool: %s, Key: %s", poolName, key)
 }
 
 func (pm *PoolManager) safelyHandleInstance(poolName string, conf PoolConfiguration, instance PoolAble, action string) error {
@@ -982,33 +1627,67 @@ func (pm *PoolManager) safelyHandleInstance(poolName string, conf PoolConfigurat
 	} else if action == "put" {
 		pm.addToCache(poolName, instance)
 		pm.triggerCallback(conf.OnPut, poolName)
+		pm.triggerInstanceCallback(conf.OnPutInstance, poolName, instance)
 	}
 	return nil
 }
 
 func (pm *PoolManager) getPoolConfiguration(poolName string) (PoolConfiguration, error) {
-	configVal, _ := pm.poolConfig.Load(poolName)
-	conf, ok := configVal.(PoolConfiguration)
+	entry, ok := pm.entryFor(poolName)
 	if !ok {
 		return PoolConfiguration{}, NewPoolError(poolName, "config", errors.New(ErrInvalidPoolConfigType))
 	}
-	return conf, nil
+	return entry.configuration(), nil
 }
 
-func (pm *PoolManager) updateMetadata(poolName, status string) {
-	pm.safelyUpdateMetadata(poolName, func(metadata *PoolItemMetadata) {
+// updateMetadata memperbarui metadata milik instance tertentu saat dipinjam atau dikembalikan
+// poolName: nama pool asal instance
+// instance: instance yang metadatanya diperbarui
+// status: status baru instance ("Active" atau "Idle")
+// o: opsi acquire yang memuat pemilik dan tag instance (kosong saat dikembalikan)
+func (pm *PoolManager) updateMetadata(poolName string, instance PoolAble, status string, o acquireOptions) {
+	pm.safelyUpdateMetadata(metadataKey(poolName, instance), func(metadata *PoolItemMetadata) {
+		metadata.PoolName = poolName
 		metadata.LastUsed = time.Now()
 		metadata.Status = status
-		metadata.Frequency++
+		metadata.IsPooled = status == "Idle"
+		metadata.OwnerID = o.owner
+		metadata.Degraded = o.degraded
+		if o.leaseID != "" {
+			metadata.LeaseID = o.leaseID
+		}
+		if status == "Active" {
+			metadata.Frequency++
+			metadata.AccessCount++
+		}
+		if len(o.tags) > 0 {
+			metadata.Tag = mergeTags(metadata.Tag, o.tags)
+		}
 	})
 }
 
+// metadataKey mengembalikan kunci metadata untuk sebuah instance.
+// Instance yang tidak dapat dilacak per objek berbagi metadata dengan nama pool-nya.
+func metadataKey(poolName string, instance PoolAble) string {
+	if key := instanceKey(poolName, instance); key != "" {
+		return key
+	}
+	return poolName
+}
+
 func (pm *PoolManager) triggerCallbackWithInstance(callback func(string, PoolAble), poolName string, instance PoolAble) {
 	if callback != nil {
 		callback(poolName, instance)
 	}
 }
 
+// triggerInstanceCallback memanggil callback yang menerima instance beserta kunci metadata-nya
+func (pm *PoolManager) triggerInstanceCallback(callback func(string, string, PoolAble), poolName string, instance PoolAble) {
+	if callback != nil {
+		callback(poolName, metadataKey(poolName, instance), instance)
+	}
+}
+
 func (pm *PoolManager) triggerCallback(callback func(string), poolName string) {
 	if callback != nil {
 		callback(poolName)
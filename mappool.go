@@ -0,0 +1,116 @@
+package poolmanager
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// MapPoolConfig mengatur ukuran map di dalam MapPool
+type MapPoolConfig struct {
+	InitialCap int // Kapasitas awal map baru (0 = default runtime)
+	MaxEntries int // Map yang pernah menampung lebih dari batas ini dibuang saat Put, bukan dipakai ulang (0 = tanpa batas)
+}
+
+// MapBuffer adalah map yang dipinjam dari MapPool. Reset mengosongkan map tanpa mengalokasikan
+// ulang, sehingga bucket yang sudah tumbuh tetap dipakai ulang.
+type MapBuffer[K comparable, V any] struct {
+	M    map[K]V
+	peak int // Jumlah entri terbanyak yang pernah ditampung; map Go tidak pernah menyusut
+}
+
+// Reset mencatat ukuran puncak map lalu menghapus seluruh entrinya
+func (b *MapBuffer[K, V]) Reset() {
+	b.observe()
+	clear(b.M)
+}
+
+// observe memperbarui ukuran puncak dari jumlah entri saat ini
+func (b *MapBuffer[K, V]) observe() {
+	if n := len(b.M); n > b.peak {
+		b.peak = n
+	}
+}
+
+// SizeBytes memperkirakan memori yang ditahan map dari ukuran puncaknya, karena bucket yang
+// sudah dialokasikan tidak dilepas saat map dikosongkan
+func (b *MapBuffer[K, V]) SizeBytes() int64 {
+	entry := reflect.TypeOf((*K)(nil)).Elem().Size() + reflect.TypeOf((*V)(nil)).Elem().Size()
+	return int64(b.peak) * int64(entry)
+}
+
+// MapPool adalah pool map generik yang terdaftar sebagai pool biasa pada PoolManager, sehingga
+// metrik, eviksi, dan perhitungan RetainedBytes berlaku. Map dikosongkan saat dikembalikan; map
+// yang pernah tumbuh melewati MaxEntries dibuang karena map Go tidak pernah menyusut.
+type MapPool[K comparable, V any] struct {
+	ref        *PoolRef
+	maxEntries int
+}
+
+// NewMapPool mendaftarkan MapPool pada pm dengan konfigurasi pool config
+func NewMapPool[K comparable, V any](pm *PoolManager, poolName string, maps MapPoolConfig, config PoolConfiguration) (*MapPool[K, V], error) {
+	if maps.InitialCap < 0 || maps.MaxEntries < 0 {
+		return nil, NewPoolError(poolName, "add", errors.New("map InitialCap and MaxEntries must be non-negative"))
+	}
+	initialCap := maps.InitialCap
+	ref, err := pm.AddPool(poolName, func() PoolAble {
+		return &MapBuffer[K, V]{M: make(map[K]V, initialCap)}
+	}, config)
+	if err != nil {
+		return nil, err
+	}
+	return &MapPool[K, V]{ref: ref, maxEntries: maps.MaxEntries}, nil
+}
+
+// Get meminjam map kosong dari pool
+func (p *MapPool[K, V]) Get(opts ...AcquireOption) (*MapBuffer[K, V], error) {
+	instance, err := p.ref.Acquire(opts...)
+	if err != nil {
+		return nil, err
+	}
+	buf, ok := instance.(*MapBuffer[K, V])
+	if !ok {
+		return nil, NewPoolError(p.ref.Name(), "get", &TypeMismatchError{
+			Expected: typeString[*MapBuffer[K, V]](),
+			Actual:   fmt.Sprintf("%T", instance),
+		})
+	}
+	return buf, nil
+}
+
+// Put mengembalikan map ke pool. Map yang pernah menampung lebih dari MaxEntries entri dibuang
+// dan dicatat sebagai discard pada metrik pool.
+func (p *MapPool[K, V]) Put(buf *MapBuffer[K, V]) error {
+	if buf == nil {
+		return NewPoolError(p.ref.Name(), "put", errors.New("cannot put nil map buffer into pool"))
+	}
+	buf.observe()
+	if p.maxEntries > 0 && buf.peak > p.maxEntries {
+		pm, poolName := p.ref.Manager(), p.ref.Name()
+		conf, err := pm.getPoolConfiguration(poolName)
+		if err != nil {
+			pm.handleError(poolName, err)
+			return err
+		}
+		// Map yang sudah dikembalikan atau bukan milik pool ditolak seperti pada ReleaseInstance
+		instance := pm.unwrapInstance(poolName, buf)
+		if err := pm.settleRelease(poolName, conf, instance); err != nil {
+			if errors.Is(err, errReclaimedRelease) {
+				return nil
+			}
+			err = NewPoolError(poolName, "put", err)
+			pm.handleError(poolName, err)
+			return err
+		}
+		pm.logPoolf(DebugLevel, poolName, "Discarding over-capacity map from pool: %s, Peak entries: %d, Limit: %d",
+			poolName, buf.peak, p.maxEntries)
+		return pm.discardInstance(poolName, conf, instance,
+			fmt.Sprintf("map peaked at %d entries, limit %d", buf.peak, p.maxEntries))
+	}
+	return p.ref.Release(buf)
+}
+
+// Ref mengembalikan handle ke pool yang mendasari MapPool
+func (p *MapPool[K, V]) Ref() *PoolRef {
+	return p.ref
+}
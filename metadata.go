@@ -1,6 +1,10 @@
 package poolmanager
 
-import "time"
+import (
+	"errors"
+	"sort"
+	"time"
+)
 
 const (
 	NoEvictionPolicy      = "no_eviction"
@@ -25,4 +29,267 @@ type PoolItemMetadata struct {
 	IsPooled         bool              // Apakah item sedang berada di pool atau sedang digunakan
 	Tag              map[string]string // Tag untuk penyimpanan informasi tambahan
 	LastResetTime    time.Time         // Waktu terakhir item di-reset
+	CreationCost     time.Duration     // Durasi pemanggilan factory saat item dibuat
+	Degraded         bool              // Item kedaluwarsa yang sedang dipinjam karena pembuatan pengganti gagal (lihat ServeStale)
+	LeaseID          string            // ID korelasi peminjaman saat ini atau terakhir (lihat LeaseID)
+	HealthPenalty    float64           // Akumulasi penalti kesehatan antara 0 dan 1 (lihat HealthScoringConfig)
+}
+
+// snapshot mengembalikan salinan metadata yang tidak berbagi map maupun pointer dengan aslinya.
+// Metadata yang tersimpan pada itemMetadata tidak pernah diubah di tempat (lihat
+// safelyUpdateMetadata), sehingga penyalinan ini tidak berlomba dengan pembaruan yang bersamaan.
+func (m *PoolItemMetadata) snapshot() PoolItemMetadata {
+	snap := *m
+	if m.ExpirationTime != nil {
+		expiration := *m.ExpirationTime
+		snap.ExpirationTime = &expiration
+	}
+	if m.Tag != nil {
+		snap.Tag = make(map[string]string, len(m.Tag))
+		for key, value := range m.Tag {
+			snap.Tag[key] = value
+		}
+	}
+	return snap
+}
+
+// Age mengembalikan umur item sejak dibuat
+func (m PoolItemMetadata) Age() time.Duration {
+	return time.Since(m.CreationTime)
+}
+
+// ItemLifecycleEvent diteruskan ke OnItemLifecycle dengan salinan metadata item yang tidak
+// dapat mengubah maupun berlomba dengan metadata internal PoolManager.
+type ItemLifecycleEvent struct {
+	PoolName string           // Nama pool pemilik item
+	Action   string           // "create", "get", "put", atau "destroy"
+	Key      string           // Kunci metadata item
+	Instance PoolAble         // Instance yang terlibat
+	Metadata PoolItemMetadata // Salinan metadata item saat event terjadi
+}
+
+// GetItemMetadataSnapshot mengembalikan salinan metadata item yang aman dibaca di luar PoolManager.
+// Pembaruan setelah pemanggilan ini tidak terlihat pada salinan.
+func (pm *PoolManager) GetItemMetadataSnapshot(key string) (PoolItemMetadata, bool) {
+	metadataVal, ok := pm.itemMetadata.Load(key)
+	if !ok {
+		return PoolItemMetadata{}, false
+	}
+	return metadataVal.(*PoolItemMetadata).snapshot(), true
+}
+
+// triggerLifecycle memanggil OnItemLifecycle dengan salinan metadata item jika callback diatur
+func (pm *PoolManager) triggerLifecycle(conf PoolConfiguration, action, poolName string, instance PoolAble) {
+	if conf.OnItemLifecycle == nil {
+		return
+	}
+	key := metadataKey(poolName, instance)
+	metadata, _ := pm.GetItemMetadataSnapshot(key)
+	conf.OnItemLifecycle(ItemLifecycleEvent{
+		PoolName: poolName,
+		Action:   action,
+		Key:      key,
+		Instance: instance,
+		Metadata: metadata,
+	})
+}
+
+// RangeItems memanggil visit dengan salinan metadata setiap item pool hingga visit mengembalikan
+// false. Urutan item tidak ditentukan; gunakan ItemsPage untuk iterasi berhalaman yang stabil.
+func (pm *PoolManager) RangeItems(poolName string, visit func(key string, metadata PoolItemMetadata) bool) error {
+	if _, err := pm.lookupEntry(poolName, "range_items"); err != nil {
+		return err
+	}
+	pm.itemMetadata.Range(func(key, value interface{}) bool {
+		metadata, ok := value.(*PoolItemMetadata)
+		if !ok || metadata.PoolName != poolName || key.(string) == poolName {
+			return true
+		}
+		return visit(key.(string), metadata.snapshot())
+	})
+	return nil
+}
+
+// PoolItem adalah satu item pada ItemsPage
+type PoolItem struct {
+	Key      string           `json:"key"`
+	Metadata PoolItemMetadata `json:"metadata"`
+}
+
+// ItemPage adalah satu halaman item pool yang diurutkan berdasarkan kunci
+type ItemPage struct {
+	Items      []PoolItem `json:"items"`
+	NextCursor string     `json:"next_cursor,omitempty"` // Cursor halaman berikutnya (kosong jika halaman terakhir)
+}
+
+// ItemsPage mengembalikan paling banyak limit item pool yang kuncinya setelah cursor, diurutkan
+// berdasarkan kunci, misalnya untuk endpoint admin. cursor kosong memulai dari halaman pertama dan
+// NextCursor hasil sebelumnya melanjutkan ke halaman berikutnya. Item yang ditambahkan atau dihapus
+// di antara pemanggilan dapat ikut atau terlewat tanpa mengacaukan urutan halaman.
+func (pm *PoolManager) ItemsPage(poolName, cursor string, limit int) (ItemPage, error) {
+	if limit <= 0 {
+		return ItemPage{}, NewPoolError(poolName, "range_items", errors.New("page limit must be positive"))
+	}
+	var items []PoolItem
+	err := pm.RangeItems(poolName, func(key string, metadata PoolItemMetadata) bool {
+		if key > cursor {
+			items = append(items, PoolItem{Key: key, Metadata: metadata})
+		}
+		return true
+	})
+	if err != nil {
+		return ItemPage{}, err
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+	page := ItemPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = page.Items[limit-1].Key
+	}
+	return page, nil
+}
+
+// EvictByTag menghancurkan objek idle di dalam pool yang memiliki tag dengan nilai tertentu,
+// misalnya seluruh objek milik tenant, versi, atau host backend yang sudah tidak ada.
+// Tag dilampirkan saat acquire dengan WithTags. Mengembalikan jumlah objek yang dieviksi.
+func (pm *PoolManager) EvictByTag(poolName, tagKey, tagValue string) int {
+	return pm.EvictWhere(poolName, func(key string, metadata *PoolItemMetadata) bool {
+		value, found := metadata.Tag[tagKey]
+		return found && value == tagValue
+	})
+}
+
+// metadataGracePeriod adalah waktu minimum sejak item terakhir digunakan sebelum CompactMetadata
+// boleh menghapus metadatanya. Item yang baru dikembalikan bisa berada di slot sync.Pool yang tidak
+// terjangkau oleh pemindaian, sehingga metadatanya tidak langsung dianggap yatim.
+const metadataGracePeriod = time.Minute
+
+// CompactMetadata menghapus metadata item pool yang objeknya sudah tidak ada, misalnya objek yang
+// dihancurkan, dibuang oleh GC dari sync.Pool, atau dipindahkan ke cold tier. Metadata dipertahankan
+// untuk objek yang masih idle di pool, sedang dipinjam, atau baru digunakan dalam metadataGracePeriod.
+// Jika pool sudah dihapus, seluruh metadatanya dihapus. Dijalankan otomatis pada setiap putaran
+// eviksi. Mengembalikan jumlah entri yang dihapus.
+func (pm *PoolManager) CompactMetadata(poolName string) int {
+	live := make(map[string]bool)
+	poolExists := pm.forEachIdle(poolName, func(instance PoolAble, shard int) bool {
+		live[metadataKey(poolName, instance)] = true
+		return true
+	})
+
+	removed := 0
+	now := time.Now()
+	pm.itemMetadata.Range(func(key, value interface{}) bool {
+		metadata, ok := value.(*PoolItemMetadata)
+		if !ok || metadata.PoolName != poolName {
+			return true
+		}
+		itemKey := key.(string)
+		if poolExists {
+			if itemKey == poolName || live[itemKey] || now.Sub(metadata.LastUsed) < metadataGracePeriod {
+				return true
+			}
+			if _, checkedOut := pm.checkouts.Load(itemKey); checkedOut {
+				return true
+			}
+		}
+		pm.itemMetadata.Delete(itemKey)
+		removed++
+		return true
+	})
+
+	if removed > 0 {
+		pm.logPoolf(DebugLevel, poolName, "Compacted %d orphaned metadata entries from pool: %s", removed, poolName)
+	}
+	return removed
+}
+
+// ExportMetadata mengembalikan salinan metadata seluruh item pool, diurutkan dari item yang
+// paling baru digunakan. Hasilnya dapat disimpan (misalnya sebagai JSON) lalu dipulihkan dengan
+// ImportMetadata setelah restart agar kebijakan LFU/LRU langsung efektif.
+func (pm *PoolManager) ExportMetadata(poolName string) []PoolItemMetadata {
+	var items []PoolItemMetadata
+	pm.itemMetadata.Range(func(key, value interface{}) bool {
+		if metadata, ok := value.(*PoolItemMetadata); ok && metadata.PoolName == poolName && key.(string) != poolName {
+			items = append(items, metadata.snapshot())
+		}
+		return true
+	})
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].LastUsed.After(items[j].LastUsed)
+	})
+	return items
+}
+
+// ImportMetadata memulihkan riwayat penggunaan hasil ExportMetadata ke objek idle pool. Karena kunci
+// metadata berasal dari alamat memori, riwayat dipasangkan secara berurutan dengan objek idle yang
+// ada (misalnya objek hasil InitialSize). Frekuensi, jumlah akses, waktu terakhir digunakan, durasi
+// penggunaan, dan tag disalin; waktu pembuatan dan kedaluwarsa objek baru dipertahankan.
+// Mengembalikan jumlah riwayat yang berhasil dipulihkan.
+func (pm *PoolManager) ImportMetadata(poolName string, items []PoolItemMetadata) int {
+	imported := 0
+	pm.forEachIdle(poolName, func(instance PoolAble, shard int) bool {
+		key := metadataKey(poolName, instance)
+		if imported >= len(items) || key == poolName {
+			return true
+		}
+		history := items[imported].snapshot()
+		pm.safelyUpdateMetadata(key, func(metadata *PoolItemMetadata) {
+			metadata.PoolName = poolName
+			metadata.LastUsed = history.LastUsed
+			metadata.Frequency = history.Frequency
+			metadata.AccessCount = history.AccessCount
+			metadata.UsageDuration = history.UsageDuration
+			metadata.MaxUsageDuration = history.MaxUsageDuration
+			metadata.Tag = history.Tag
+		})
+		imported++
+		return true
+	})
+
+	if imported < len(items) {
+		pm.logPoolf(InfoLevel, poolName, "Imported %d of %d metadata records into pool: %s, remaining records have no idle instance",
+			imported, len(items), poolName)
+	}
+	return imported
+}
+
+// metadataStats menghitung jumlah entri metadata milik pool beserta histogram umur objek yang
+// sedang idle di dalamnya
+func (pm *PoolManager) metadataStats(poolName string) (int64, AgeHistogram) {
+	var count int64
+	var idleAges AgeHistogram
+	now := time.Now()
+	pm.itemMetadata.Range(func(key, value interface{}) bool {
+		metadata, ok := value.(*PoolItemMetadata)
+		if !ok || metadata.PoolName != poolName {
+			return true
+		}
+		count++
+		if metadata.IsPooled && key.(string) != poolName {
+			age := now.Sub(metadata.CreationTime)
+			idleAges.Buckets[ageBucket(age)]++
+			idleAges.Count++
+			idleAges.Sum += age
+		}
+		return true
+	})
+	return count, idleAges
+}
+
+// ItemsWithTag mengembalikan kunci metadata dari item pada pool tertentu yang memiliki tag dengan nilai tertentu.
+// poolName: nama pool yang diperiksa
+// tagKey: nama tag yang dicari
+// tagValue: nilai tag yang harus cocok
+func (pm *PoolManager) ItemsWithTag(poolName, tagKey, tagValue string) []string {
+	var keys []string
+	pm.itemMetadata.Range(func(key, value interface{}) bool {
+		if metadata, ok := value.(*PoolItemMetadata); ok && metadata.PoolName == poolName {
+			if v, found := metadata.Tag[tagKey]; found && v == tagValue {
+				keys = append(keys, key.(string))
+			}
+		}
+		return true
+	})
+	return keys
 }
@@ -1,19 +1,49 @@
 package poolmanager
 
 import (
-	"errors"
 	"sync/atomic"
+	"time"
 )
 
 // PoolMetrics untuk mencatat metrik penggunaan pool
 // PoolMetrics menyimpan data mengenai jumlah operasi yang dilakukan pada pool,
 // termasuk berapa kali objek diambil (TotalGets), dikembalikan (TotalPuts),
 // dihapus (TotalEvicts), dan jumlah penggunaan pool saat ini (CurrentUsage).
+// IdleCount dan InUseCount memisahkan objek yang menunggu di pool dari objek yang sedang dipinjam.
+// Pada metrik yang hidup, TotalGets, TotalPuts, TotalCacheHits, dan CurrentUsage disimpan dalam
+// penghitung bergaris dan baru terisi pada salinan hasil snapshot.
 type PoolMetrics struct {
-	TotalGets    int64 // Total jumlah objek yang diambil dari pool
-	TotalPuts    int64 // Total jumlah objek yang dikembalikan ke pool
-	TotalEvicts  int64 // Total jumlah objek yang dihapus dari pool
-	CurrentUsage int32 // Jumlah objek yang sedang digunakan
+	TotalGets           int64             // Total jumlah objek yang diambil dari pool
+	TotalPuts           int64             // Total jumlah objek yang dikembalikan ke pool
+	TotalEvicts         int64             // Total jumlah objek yang dihapus dari pool
+	TotalLeaks          int64             // Total jumlah objek yang di-GC saat masih dipinjam
+	TotalDiscards       int64             // Total jumlah objek yang dihancurkan saat dikembalikan
+	TotalBroken         int64             // Total jumlah objek yang ditandai rusak dengan MarkBroken
+	TotalCacheHits      int64             // Total jumlah objek yang diambil dari cache
+	TotalSpills         int64             // Total jumlah objek yang diserialisasi ke cold tier
+	TotalRehydrates     int64             // Total jumlah objek yang dihidrasi kembali dari cold tier
+	TotalStale          int64             // Total jumlah item kedaluwarsa yang diserahkan karena pembuatan pengganti gagal
+	TotalCoalesced      int64             // Total jumlah acquire berkunci yang berbagi hasil acquire lain (juga dihitung pada TotalCacheHits)
+	TotalReclaimed      int64             // Total jumlah instance yang diambil kembali karena context AcquireBound selesai atau lease berakhir
+	TotalInvalid        int64             // Total jumlah instance idle yang gagal Validate saat acquire dan dihancurkan
+	ColdCount           int64             // Jumlah objek yang sedang berada di cold tier
+	CurrentUsage        int32             // Jumlah objek yang sedang digunakan
+	RetainedBytes       int64             // Perkiraan byte yang ditahan oleh objek idle di dalam pool
+	IdleCount           int64             // Perkiraan jumlah objek idle di dalam pool
+	InUseCount          int64             // Jumlah objek yang sedang dipinjam
+	Waiters             int64             // Jumlah goroutine yang sedang menunggu unit MaxActive
+	MetadataEntries     int64             // Jumlah entri metadata item milik pool, hanya diisi oleh DescribePool dan Snapshot
+	SlowestConstruction time.Duration     // Durasi pemanggilan factory terlama
+	FactoryLatency      LatencyHistogram  // Histogram durasi pemanggilan factory
+	ObjectLifetime      AgeHistogram      // Histogram umur objek saat dihancurkan
+	IdleAges            AgeHistogram      // Histogram umur objek yang sedang idle, hanya diisi oleh DescribePool dan Snapshot
+	Shards              []ShardMetrics    // Gauge per shard (kosong jika pool tidak menggunakan sharding)
+	Labels              map[string]string // Label statis pool, hanya diisi pada salinan metrik yang diekspor
+
+	putDriftReported int32        // Apakah pelanggaran invarian put sudah dilaporkan
+	carriedInUse     int64        // Objek yang masih dipinjam saat metrik di-reset oleh ReinitializePool
+	usageAdjust      int64        // Koreksi CurrentUsage dari objek yang dibawa saat reset dan dari laporan drift
+	hot              *hotCounters // Penghitung bergaris untuk TotalGets, TotalPuts, dan TotalCacheHits (nil pada salinan)
 }
 
 // MetricsCallback digunakan untuk mencatat metrik secara custom
@@ -21,12 +51,32 @@ type PoolMetrics struct {
 // penggunaan pool secara kustom berdasarkan tipe pool dan tindakan yang terjadi.
 type MetricsCallback func(poolType, action string, metrics PoolMetrics)
 
-// initMetrics menginisialisasi metrik untuk pool tertentu
-// poolType: tipe pool untuk menginisialisasi metrik
+// newPoolMetrics membuat metrik kosong untuk sebuah pool
+// shardCount: jumlah shard pool (0 atau 1 berarti tanpa gauge per shard)
 // Fungsi ini digunakan untuk mempersiapkan penyimpanan metrik untuk sebuah pool,
 // memastikan bahwa data metrik tersedia dan siap untuk dicatat.
-func (pm *PoolManager) initMetrics(poolType string) {
-	pm.metrics.Store(poolType, &PoolMetrics{})
+func newPoolMetrics(shardCount int) *PoolMetrics {
+	metrics := &PoolMetrics{hot: &hotCounters{}}
+	if shardCount > 1 {
+		metrics.Shards = make([]ShardMetrics, shardCount)
+	}
+	return metrics
+}
+
+// resetMetrics mengganti metrik pool dengan metrik baru, namun mempertahankan gauge
+// objek yang sedang dipinjam karena objek tersebut masih akan dikembalikan ke pool.
+func (e *poolEntry) resetMetrics(shardCount int) {
+	previous := e.metrics.Load().snapshot()
+	metrics := newPoolMetrics(shardCount)
+	atomic.StoreInt64(&metrics.usageAdjust, int64(previous.CurrentUsage))
+	atomic.StoreInt64(&metrics.InUseCount, previous.InUseCount)
+	atomic.StoreInt64(&metrics.carriedInUse, previous.InUseCount)
+	for i := range metrics.Shards {
+		if i < len(previous.Shards) {
+			atomic.StoreInt64(&metrics.Shards[i].InUseCount, previous.Shards[i].InUseCount)
+		}
+	}
+	e.metrics.Store(metrics)
 }
 
 // MonitoringConfig untuk mengatur konfigurasi monitoring
@@ -35,10 +85,23 @@ func (pm *PoolManager) initMetrics(poolType string) {
 // (LogFunc), dan fungsi pencatatan metrik kustom (CustomMetricsFunc).
 type MonitoringConfig struct {
 	EnableLogging     bool                 // Menentukan apakah logging diaktifkan
-	LogFunc           func(message string) // Fungsi untuk mencatat log
+	LogFunc           func(message string) // Fungsi untuk mencatat log sebagai satu baris teks (lihat NewFuncLogger)
+	Logger            Logger               // Tujuan log PoolManager, didahulukan atas LogFunc jika diatur
 	CustomMetricsFunc MetricsCallback      // Fungsi untuk mencatat metrik secara kustom
-	LogLevel          LogLevel
+	MetricsSink       MetricsSink          // Tujuan counter, gauge, dan histogram internal (nil berarti tidak dikirim ke mana pun)
+	LogLevel          LogLevel             // Level log minimum yang dicatat
 	OnEvent           func(event PoolEvent)
+	OnDrift           func(report DriftReport)          // Dipanggil saat invarian akuntansi metrik dilanggar
+	OnAdvisory        func(advisory SaturationAdvisory) // Dipanggil saat pool jenuh lebih lama dari SaturationAdvisoryAfter
+	OnRunawayGrowth   func(alert GrowthAlert)           // Dipanggil saat pool tumbuh melampaui GrowthGuardConfig
+	OnLeakSuspected   func(report LeakReport)           // Dipanggil saat LeakDetection melaporkan instance yang tidak dikembalikan
+	TraceOperations   bool                              // Catat setiap keputusan acquire/release/evict pada DebugLevel
+
+	// SampleEvery membatasi log, event, dan observasi histogram per acquire/release menjadi
+	// satu dari setiap N operasi (0 atau 1 berarti semua operasi dicatat)
+	SampleEvery int
+	// MaxSamplesPerSecond membatasi jumlah operasi yang diobservasi per detik (0 berarti tanpa batas)
+	MaxSamplesPerSecond int
 }
 
 type EventType int
@@ -47,52 +110,107 @@ const (
 	EventAcquire EventType = iota
 	EventRelease
 	EventEvict
+	EventLeak
+	EventDrift
+	EventStarvation
+	EventSlowFactory
+	EventAdvisory
+	EventRunawayGrowth
+	EventFailover      // Item berisi nama pool shadow yang kini melayani trafik
+	EventLeakSuspected // Instance dipinjam lebih lama dari LeakWindow atau masih dipinjam saat Close
 )
 
 type PoolEvent struct {
 	Type     EventType
 	PoolName string
 	Item     interface{}
+	Drift    *DriftReport        // Diisi hanya untuk EventDrift
+	Duration time.Duration       // Durasi pembuatan instance, diisi hanya untuk EventSlowFactory
+	Advisory *SaturationAdvisory // Diisi hanya untuk EventAdvisory
+	Growth   *GrowthAlert        // Diisi hanya untuk EventRunawayGrowth
+	Leak     *LeakReport         // Diisi hanya untuk EventLeakSuspected
+	LeaseID  string              // ID korelasi peminjaman instance terkait (kosong jika tidak terkait instance)
+	Labels   map[string]string   // Label statis pool asal event
 }
 
 func (pm *PoolManager) triggerEvent(event PoolEvent) {
-	if pm.monitoringConfig.OnEvent != nil {
-		pm.monitoringConfig.OnEvent(event)
+	pm.emitEvent(pm.monitoring(), event)
+}
+
+// emitEvent meneruskan event ke OnEvent menurut konfigurasi monitoring yang sudah dimuat pemanggil
+func (pm *PoolManager) emitEvent(monitoring *MonitoringConfig, event PoolEvent) {
+	if onEvent := monitoring.OnEvent; onEvent != nil {
+		if event.Labels == nil {
+			event.Labels = pm.poolLabels(event.PoolName)
+		}
+		onEvent(event)
 	}
 }
 
-// GetPoolUsage mengakses metrik penggunaan pool secara langsung dari sync.Map.
+// GetPoolUsage mengakses metrik penggunaan pool secara langsung dari entri pool.
 func (pm *PoolManager) GetPoolUsage(poolType string) (int32, error) {
-	if metrics, ok := pm.metrics.Load(poolType); ok {
-		return metrics.(PoolMetrics).CurrentUsage, nil
+	metrics, err := pm.loadMetrics(poolType)
+	if err != nil {
+		return 0, err
 	}
-	return 0, errors.New("metrics not found for pool: " + poolType)
+	usage, _ := metrics.currentUsage()
+	return usage, nil
 }
 
 // recordMetric mencatat metrik penggunaan pool
 // poolType: tipe pool yang metriknya akan dicatat
-// action: tindakan yang dilakukan ("get", "put", atau "evict")
+// action: tindakan yang dilakukan ("get", "cache_hit", "put", "evict", "leak", "discard", "broken", "spill", "stale", atau "coalesced")
+// "evict" dicatat saat objek idle dihancurkan, misalnya karena ExpirationTime-nya sudah lewat
 // Fungsi ini mencatat tindakan yang dilakukan pada pool dan memperbarui
 // metrik secara atomik, untuk memastikan konsistensi data saat beberapa goroutine
-// melakukan pencatatan secara bersamaan.
+// melakukan pencatatan secara bersamaan. Jika CustomMetricsFunc diatur, callback tersebut
+// dipanggil setelah setiap pencatatan dengan salinan metrik terbaru.
 func (pm *PoolManager) recordMetric(poolType, action string) {
-	// Memastikan metrik sudah ada, jika tidak, buat baru
-	metricsVal, _ := pm.metrics.LoadOrStore(poolType, &PoolMetrics{})
-	metrics, ok := metricsVal.(*PoolMetrics)
+	// Pencatatan untuk pool yang tidak terdaftar (misalnya setelah RemovePool) dibuang
+	entry, ok := pm.entryFor(poolType)
 	if !ok {
 		return
 	}
+	metrics := entry.metrics.Load()
 
 	// Memperbarui metrik secara atomik
 	switch action {
+	// CurrentUsage diturunkan dari penghitung di bawah ini sehingga tidak diperbarui terpisah
 	case "get":
-		atomic.AddInt64(&metrics.TotalGets, 1)
-		atomic.AddInt32(&metrics.CurrentUsage, 1)
+		metrics.hot.gets.add(1)
 	case "put":
-		atomic.AddInt64(&metrics.TotalPuts, 1)
-		atomic.AddInt32(&metrics.CurrentUsage, -1)
+		metrics.hot.puts.add(1)
+	case "cache_hit":
+		metrics.hot.cacheHits.add(1)
+	case "coalesced":
+		// Peminjaman bersama dihitung sebagai cache hit karena tidak mengambil objek dari pool
+		metrics.hot.cacheHits.add(1)
+		atomic.AddInt64(&metrics.TotalCoalesced, 1)
 	case "evict":
 		atomic.AddInt64(&metrics.TotalEvicts, 1)
+	case "leak":
+		atomic.AddInt64(&metrics.TotalLeaks, 1)
+	case "discard":
+		atomic.AddInt64(&metrics.TotalDiscards, 1)
+	case "broken":
+		atomic.AddInt64(&metrics.TotalBroken, 1)
+	case "spill":
+		atomic.AddInt64(&metrics.TotalSpills, 1)
+	case "stale":
+		atomic.AddInt64(&metrics.TotalStale, 1)
+	case "reclaim":
+		atomic.AddInt64(&metrics.TotalReclaimed, 1)
+	case "invalid":
+		atomic.AddInt64(&metrics.TotalInvalid, 1)
+	}
+
+	pm.recordOperation(poolType, action)
+	pm.checkInvariants(poolType, action, metrics)
+	pm.emitCounter(poolType, SinkOperationsTotal, 1, "action", action)
+
+	// Teruskan salinan metrik ke callback kustom agar pengguna dapat mengirimnya ke telemetri sendiri
+	if customMetrics := pm.monitoring().CustomMetricsFunc; customMetrics != nil {
+		customMetrics(poolType, action, pm.snapshotMetrics(poolType, metrics))
 	}
 }
 
@@ -100,39 +218,10 @@ func (pm *PoolManager) recordMetric(poolType, action string) {
 // poolType: tipe pool yang ingin diperiksa jumlah penggunaannya
 // Mengembalikan jumlah objek yang sedang digunakan dalam pool saat ini.
 func (pm *PoolManager) getCurrentUsage(poolType string) int32 {
-	metricsVal, ok := pm.metrics.Load(poolType)
-	if !ok {
-		return 0
-	}
-	metrics, ok := metricsVal.(*PoolMetrics)
+	entry, ok := pm.entryFor(poolType)
 	if !ok {
 		return 0
 	}
-	return metrics.CurrentUsage
-}
-
-// getShardSize menghitung ukuran dari shard tertentu dalam sync.Pool
-func (pm *PoolManager) getShardSize(poolType string, shardIndex int) int {
-	size := 0
-	pm.cache.Range(func(key, value interface{}) bool {
-		if keyStr, ok := key.(string); ok && keyStr == poolType {
-			if shardVal, ok := value.(int); ok && shardVal == shardIndex {
-				size++
-			}
-		}
-		return true
-	})
-	return size
-}
-
-// getNonShardedPoolSize mengambil ukuran pool non-sharded di sync.Pool
-func (pm *PoolManager) getNonShardedPoolSize(poolType string) int {
-	size := 0
-	pm.cache.Range(func(key, value interface{}) bool {
-		if keyStr, ok := key.(string); ok && keyStr == poolType {
-			size++
-		}
-		return true
-	})
-	return size
+	usage, _ := entry.metrics.Load().currentUsage()
+	return usage
 }
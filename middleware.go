@@ -0,0 +1,41 @@
+package poolmanager
+
+// AcquireFunc adalah bentuk fungsi acquire yang dibungkus oleh middleware
+type AcquireFunc func(poolName string, opts ...AcquireOption) (PoolAble, error)
+
+// ReleaseFunc adalah bentuk fungsi release yang dibungkus oleh middleware
+type ReleaseFunc func(poolName string, instance PoolAble) error
+
+// PoolMiddleware membungkus operasi acquire dan release seperti middleware HTTP, sehingga
+// kebutuhan lintas fungsi (tracing, otorisasi, kuota, instrumentasi) dapat dipasang tanpa
+// mengubah kode inti maupun setiap pemanggil. Field yang nil tidak membungkus operasi terkait.
+type PoolMiddleware struct {
+	Acquire func(next AcquireFunc) AcquireFunc // Membungkus AcquireInstance
+	Release func(next ReleaseFunc) ReleaseFunc // Membungkus ReleaseInstance
+}
+
+// middlewareChain adalah rantai middleware yang sudah disusun di atas operasi inti
+type middlewareChain struct {
+	acquire AcquireFunc
+	release ReleaseFunc
+}
+
+// Use mendaftarkan middleware untuk seluruh pool. Middleware yang didaftarkan lebih dahulu
+// menjadi lapisan terluar, sehingga dijalankan pertama saat acquire dan release.
+func (pm *PoolManager) Use(middleware ...PoolMiddleware) {
+	pm.middlewareMu.Lock()
+	defer pm.middlewareMu.Unlock()
+
+	pm.middlewares = append(pm.middlewares, middleware...)
+
+	chain := &middlewareChain{acquire: pm.acquireInstance, release: pm.releaseInstance}
+	for i := len(pm.middlewares) - 1; i >= 0; i-- {
+		if mw := pm.middlewares[i]; mw.Acquire != nil {
+			chain.acquire = mw.Acquire(chain.acquire)
+		}
+		if mw := pm.middlewares[i]; mw.Release != nil {
+			chain.release = mw.Release(chain.release)
+		}
+	}
+	pm.middleware.Store(chain)
+}
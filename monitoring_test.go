@@ -0,0 +1,45 @@
+package poolmanager_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	poolmanager "github.com/hibbannn/pool-manager"
+)
+
+func TestSetMonitoringConfigDuringEviction(t *testing.T) {
+	pm := newTestManager(t)
+	_, err := pm.AddPoolWithOptions("monitored", func() poolmanager.PoolAble { return &testObject{} },
+		poolmanager.WithMaxIdleTime(time.Millisecond, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(50 * time.Millisecond)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for time.Now().Before(deadline) {
+			pm.SetMonitoringConfig(poolmanager.MonitoringConfig{MetricsSink: poolmanager.NewInMemorySink()})
+			pm.SetLogLevel(poolmanager.ErrorLevel)
+			pm.SetDebugTracing(false)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for time.Now().Before(deadline) {
+			instance, err := pm.AcquireInstance("monitored")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if err := pm.ReleaseInstance("monitored", instance); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	wg.Wait()
+}
@@ -0,0 +1,107 @@
+package poolmanager
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxPoolNameLength adalah panjang maksimum nama pool, termasuk namespace
+const MaxPoolNameLength = 128
+
+// PoolNameSeparator memisahkan namespace dari nama pool, misalnya "billing/invoice-buffer"
+const PoolNameSeparator = "/"
+
+// ValidatePoolName memeriksa apakah nama pool mengikuti aturan penamaan PoolManager.
+// Nama terdiri dari satu segmen ("buffers") atau namespace dan nama ("billing/buffers").
+// Setiap segmen harus diawali huruf atau angka dan hanya boleh berisi huruf, angka, '_', '-', dan '.'.
+// Karakter lain seperti '#' dan spasi ditolak karena dipakai sebagai pemisah kunci metadata,
+// cache, dan label sehingga nama bebas dapat bertabrakan secara diam-diam.
+func ValidatePoolName(poolName string) error {
+	if poolName == "" {
+		return fmt.Errorf("%w: name is empty", ErrInvalidPoolName)
+	}
+	if len(poolName) > MaxPoolNameLength {
+		return fmt.Errorf("%w: name is longer than %d characters", ErrInvalidPoolName, MaxPoolNameLength)
+	}
+	if strings.Count(poolName, PoolNameSeparator) > 1 {
+		return fmt.Errorf("%w: %q has more than one namespace separator", ErrInvalidPoolName, poolName)
+	}
+	namespace, name := SplitPoolName(poolName)
+	if strings.Contains(poolName, PoolNameSeparator) {
+		if err := validateNameSegment("namespace", namespace); err != nil {
+			return err
+		}
+	}
+	return validateNameSegment("name", name)
+}
+
+// validateNameSegment memeriksa satu segmen nama pool
+func validateNameSegment(kind, segment string) error {
+	if segment == "" {
+		return fmt.Errorf("%w: %s is empty", ErrInvalidPoolName, kind)
+	}
+	for i, r := range segment {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case i > 0 && (r == '_' || r == '-' || r == '.'):
+		default:
+			return fmt.Errorf("%w: %s %q contains invalid character %q", ErrInvalidPoolName, kind, segment, r)
+		}
+	}
+	return nil
+}
+
+// SplitPoolName memisahkan nama pool menjadi namespace dan nama lokalnya.
+// Pool tanpa namespace mengembalikan namespace kosong, misalnya "buffers" menjadi ("", "buffers")
+// dan "billing/buffers" menjadi ("billing", "buffers").
+func SplitPoolName(poolName string) (namespace, name string) {
+	if i := strings.Index(poolName, PoolNameSeparator); i >= 0 {
+		return poolName[:i], poolName[i+len(PoolNameSeparator):]
+	}
+	return "", poolName
+}
+
+// PoolNamespace mengembalikan namespace dari nama pool, string kosong jika tidak ada
+func PoolNamespace(poolName string) string {
+	namespace, _ := SplitPoolName(poolName)
+	return namespace
+}
+
+// inNamespace memeriksa apakah pool termasuk namespace tertentu. Namespace kosong cocok dengan
+// seluruh pool.
+func inNamespace(poolName, namespace string) bool {
+	return namespace == "" || PoolNamespace(poolName) == namespace
+}
+
+// ListPoolsInNamespace mengembalikan nama lengkap pool dalam namespace tertentu, diurutkan secara
+// alfabetis. Namespace kosong mengembalikan seluruh pool seperti ListPools.
+func (pm *PoolManager) ListPoolsInNamespace(namespace string) []string {
+	var names []string
+	pm.forEachEntry(func(entry *poolEntry) bool {
+		if inNamespace(entry.name, namespace) {
+			names = append(names, entry.name)
+		}
+		return true
+	})
+	sort.Strings(names)
+	return names
+}
+
+// Namespaces mengembalikan daftar namespace yang memiliki setidaknya satu pool, diurutkan secara
+// alfabetis. Pool tanpa namespace tidak disertakan.
+func (pm *PoolManager) Namespaces() []string {
+	seen := make(map[string]struct{})
+	pm.forEachEntry(func(entry *poolEntry) bool {
+		if namespace := PoolNamespace(entry.name); namespace != "" {
+			seen[namespace] = struct{}{}
+		}
+		return true
+	})
+	namespaces := make([]string, 0, len(seen))
+	for namespace := range seen {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
@@ -0,0 +1,290 @@
+package poolmanager
+
+import "time"
+
+// PoolOption adalah opsi fungsional untuk mengubah PoolConfiguration saat membuat pool dengan NewPool.
+// PoolOption merupakan alternatif dari PoolConfigBuilder; opsi kustom dapat dibuat cukup dengan
+// menulis fungsi yang menerima *PoolConfiguration.
+type PoolOption func(config *PoolConfiguration)
+
+// PoolRef adalah handle ke pool yang sudah terdaftar pada PoolManager.
+// Handle ini terikat langsung ke data pool sehingga Acquire dan Release tidak melakukan pencarian
+// berdasarkan nama. Jika pool dihapus, handle kembali menggunakan API berbasis nama.
+type PoolRef struct {
+	pm    *PoolManager // PoolManager yang mengelola pool
+	name  string       // Nama pool
+	entry *poolEntry   // Entri pool yang di-resolve saat pool dibuat
+}
+
+// NewPool membuat PoolManager baru beserta satu pool yang siap digunakan.
+// name: nama pool yang dibuat
+// factory: fungsi untuk membuat objek baru dalam pool
+// opts: opsi fungsional yang diterapkan di atas konfigurasi default NewPoolConfiguration
+// Mengembalikan handle ke pool, atau error jika konfigurasi tidak valid.
+// Tugas latar belakang pool baru dijalankan oleh AddPool, sehingga tidak ada yang tertinggal jika
+// pool gagal ditambahkan.
+func NewPool(name string, factory func() PoolAble, opts ...PoolOption) (*PoolRef, error) {
+	config, err := buildPoolConfiguration(name, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newManager(config).AddPool(name, factory, config)
+}
+
+// AddPoolWithOptions menambahkan pool baru ke PoolManager yang sudah ada menggunakan opsi fungsional.
+// Mengembalikan handle ke pool, atau error jika konfigurasi tidak valid atau pool sudah ada.
+func (pm *PoolManager) AddPoolWithOptions(name string, factory func() PoolAble, opts ...PoolOption) (*PoolRef, error) {
+	config, err := buildPoolConfiguration(name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return pm.AddPool(name, factory, config)
+}
+
+// buildPoolConfiguration menerapkan opsi secara berurutan di atas konfigurasi default lalu memvalidasinya.
+func buildPoolConfiguration(name string, opts []PoolOption) (PoolConfiguration, error) {
+	config := NewPoolConfiguration(name).config
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&config)
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return PoolConfiguration{}, err
+	}
+	return config, nil
+}
+
+// Name mengembalikan nama pool yang dirujuk oleh handle.
+func (r *PoolRef) Name() string {
+	return r.name
+}
+
+// Manager mengembalikan PoolManager yang mengelola pool.
+func (r *PoolRef) Manager() *PoolManager {
+	return r.pm
+}
+
+// bound mengembalikan entri pool yang terikat pada handle, atau nil jika handle harus kembali
+// menggunakan API berbasis nama (pool sudah dihapus atau middleware terdaftar)
+func (r *PoolRef) bound() *poolEntry {
+	if r.entry == nil || r.entry.removed.Load() || r.pm.middleware.Load() != nil {
+		return nil
+	}
+	return r.entry
+}
+
+// Acquire mengambil instance dari pool yang dirujuk oleh handle.
+func (r *PoolRef) Acquire(opts ...AcquireOption) (PoolAble, error) {
+	if entry := r.bound(); entry != nil {
+		return r.pm.acquireFrom(entry, opts...)
+	}
+	return r.pm.AcquireInstance(r.name, opts...)
+}
+
+// Release mengembalikan instance ke pool yang dirujuk oleh handle.
+func (r *PoolRef) Release(instance PoolAble) error {
+	if entry := r.bound(); entry != nil {
+		return r.pm.releaseTo(entry, instance)
+	}
+	return r.pm.ReleaseInstance(r.name, instance)
+}
+
+// WithConfig menggantikan seluruh konfigurasi dengan base, misalnya hasil dari preset,
+// tetapi tetap mempertahankan nama pool. Letakkan opsi ini paling awal agar opsi berikutnya
+// dapat menimpa nilai dari base.
+func WithConfig(base PoolConfiguration) PoolOption {
+	return func(config *PoolConfiguration) {
+		name := config.Name
+		*config = base
+		config.Name = name
+	}
+}
+
+// WithSizeLimit menetapkan batas maksimum jumlah objek dalam pool.
+func WithSizeLimit(sizeLimit int) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.SizeLimit = sizeLimit
+	}
+}
+
+// WithMinSize menetapkan ukuran minimum pool.
+func WithMinSize(minSize int) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.MinSize = minSize
+	}
+}
+
+// WithMaxSize menetapkan ukuran maksimum pool saat auto-tuning.
+func WithMaxSize(maxSize int) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.MaxSize = maxSize
+	}
+}
+
+// WithInitialSize menetapkan ukuran awal pool saat diinisialisasi.
+func WithInitialSize(initialSize int) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.InitialSize = initialSize
+	}
+}
+
+// WithAutoTune mengaktifkan auto-tuning dengan interval dan faktor peningkatan ukuran tertentu.
+func WithAutoTune(interval time.Duration, factor float64) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.AutoTune = true
+		config.AutoTuneInterval = interval
+		config.AutoTuneFactor = factor
+	}
+}
+
+// WithCaching mengaktifkan caching dengan batas ukuran cache tertentu.
+func WithCaching(cacheMaxSize int) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.EnableCaching = true
+		config.CacheMaxSize = cacheMaxSize
+	}
+}
+
+// WithSharding mengaktifkan sharding dengan jumlah shard dan strategi tertentu.
+// strategy boleh nil untuk menggunakan strategi default.
+func WithSharding(shardCount int, strategy ShardingStrategy) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.ShardingEnabled = true
+		config.ShardCount = shardCount
+		config.ShardStrategy = strategy
+	}
+}
+
+// WithEviction menetapkan kebijakan eviksi beserta TTL dan interval eviksinya.
+func WithEviction(policy EvictionPolicy, ttl, interval time.Duration) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.Eviction = policy
+		config.TTL = ttl
+		config.EvictionInterval = interval
+	}
+}
+
+// WithMaxIdleTime menetapkan batas waktu idle objek beserta interval scheduler eviksi yang menegakkannya.
+func WithMaxIdleTime(maxIdleTime, interval time.Duration) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.MaxIdleTime = maxIdleTime
+		config.EvictionInterval = interval
+	}
+}
+
+// WithMaxLifetime menetapkan batas umur objek sejak dibuat; objek yang lebih tua dihancurkan saat dikembalikan.
+func WithMaxLifetime(maxLifetime time.Duration) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.MaxLifetime = maxLifetime
+	}
+}
+
+// WithMaxPooledObjectBytes menetapkan batas ukuran objek yang boleh dikembalikan ke pool.
+func WithMaxPooledObjectBytes(maxBytes int64) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.MaxPooledObjectBytes = maxBytes
+	}
+}
+
+// WithMaxActive menetapkan kapasitas unit in-use pool; acquire menunggu jika kapasitas habis.
+func WithMaxActive(maxActive int64) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.MaxActive = maxActive
+	}
+}
+
+// WithWaitTimeout menetapkan batas waktu tunggu acquire saat MaxActive habis atau pool berbatas penuh.
+func WithWaitTimeout(timeout time.Duration) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.WaitTimeout = timeout
+	}
+}
+
+// WithGrowthGuard mengaktifkan batas pertumbuhan pool beserta peringatannya.
+func WithGrowthGuard(guard GrowthGuardConfig) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.GrowthGuard = &guard
+	}
+}
+
+// WithServeStale mengizinkan item kedaluwarsa diserahkan sebagai instance degraded saat pembuatan pengganti gagal.
+func WithServeStale() PoolOption {
+	return func(config *PoolConfiguration) {
+		config.ServeStale = true
+	}
+}
+
+// WithMaintenance menunda eviksi terjadwal dan auto-tuning hingga maintenance window terbuka.
+func WithMaintenance(maintenance MaintenanceConfig) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.Maintenance = &maintenance
+	}
+}
+
+// WithBackend menetapkan jenis penyimpanan objek idle, misalnya BackendBounded untuk batas keras objek hidup.
+func WithBackend(backend Backend) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.Backend = backend
+	}
+}
+
+// WithHealthScoring mengutamakan instance yang sehat dan memensiunkan instance dengan skor rendah.
+func WithHealthScoring(health HealthScoringConfig) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.Health = &health
+	}
+}
+
+// WithLabels menetapkan label statis pool yang diteruskan ke metrik, event, dan log.
+func WithLabels(labels map[string]string) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.Labels = copyLabels(labels)
+	}
+}
+
+// WithLogger menetapkan tujuan log pool, misalnya NewSlogLogger atau DiscardLogger, yang didahulukan
+// atas MonitoringConfig.Logger dan LogFunc untuk pesan pool tersebut. Pada NewPool, logger juga
+// menjadi MonitoringConfig.Logger PoolManager.
+func WithLogger(logger Logger) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.Logger = logger
+	}
+}
+
+// WithLeakFinalizer mengaktifkan deteksi kebocoran berbasis finalizer beserta callback-nya.
+// onLeak boleh nil jika cukup dicatat pada log dan metrik. Instance harus pointer ke objek berukuran
+// non-nol; instance lain dilaporkan sebagai dugaan kebocoran setelah LeakWindow (default 10 menit).
+func WithLeakFinalizer(onLeak func(poolType string, heldFor time.Duration)) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.LeakFinalizer = true
+		config.OnLeak = onLeak
+	}
+}
+
+// WithLeakDetection mengaktifkan mode debug deteksi kebocoran berbasis stack acquire. Instance yang
+// dipinjam lebih lama dari window dilaporkan sebagai dugaan kebocoran; window 0 berarti instance
+// hanya dilaporkan saat Close.
+func WithLeakDetection(window time.Duration) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.LeakDetection = true
+		config.LeakWindow = window
+	}
+}
+
+// WithLease mengaktifkan mode lease beserta callback saat lease berakhir. onExpired boleh nil
+// jika cukup dicatat pada log dan metrik.
+func WithLease(duration time.Duration, onExpired func(poolType, leaseID string, heldFor time.Duration)) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.LeaseDuration = duration
+		config.OnLeaseExpired = onExpired
+	}
+}
+
+// WithOnError menetapkan callback yang dipanggil saat terjadi error pada pool.
+func WithOnError(onError func(poolType string, err error)) PoolOption {
+	return func(config *PoolConfiguration) {
+		config.OnError = onError
+	}
+}
@@ -0,0 +1,26 @@
+package poolmanager_test
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	poolmanager "github.com/hibbannn/pool-manager"
+)
+
+func TestNewPoolInvalidNameStartsNoTasks(t *testing.T) {
+	before := runtime.NumGoroutine()
+	_, err := poolmanager.NewPool("a/b/c", func() poolmanager.PoolAble { return &testObject{} },
+		poolmanager.WithAutoTune(time.Millisecond, 2), poolmanager.WithMaxIdleTime(time.Minute, time.Millisecond),
+		poolmanager.WithLogger(poolmanager.DiscardLogger))
+	if !errors.Is(err, poolmanager.ErrInvalidPoolName) {
+		t.Fatalf("NewPool error = %v, want ErrInvalidPoolName", err)
+	}
+
+	// Beri kesempatan goroutine yang keliru dijalankan untuk muncul
+	time.Sleep(10 * time.Millisecond)
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("goroutines = %d after failed NewPool, want at most %d", after, before)
+	}
+}
@@ -0,0 +1,178 @@
+package poolmanager
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// ItemsByOwner mengembalikan kunci metadata dari seluruh item, di semua pool, yang sedang
+// dipinjam oleh pemilik tertentu. Pemilik ditetapkan saat acquire dengan WithOwner.
+func (pm *PoolManager) ItemsByOwner(owner string) []string {
+	var keys []string
+	pm.itemMetadata.Range(func(key, value interface{}) bool {
+		if metadata, ok := value.(*PoolItemMetadata); ok && isOwnedItem(key.(string), metadata) && metadata.OwnerID == owner {
+			keys = append(keys, key.(string))
+		}
+		return true
+	})
+	return keys
+}
+
+// OwnerCounts mengembalikan jumlah item pool yang sedang dipinjam per pemilik, sehingga
+// pertanyaan "siapa yang memegang seluruh instance saat ini?" dapat dijawab saat runtime.
+// Item yang dipinjam tanpa WithOwner tidak dihitung.
+func (pm *PoolManager) OwnerCounts(poolName string) map[string]int {
+	counts := make(map[string]int)
+	pm.itemMetadata.Range(func(key, value interface{}) bool {
+		if metadata, ok := value.(*PoolItemMetadata); ok && metadata.PoolName == poolName && isOwnedItem(key.(string), metadata) {
+			counts[metadata.OwnerID]++
+		}
+		return true
+	})
+	return counts
+}
+
+// isOwnedItem melaporkan apakah metadata milik satu instance yang sedang dipinjam oleh pemilik tertentu.
+// Metadata bersama untuk instance yang tidak dapat dilacak (berkunci nama pool) diabaikan.
+func isOwnedItem(key string, metadata *PoolItemMetadata) bool {
+	return key != metadata.PoolName && metadata.Status == "Active" && metadata.OwnerID != ""
+}
+
+const (
+	ownerUsageBucketWidth = time.Minute // Lebar setiap bucket penggunaan per pemilik
+	ownerUsageBucketCount = 60          // Jumlah bucket yang disimpan per pemilik (1 jam)
+)
+
+// ownerUsageBucket menyimpan penggunaan pool oleh satu pemilik selama satu menit
+type ownerUsageBucket struct {
+	minute    int64         // Menit Unix yang diwakili bucket
+	acquires  int64         // Jumlah acquire
+	releases  int64         // Jumlah peminjaman yang selesai
+	holdTotal time.Duration // Total durasi peminjaman yang selesai
+	maxHold   time.Duration // Durasi peminjaman terlama
+	failures  int64         // Acquire gagal, instance rusak, dan kebocoran
+}
+
+// ownerUsageRecorder menyimpan bucket penggunaan per menit untuk setiap pemilik satu pool
+type ownerUsageRecorder struct {
+	mu     sync.Mutex
+	owners map[string]*[ownerUsageBucketCount]ownerUsageBucket
+}
+
+// observe memperbarui bucket menit saat ini milik pemilik tertentu
+func (r *ownerUsageRecorder) observe(owner string, update func(b *ownerUsageBucket)) {
+	minute := time.Now().Unix() / int64(ownerUsageBucketWidth/time.Second)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	buckets, ok := r.owners[owner]
+	if !ok {
+		buckets = &[ownerUsageBucketCount]ownerUsageBucket{}
+		r.owners[owner] = buckets
+	}
+	b := &buckets[minute%ownerUsageBucketCount]
+	if b.minute != minute {
+		*b = ownerUsageBucket{minute: minute}
+	}
+	update(b)
+}
+
+// OwnerUsage merangkum penggunaan pool oleh satu pemilik dalam window tertentu
+type OwnerUsage struct {
+	Owner    string        `json:"owner"`
+	Acquires int64         `json:"acquires"`  // Jumlah acquire
+	Releases int64         `json:"releases"`  // Jumlah peminjaman yang selesai
+	MeanHold time.Duration `json:"mean_hold"` // Rata-rata durasi peminjaman yang selesai
+	MaxHold  time.Duration `json:"max_hold"`  // Durasi peminjaman terlama
+	Failures int64         `json:"failures"`  // Acquire gagal, instance rusak, dan kebocoran
+}
+
+// summarize merangkum bucket setiap pemilik dalam window, diurutkan dari acquire terbanyak
+func (r *ownerUsageRecorder) summarize(now time.Time, window time.Duration) []OwnerUsage {
+	current := now.Unix() / int64(ownerUsageBucketWidth/time.Second)
+	oldest := current - int64((window+ownerUsageBucketWidth-1)/ownerUsageBucketWidth) + 1
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var usages []OwnerUsage
+	for owner, buckets := range r.owners {
+		usage := OwnerUsage{Owner: owner}
+		var holdTotal time.Duration
+		for _, b := range buckets {
+			if b.minute < oldest || b.minute > current {
+				continue
+			}
+			usage.Acquires += b.acquires
+			usage.Releases += b.releases
+			usage.Failures += b.failures
+			holdTotal += b.holdTotal
+			if b.maxHold > usage.MaxHold {
+				usage.MaxHold = b.maxHold
+			}
+		}
+		if usage.Acquires == 0 && usage.Releases == 0 && usage.Failures == 0 {
+			// Pemilik yang tidak aktif selama window terakhir tidak perlu disimpan lagi
+			if window >= ownerUsageBucketWidth*ownerUsageBucketCount {
+				delete(r.owners, owner)
+			}
+			continue
+		}
+		if usage.Releases > 0 {
+			usage.MeanHold = holdTotal / time.Duration(usage.Releases)
+		}
+		usages = append(usages, usage)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Acquires != usages[j].Acquires {
+			return usages[i].Acquires > usages[j].Acquires
+		}
+		return usages[i].Owner < usages[j].Owner
+	})
+	return usages
+}
+
+// observeOwner memperbarui penggunaan pemilik pada pool; peminjaman tanpa pemilik diabaikan
+func (pm *PoolManager) observeOwner(poolName, owner string, update func(b *ownerUsageBucket)) {
+	if owner == "" {
+		return
+	}
+	recorderVal, _ := pm.ownerUsage.LoadOrStore(poolName, &ownerUsageRecorder{owners: make(map[string]*[ownerUsageBucketCount]ownerUsageBucket)})
+	recorderVal.(*ownerUsageRecorder).observe(owner, update)
+}
+
+// observeOwnerAcquire mencatat acquire yang berhasil
+func (pm *PoolManager) observeOwnerAcquire(poolName, owner string) {
+	pm.observeOwner(poolName, owner, func(b *ownerUsageBucket) {
+		b.acquires++
+	})
+}
+
+// observeOwnerHold mencatat peminjaman yang selesai beserta durasinya
+func (pm *PoolManager) observeOwnerHold(poolName, owner string, held time.Duration) {
+	pm.observeOwner(poolName, owner, func(b *ownerUsageBucket) {
+		b.releases++
+		b.holdTotal += held
+		if held > b.maxHold {
+			b.maxHold = held
+		}
+	})
+}
+
+// observeOwnerFailure mencatat kegagalan yang dialami pemilik (acquire gagal, instance rusak, atau kebocoran)
+func (pm *PoolManager) observeOwnerFailure(poolName, owner string) {
+	pm.observeOwner(poolName, owner, func(b *ownerUsageBucket) {
+		b.failures++
+	})
+}
+
+// OwnerUsageReport mengembalikan jumlah acquire, durasi peminjaman, dan kegagalan per pemilik
+// pada pool selama window terakhir (dibulatkan ke menit, maksimal 1 jam), diurutkan dari pemilik
+// dengan acquire terbanyak. Pemilik ditetapkan saat acquire dengan WithOwner.
+func (pm *PoolManager) OwnerUsageReport(poolName string, window time.Duration) []OwnerUsage {
+	recorderVal, ok := pm.ownerUsage.Load(poolName)
+	if !ok {
+		return nil
+	}
+	return recorderVal.(*ownerUsageRecorder).summarize(time.Now(), window)
+}
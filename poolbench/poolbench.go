@@ -0,0 +1,192 @@
+// Package poolbench membandingkan beban kerja yang sama dengan alokasi langsung dan dengan
+// PoolManager, sehingga pertanyaan "apakah pooling bermanfaat di sini?" dapat dijawab dengan
+// satu pemanggilan Compare.
+package poolbench
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	poolmanager "github.com/hibbannn/pool-manager"
+)
+
+// Workload adalah pekerjaan yang dilakukan terhadap satu instance pada setiap operasi
+type Workload func(instance poolmanager.PoolAble)
+
+// Option mengatur jalannya Compare
+type Option func(o *options)
+
+type options struct {
+	operations  int
+	concurrency int
+	config      *poolmanager.PoolConfiguration
+}
+
+// WithOperations menetapkan jumlah operasi per run (default 10000)
+func WithOperations(n int) Option {
+	return func(o *options) {
+		o.operations = n
+	}
+}
+
+// WithConcurrency menetapkan jumlah goroutine yang menjalankan operasi (default GOMAXPROCS)
+func WithConcurrency(n int) Option {
+	return func(o *options) {
+		o.concurrency = n
+	}
+}
+
+// WithPoolConfig menetapkan konfigurasi pool untuk run dengan pool
+// (default NewPoolConfiguration dengan nama "poolbench")
+func WithPoolConfig(config poolmanager.PoolConfiguration) Option {
+	return func(o *options) {
+		o.config = &config
+	}
+}
+
+// Run berisi hasil pengukuran satu run
+type Run struct {
+	Elapsed    time.Duration // Durasi total
+	NsPerOp    float64       // Rata-rata nanodetik per operasi
+	Allocs     uint64        // Jumlah alokasi heap
+	AllocBytes uint64        // Total byte yang dialokasikan
+	GCCycles   uint32        // Jumlah siklus GC selama run
+	GCPause    time.Duration // Total jeda GC selama run
+}
+
+// Result berisi perbandingan run dengan alokasi langsung dan run dengan pool
+type Result struct {
+	Operations int // Jumlah operasi per run
+	Direct     Run // Run dengan alokasi langsung melalui factory
+	Pooled     Run // Run dengan AcquireInstance/ReleaseInstance
+}
+
+// Speedup mengembalikan rasio durasi Direct terhadap Pooled (> 1 berarti pool lebih cepat)
+func (r Result) Speedup() float64 {
+	if r.Pooled.Elapsed == 0 {
+		return 0
+	}
+	return float64(r.Direct.Elapsed) / float64(r.Pooled.Elapsed)
+}
+
+// AllocSavings mengembalikan proporsi byte alokasi yang dihemat oleh pool (negatif berarti pool lebih boros)
+func (r Result) AllocSavings() float64 {
+	if r.Direct.AllocBytes == 0 {
+		return 0
+	}
+	return 1 - float64(r.Pooled.AllocBytes)/float64(r.Direct.AllocBytes)
+}
+
+// Worthwhile mengembalikan true jika pool lebih cepat dan mengalokasikan lebih sedikit byte
+func (r Result) Worthwhile() bool {
+	return r.Speedup() > 1 && r.AllocSavings() > 0
+}
+
+// String merangkum hasil perbandingan dalam beberapa baris
+func (r Result) String() string {
+	return fmt.Sprintf("direct: %s\npooled: %s\nspeedup %.2fx, alloc savings %.1f%%, worthwhile=%t",
+		r.Direct, r.Pooled, r.Speedup(), r.AllocSavings()*100, r.Worthwhile())
+}
+
+// String merangkum hasil satu run
+func (r Run) String() string {
+	return fmt.Sprintf("%s (%.0f ns/op), %d allocs, %d bytes, %d GC (%s pause)",
+		r.Elapsed, r.NsPerOp, r.Allocs, r.AllocBytes, r.GCCycles, r.GCPause)
+}
+
+// Compare menjalankan workload dengan alokasi langsung melalui factory, lalu dengan PoolManager
+// baru yang menggunakan factory yang sama, dan melaporkan waktu, alokasi, serta statistik GC keduanya.
+func Compare(factory func() poolmanager.PoolAble, workload Workload, opts ...Option) (Result, error) {
+	o := options{operations: 10000, concurrency: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	if o.operations <= 0 {
+		o.operations = 1
+	}
+	if o.concurrency <= 0 {
+		o.concurrency = 1
+	}
+
+	var config poolmanager.PoolConfiguration
+	if o.config != nil {
+		config = *o.config
+	} else {
+		var err error
+		if config, err = poolmanager.NewPoolConfiguration("poolbench").Build(); err != nil {
+			return Result{}, err
+		}
+	}
+	poolName := config.Name
+	if poolName == "" {
+		poolName = "poolbench"
+	}
+
+	result := Result{Operations: o.operations}
+	result.Direct, _ = measure(o, func() error {
+		workload(factory())
+		return nil
+	})
+
+	pm := poolmanager.NewPoolManager(config)
+	if _, err := pm.AddPool(poolName, factory, config); err != nil {
+		return Result{}, err
+	}
+	defer pm.RemovePool(poolName)
+
+	var err error
+	result.Pooled, err = measure(o, func() error {
+		instance, err := pm.AcquireInstance(poolName)
+		if err != nil {
+			return err
+		}
+		workload(instance)
+		return pm.ReleaseInstance(poolName, instance)
+	})
+	return result, err
+}
+
+// measure menjalankan operasi sebanyak o.operations dengan o.concurrency goroutine dan
+// mengukur durasi, alokasi, serta GC di antara dua pembacaan runtime.MemStats.
+// Mengembalikan error pertama dari operasi, jika ada.
+func measure(o options, op func() error) (Run, error) {
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	var (
+		next     int64
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	start := time.Now()
+	for i := 0; i < o.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for atomic.AddInt64(&next, 1) <= int64(o.operations) {
+				if err := op(); err != nil {
+					errOnce.Do(func() { firstErr = err })
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	return Run{
+		Elapsed:    elapsed,
+		NsPerOp:    float64(elapsed.Nanoseconds()) / float64(o.operations),
+		Allocs:     after.Mallocs - before.Mallocs,
+		AllocBytes: after.TotalAlloc - before.TotalAlloc,
+		GCCycles:   after.NumGC - before.NumGC,
+		GCPause:    time.Duration(after.PauseTotalNs - before.PauseTotalNs),
+	}, firstErr
+}
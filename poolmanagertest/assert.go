@@ -0,0 +1,85 @@
+// Package poolmanagertest menyediakan helper untuk menguji kode yang menggunakan PoolManager:
+// asersi invarian pool, driver beban kerja dengan konkurensi yang dapat diatur, dan
+// interseptor factory untuk mengamati atau memanipulasi pembuatan instance.
+package poolmanagertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	poolmanager "github.com/hibbannn/pool-manager"
+)
+
+// AssertNoLeaks menggagalkan test jika ada pool yang mencatat instance bocor, yaitu instance
+// yang di-GC saat masih dipinjam. Deteksi kebocoran membutuhkan LeakFinalizer pada pool.
+func AssertNoLeaks(t testing.TB, pm *poolmanager.PoolManager) {
+	t.Helper()
+	for name, metrics := range pm.Snapshot().Pools {
+		if metrics.TotalLeaks > 0 {
+			t.Errorf("pool %s leaked %d instance(s)", name, metrics.TotalLeaks)
+		}
+	}
+}
+
+// AssertAllReleased menggagalkan test jika masih ada instance yang dipinjam dari pool.
+// pools: nama pool yang diperiksa, kosong berarti seluruh pool yang terdaftar
+func AssertAllReleased(t testing.TB, pm *poolmanager.PoolManager, pools ...string) {
+	t.Helper()
+	if len(pools) == 0 {
+		pools = pm.ListPools()
+	}
+
+	checkouts := outstandingCheckouts(t, pm)
+	for _, name := range pools {
+		inUse, err := pm.GetInUseCount(name)
+		if err != nil {
+			t.Errorf("pool %s: %v", name, err)
+			continue
+		}
+		if inUse == 0 {
+			continue
+		}
+		t.Errorf("pool %s has %d instance(s) still checked out", name, inUse)
+		for _, checkout := range checkouts {
+			if checkout.PoolName == name {
+				t.Logf("  %s owner=%q held for %s", checkout.Key, checkout.Owner, checkout.HeldFor)
+			}
+		}
+	}
+}
+
+// AssertNoDrift menggagalkan test jika metrik pool melanggar invarian akuntansi dasar:
+// CurrentUsage negatif atau jumlah put melebihi jumlah get dan cache hit.
+// initial: jumlah objek awal pool (InitialSize) yang boleh dikembalikan tanpa get
+func AssertNoDrift(t testing.TB, pm *poolmanager.PoolManager, poolName string, initial int) {
+	t.Helper()
+	metrics, ok := pm.Snapshot().Pools[poolName]
+	if !ok {
+		t.Errorf("pool %s has no metrics", poolName)
+		return
+	}
+	if metrics.CurrentUsage < 0 {
+		t.Errorf("pool %s has negative CurrentUsage %d", poolName, metrics.CurrentUsage)
+	}
+	if metrics.TotalPuts > metrics.TotalGets+metrics.TotalCacheHits+int64(initial) {
+		t.Errorf("pool %s has more puts (%d) than gets (%d) and cache hits (%d)",
+			poolName, metrics.TotalPuts, metrics.TotalGets, metrics.TotalCacheHits)
+	}
+}
+
+// outstandingCheckouts membaca daftar instance yang sedang dipinjam melalui DumpState
+func outstandingCheckouts(t testing.TB, pm *poolmanager.PoolManager) []poolmanager.CheckoutDump {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := pm.DumpState(&buf, poolmanager.DumpOptions{Format: poolmanager.DumpJSON, IncludeCheckout: true}); err != nil {
+		t.Logf("cannot dump checkouts: %v", err)
+		return nil
+	}
+	var dump poolmanager.StateDump
+	if err := json.Unmarshal(buf.Bytes(), &dump); err != nil {
+		t.Logf("cannot decode checkouts: %v", err)
+		return nil
+	}
+	return dump.Checkouts
+}
@@ -0,0 +1,70 @@
+package poolmanagertest
+
+import (
+	"sync"
+	"sync/atomic"
+
+	poolmanager "github.com/hibbannn/pool-manager"
+)
+
+// FactoryInterceptor membungkus factory pool untuk menghitung dan mencatat setiap pemanggilan,
+// serta memungkinkan test menyisipkan perilaku seperti penundaan atau instance nil.
+type FactoryInterceptor struct {
+	factory func() poolmanager.PoolAble
+	calls   atomic.Int64
+
+	mu        sync.Mutex
+	hook      func(call int64) (poolmanager.PoolAble, bool)
+	instances []poolmanager.PoolAble
+}
+
+// InterceptFactory membuat FactoryInterceptor untuk factory yang diberikan
+func InterceptFactory(factory func() poolmanager.PoolAble) *FactoryInterceptor {
+	return &FactoryInterceptor{factory: factory}
+}
+
+// Factory mengembalikan factory terbungkus yang diteruskan ke AddPool
+func (f *FactoryInterceptor) Factory() func() poolmanager.PoolAble {
+	return func() poolmanager.PoolAble {
+		call := f.calls.Add(1)
+
+		f.mu.Lock()
+		hook := f.hook
+		f.mu.Unlock()
+
+		var instance poolmanager.PoolAble
+		handled := false
+		if hook != nil {
+			instance, handled = hook(call)
+		}
+		if !handled {
+			instance = f.factory()
+		}
+
+		f.mu.Lock()
+		f.instances = append(f.instances, instance)
+		f.mu.Unlock()
+		return instance
+	}
+}
+
+// OnCall memasang hook yang dipanggil sebelum factory asli. Jika hook mengembalikan true,
+// instance dari hook digunakan dan factory asli tidak dipanggil.
+// call: nomor urut pemanggilan, dimulai dari 1
+func (f *FactoryInterceptor) OnCall(hook func(call int64) (poolmanager.PoolAble, bool)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hook = hook
+}
+
+// Calls mengembalikan jumlah pemanggilan factory sejauh ini
+func (f *FactoryInterceptor) Calls() int64 {
+	return f.calls.Load()
+}
+
+// Instances mengembalikan salinan seluruh instance yang dibuat melalui factory
+func (f *FactoryInterceptor) Instances() []poolmanager.PoolAble {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]poolmanager.PoolAble(nil), f.instances...)
+}
@@ -0,0 +1,84 @@
+package poolmanagertest
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	poolmanager "github.com/hibbannn/pool-manager"
+)
+
+// Workload menjelaskan beban kerja acquire/release yang dijalankan oleh RunWorkload
+type Workload struct {
+	Goroutines  int                                             // Jumlah goroutine yang berjalan bersamaan (default 1)
+	Iterations  int                                             // Jumlah acquire per goroutine (default 1)
+	Hold        time.Duration                                   // Durasi instance dipegang sebelum dikembalikan
+	Use         func(worker int, instance poolmanager.PoolAble) // Dipanggil saat instance dipegang (opsional)
+	Options     []poolmanager.AcquireOption                     // Opsi yang diteruskan ke setiap AcquireInstance
+	SkipRelease func(worker, iteration int) bool                // Jika mengembalikan true, instance sengaja tidak dikembalikan
+}
+
+// WorkloadResult merangkum hasil RunWorkload
+type WorkloadResult struct {
+	Acquired int64         // Jumlah acquire yang berhasil
+	Released int64         // Jumlah release yang berhasil
+	Errors   []error       // Error dari acquire atau release
+	Elapsed  time.Duration // Durasi total beban kerja
+}
+
+// RunWorkload menjalankan beban kerja terhadap pool dan menunggu hingga seluruh goroutine selesai.
+func RunWorkload(pm *poolmanager.PoolManager, poolName string, w Workload) WorkloadResult {
+	goroutines := w.Goroutines
+	if goroutines <= 0 {
+		goroutines = 1
+	}
+	iterations := w.Iterations
+	if iterations <= 0 {
+		iterations = 1
+	}
+
+	var (
+		result WorkloadResult
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+	)
+	recordErr := func(err error) {
+		mu.Lock()
+		result.Errors = append(result.Errors, err)
+		mu.Unlock()
+	}
+
+	start := time.Now()
+	for worker := 0; worker < goroutines; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				instance, err := pm.AcquireInstance(poolName, w.Options...)
+				if err != nil {
+					recordErr(err)
+					continue
+				}
+				atomic.AddInt64(&result.Acquired, 1)
+
+				if w.Use != nil {
+					w.Use(worker, instance)
+				}
+				if w.Hold > 0 {
+					time.Sleep(w.Hold)
+				}
+				if w.SkipRelease != nil && w.SkipRelease(worker, i) {
+					continue
+				}
+				if err := pm.ReleaseInstance(poolName, instance); err != nil {
+					recordErr(err)
+					continue
+				}
+				atomic.AddInt64(&result.Released, 1)
+			}
+		}(worker)
+	}
+	wg.Wait()
+	result.Elapsed = time.Since(start)
+	return result
+}
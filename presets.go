@@ -0,0 +1,60 @@
+package poolmanager
+
+import (
+	"runtime"
+	"time"
+)
+
+// PresetHighThroughput mengembalikan builder untuk pool dengan lalu lintas tinggi dan banyak goroutine.
+// Pool di-shard sesuai jumlah CPU, auto-tuning diaktifkan, dan objek idle dipertahankan cukup lama
+// agar pembuatan objek ulang jarang terjadi. Nilai dapat diubah lebih lanjut melalui builder.
+func PresetHighThroughput(poolName string) *PoolConfigBuilder {
+	shards := runtime.NumCPU()
+	if shards < 2 {
+		shards = 2
+	}
+
+	return NewPoolConfiguration(poolName).
+		WithSizeLimit(1024).
+		WithMinSize(32).
+		WithMaxSize(1024).
+		WithInitialSize(64).
+		WithSharding(true, shards).
+		WithShardStrategy(&RoundRobinSharding{}).
+		WithAutoTune(true).
+		WithAutoTuneInterval(30 * time.Second).
+		WithAutoTuneFactor(1.5).
+		WithTTL(10 * time.Minute).
+		WithEvictionInterval(5 * time.Minute).
+		WithEvictionPolicy(&TTLEvictionPolicy{TTL: 10 * time.Minute})
+}
+
+// PresetLowMemory mengembalikan builder untuk lingkungan dengan memori terbatas.
+// Pool dimulai kosong, ukurannya kecil, objek idle cepat dieviksi, dan objek berukuran
+// lebih dari 1 MiB tidak pernah dikembalikan ke pool.
+func PresetLowMemory(poolName string) *PoolConfigBuilder {
+	return NewPoolConfiguration(poolName).
+		WithSizeLimit(16).
+		WithMinSize(0).
+		WithMaxSize(16).
+		WithInitialSize(0).
+		WithTTL(time.Minute).
+		WithEvictionInterval(30 * time.Second).
+		WithEvictionPolicy(&LRUEvictionPolicy{MaxIdleTime: time.Minute}).
+		WithMaxPooledObjectBytes(1 << 20)
+}
+
+// PresetConnectionPool mengembalikan builder untuk objek mahal dan stateful seperti koneksi.
+// Ukuran pool dibatasi, beberapa objek disiapkan di awal, objek yang lama idle dirotasi,
+// dan finalizer kebocoran dipasang karena koneksi yang bocor sangat mahal.
+func PresetConnectionPool(poolName string) *PoolConfigBuilder {
+	return NewPoolConfiguration(poolName).
+		WithSizeLimit(20).
+		WithMinSize(2).
+		WithMaxSize(20).
+		WithInitialSize(2).
+		WithTTL(30 * time.Minute).
+		WithEvictionInterval(time.Minute).
+		WithEvictionPolicy(&SmartEvictionPolicy{TTL: 30 * time.Minute, MaxIdleTime: 5 * time.Minute}).
+		WithLeakFinalizer(true)
+}
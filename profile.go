@@ -0,0 +1,160 @@
+package poolmanager
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"runtime/pprof"
+	"strconv"
+	"time"
+)
+
+// ProfileKind adalah jenis profil yang dapat diambil untuk sebuah pool
+type ProfileKind string
+
+const (
+	ProfileCPU       ProfileKind = "cpu"       // Profil CPU selama durasi pengambilan
+	ProfileGoroutine ProfileKind = "goroutine" // Profil goroutine di akhir durasi pengambilan
+)
+
+// Label pprof yang dipasang pada operasi pool selama profil diambil
+const (
+	ProfileLabelPool      = "pool"      // Nama pool yang dioperasikan
+	ProfileLabelOperation = "operation" // "acquire" atau "release"
+)
+
+// Batas durasi profil yang diminta melalui ProfileHandler
+const (
+	defaultProfileDuration = 5 * time.Second
+	maxProfileDuration     = time.Minute
+)
+
+// ProfilePool mengambil profil CPU atau goroutine berformat pprof selama duration dan menulisnya ke
+// w. Selama pengambilan, acquire dan release pool diberi label pprof ProfileLabelPool dan
+// ProfileLabelOperation sehingga sampel milik pool dapat dipisahkan, misalnya dengan
+// "go tool pprof -tagfocus=pool=<nama>". Profil CPU bersifat global untuk proses, sehingga hanya
+// satu profil CPU yang dapat diambil pada satu waktu. Pengambilan berhenti lebih awal jika ctx selesai.
+// Label pprof milik pemanggil tidak dipertahankan pada goroutine yang menjalankan operasi pool selama
+// pengambilan berlangsung.
+func (pm *PoolManager) ProfilePool(ctx context.Context, poolName string, kind ProfileKind, duration time.Duration, w io.Writer) error {
+	entry, err := pm.lookupEntry(poolName, "profile")
+	if err != nil {
+		return err
+	}
+	if duration <= 0 {
+		return NewPoolError(poolName, "profile", errors.New("profile duration must be positive"))
+	}
+	if kind != ProfileCPU && kind != ProfileGoroutine {
+		return NewPoolError(poolName, "profile", fmt.Errorf("unknown profile kind %q", kind))
+	}
+
+	if kind == ProfileCPU {
+		if err := pprof.StartCPUProfile(w); err != nil {
+			return NewPoolError(poolName, "profile", err)
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	entry.state.profiling.Add(1)
+	defer entry.state.profiling.Add(-1)
+	pm.logPoolf(InfoLevel, poolName, "Capturing %s profile for pool: %s, Duration: %s", kind, poolName, duration)
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		if kind == ProfileGoroutine {
+			return NewPoolError(poolName, "profile", ctx.Err())
+		}
+	}
+
+	if kind == ProfileGoroutine {
+		if err := pprof.Lookup("goroutine").WriteTo(w, 0); err != nil {
+			return NewPoolError(poolName, "profile", err)
+		}
+	}
+	return nil
+}
+
+// ProfilePoolToFile mengambil profil seperti ProfilePool dan menyimpannya ke path
+func (pm *PoolManager) ProfilePoolToFile(ctx context.Context, poolName string, kind ProfileKind, duration time.Duration, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return NewPoolError(poolName, "profile", err)
+	}
+	if err := pm.ProfilePool(ctx, poolName, kind, duration, file); err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return NewPoolError(poolName, "profile", err)
+	}
+	return nil
+}
+
+// ProfileHandler mengembalikan http.Handler untuk endpoint admin yang mengambil profil pool, misalnya
+// GET /debug/pool-profile?pool=conn&kind=cpu&seconds=10. kind bawaan adalah cpu dan seconds bawaan
+// adalah 5 (maksimal 60). Endpoint ini sebaiknya hanya dipasang pada listener admin internal.
+func (pm *PoolManager) ProfileHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		poolName := query.Get("pool")
+		if !pm.HasPool(poolName) {
+			http.Error(w, ErrPoolDoesNotExist+poolName, http.StatusNotFound)
+			return
+		}
+		kind := ProfileKind(query.Get("kind"))
+		if kind == "" {
+			kind = ProfileCPU
+		}
+		duration := defaultProfileDuration
+		if seconds := query.Get("seconds"); seconds != "" {
+			n, err := strconv.Atoi(seconds)
+			if err != nil || n <= 0 {
+				http.Error(w, "invalid seconds: "+seconds, http.StatusBadRequest)
+				return
+			}
+			duration = time.Duration(n) * time.Second
+		}
+		if duration > maxProfileDuration {
+			duration = maxProfileDuration
+		}
+
+		// Profil ditampung terlebih dahulu agar error tetap dapat dilaporkan sebagai status HTTP
+		var buf bytes.Buffer
+		if err := pm.ProfilePool(r.Context(), poolName, kind, duration, &buf); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", poolName+"-"+string(kind)+".pprof"))
+		_, _ = buf.WriteTo(w)
+	})
+}
+
+// profileLabels membentuk label pprof untuk operasi pool
+func profileLabels(poolName, operation string) pprof.LabelSet {
+	return pprof.Labels(ProfileLabelPool, poolName, ProfileLabelOperation, operation)
+}
+
+// acquireProfiled menjalankan acquire dengan label pprof selama profil pool diambil
+func (pm *PoolManager) acquireProfiled(entry *poolEntry, opts []AcquireOption) (instance PoolAble, err error) {
+	pprof.Do(context.Background(), profileLabels(entry.name, "acquire"), func(context.Context) {
+		instance, err = pm.acquireEntry(entry, opts...)
+	})
+	return instance, err
+}
+
+// releaseProfiled menjalankan release dengan label pprof selama profil pool diambil
+func (pm *PoolManager) releaseProfiled(entry *poolEntry, instance PoolAble) (err error) {
+	pprof.Do(context.Background(), profileLabels(entry.name, "release"), func(context.Context) {
+		err = pm.releaseEntry(entry, instance)
+	})
+	return err
+}
@@ -0,0 +1,131 @@
+package poolmanager
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PrometheusSink mengumpulkan metrik di memori dan menyajikannya dalam format teks eksposisi
+// Prometheus. PrometheusSink mengimplementasikan http.Handler sehingga dapat dipasang langsung
+// pada endpoint /metrics tanpa dependensi client Prometheus.
+type PrometheusSink struct {
+	store     InMemorySink
+	namespace string // Prefiks nama metrik, misalnya "myapp" menghasilkan "myapp_pool_in_use"
+}
+
+// NewPrometheusSink membuat PrometheusSink. namespace boleh kosong.
+func NewPrometheusSink(namespace string) *PrometheusSink {
+	return &PrometheusSink{store: InMemorySink{series: make(map[string]*sinkSeries)}, namespace: namespace}
+}
+
+// AddCounter menambah counter
+func (p *PrometheusSink) AddCounter(name string, labels map[string]string, delta int64) {
+	p.store.AddCounter(name, labels, delta)
+}
+
+// SetGauge menetapkan nilai gauge
+func (p *PrometheusSink) SetGauge(name string, labels map[string]string, value float64) {
+	p.store.SetGauge(name, labels, value)
+}
+
+// ObserveHistogram mencatat satu observasi histogram
+func (p *PrometheusSink) ObserveHistogram(name string, labels map[string]string, value float64) {
+	p.store.ObserveHistogram(name, labels, value)
+}
+
+// ServeHTTP menulis seluruh metrik dalam format teks eksposisi Prometheus
+func (p *PrometheusSink) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = p.WriteTo(w)
+}
+
+// WriteTo menulis seluruh metrik dalam format teks eksposisi Prometheus ke w
+func (p *PrometheusSink) WriteTo(w io.Writer) (int64, error) {
+	counter := &countingWriter{w: w}
+	buf := bufio.NewWriter(counter)
+
+	lastName := ""
+	for _, series := range p.store.snapshot() {
+		name := p.metricName(series.name)
+		if name != lastName {
+			buf.WriteString("# TYPE " + name + " " + series.kind + "\n")
+			lastName = name
+		}
+		if series.kind != "histogram" {
+			buf.WriteString(name + promLabels(series.labels, "", "") + " " + formatPromFloat(series.value) + "\n")
+			continue
+		}
+
+		// Bucket Prometheus bersifat kumulatif
+		var cumulative int64
+		for i, bound := range sinkBucketBounds(series.name) {
+			cumulative += series.histogram.Buckets[i]
+			buf.WriteString(name + "_bucket" + promLabels(series.labels, "le", formatPromFloat(bound)) + " " +
+				strconv.FormatInt(cumulative, 10) + "\n")
+		}
+		buf.WriteString(name + "_bucket" + promLabels(series.labels, "le", "+Inf") + " " +
+			strconv.FormatInt(series.histogram.Count, 10) + "\n")
+		buf.WriteString(name + "_sum" + promLabels(series.labels, "", "") + " " + formatPromFloat(series.histogram.Sum) + "\n")
+		buf.WriteString(name + "_count" + promLabels(series.labels, "", "") + " " +
+			strconv.FormatInt(series.histogram.Count, 10) + "\n")
+	}
+
+	err := buf.Flush()
+	return counter.n, err
+}
+
+// metricName menambahkan namespace pada nama metrik
+func (p *PrometheusSink) metricName(name string) string {
+	if p.namespace == "" {
+		return name
+	}
+	return p.namespace + "_" + name
+}
+
+// promLabels memformat label sebagai {k="v",...} dengan urutan kunci yang stabil.
+// extraKey/extraValue ditambahkan di akhir jika extraKey tidak kosong (digunakan untuk "le").
+func promLabels(labels map[string]string, extraKey, extraValue string) string {
+	if len(labels) == 0 && extraKey == "" {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		pairs = append(pairs, key+`="`+escapeLabelValue(labels[key])+`"`)
+	}
+	if extraKey != "" {
+		pairs = append(pairs, extraKey+`="`+extraValue+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// escapeLabelValue meng-escape nilai label untuk format teks Prometheus
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// formatPromFloat memformat angka dengan representasi terpendek
+func formatPromFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// countingWriter menghitung jumlah byte yang ditulis untuk nilai kembali WriteTo
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
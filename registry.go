@@ -0,0 +1,55 @@
+package poolmanager
+
+import "sync"
+
+var (
+	defaultManager     *PoolManager // PoolManager tingkat package yang dibagi oleh seluruh aplikasi
+	defaultManagerOnce sync.Once    // Memastikan default manager hanya dibuat sekali
+	defaultManagerMu   sync.RWMutex // Melindungi penggantian default manager melalui SetDefault
+)
+
+// Default mengembalikan PoolManager tingkat package, membuatnya saat pertama kali dibutuhkan.
+// Default manager memungkinkan aplikasi kecil dan library berbagi pool tanpa harus
+// meneruskan instance PoolManager ke mana-mana.
+func Default() *PoolManager {
+	defaultManagerOnce.Do(func() {
+		defaultManagerMu.Lock()
+		if defaultManager == nil {
+			defaultManager = NewPoolManager(PoolConfiguration{})
+		}
+		defaultManagerMu.Unlock()
+	})
+
+	defaultManagerMu.RLock()
+	defer defaultManagerMu.RUnlock()
+	return defaultManager
+}
+
+// SetDefault mengganti PoolManager tingkat package, misalnya dengan manager yang
+// sudah dikonfigurasi monitoring atau logger-nya. Pool yang terdaftar pada manager
+// sebelumnya tidak dipindahkan.
+func SetDefault(pm *PoolManager) {
+	defaultManagerOnce.Do(func() {})
+	defaultManagerMu.Lock()
+	defaultManager = pm
+	defaultManagerMu.Unlock()
+}
+
+// Register mendaftarkan pool baru pada default manager.
+// name: nama pool yang didaftarkan
+// factory: fungsi untuk membuat objek baru dalam pool
+// config: konfigurasi pool
+func Register(name string, factory func() PoolAble, config PoolConfiguration) error {
+	_, err := Default().AddPool(name, factory, config)
+	return err
+}
+
+// Acquire mengambil instance dari pool pada default manager.
+func Acquire(name string, opts ...AcquireOption) (PoolAble, error) {
+	return Default().AcquireInstance(name, opts...)
+}
+
+// Release mengembalikan instance ke pool pada default manager.
+func Release(name string, instance PoolAble) error {
+	return Default().ReleaseInstance(name, instance)
+}
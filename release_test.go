@@ -0,0 +1,184 @@
+package poolmanager
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// waitInUse menunggu hingga jumlah instance yang dipinjam dari pool sama dengan n
+func waitInUse(t *testing.T, pm *PoolManager, poolName string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, inUse := pm.poolSize(poolName); inUse == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			_, inUse := pm.poolSize(poolName)
+			t.Fatalf("in use = %d, want %d", inUse, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSettleRelease(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []PoolOption
+		// prepare mengembalikan instance yang akan dikembalikan ke pool
+		prepare func(t *testing.T, pm *PoolManager) PoolAble
+		want    error
+	}{
+		{
+			name: "double release",
+			prepare: func(t *testing.T, pm *PoolManager) PoolAble {
+				instance, err := pm.AcquireInstance("settled")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := pm.ReleaseInstance("settled", instance); err != nil {
+					t.Fatal(err)
+				}
+				return instance
+			},
+			want: ErrDoubleRelease,
+		},
+		{
+			name:    "foreign instance",
+			prepare: func(*testing.T, *PoolManager) PoolAble { return &tuneTestObject{} },
+			want:    ErrForeignInstance,
+		},
+		{
+			name: "release after reclaim",
+			prepare: func(t *testing.T, pm *PoolManager) PoolAble {
+				ctx, cancel := context.WithCancel(context.Background())
+				instance, err := pm.AcquireBound(ctx, "settled")
+				if err != nil {
+					t.Fatal(err)
+				}
+				cancel()
+				waitInUse(t, pm, "settled", 0)
+				return instance
+			},
+			want: errReclaimedRelease,
+		},
+		{
+			// Cache menyerahkan instance yang sama ke beberapa peminjam, jadi verifyUntracked dilewati
+			name: "caching skips verification",
+			opts: []PoolOption{WithCaching(4)},
+			prepare: func(t *testing.T, pm *PoolManager) PoolAble {
+				instance, err := pm.AcquireInstance("settled")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := pm.ReleaseInstance("settled", instance); err != nil {
+					t.Fatal(err)
+				}
+				return instance
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newSilentManager(t)
+			if _, err := pm.AddPoolWithOptions("settled", func() PoolAble { return &tuneTestObject{} }, tt.opts...); err != nil {
+				t.Fatal(err)
+			}
+			instance := tt.prepare(t, pm)
+			conf, err := pm.getPoolConfiguration("settled")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = pm.settleRelease("settled", conf, instance)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("settleRelease returned %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("settleRelease returned %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseRejectsUnsettledInstance(t *testing.T) {
+	tests := []struct {
+		name    string
+		discard bool // Gunakan DiscardInstance alih-alih ReleaseInstance
+		foreign bool // Instance yang dikembalikan tidak pernah dipinjam dari pool
+		want    error
+		other   error // Sentinel yang tidak boleh ikut terbungkus
+	}{
+		{name: "release twice", want: ErrDoubleRelease, other: ErrForeignInstance},
+		{name: "discard twice", discard: true, want: ErrDoubleRelease, other: ErrForeignInstance},
+		{name: "release foreign", foreign: true, want: ErrForeignInstance, other: ErrDoubleRelease},
+		{name: "discard foreign", discard: true, foreign: true, want: ErrForeignInstance, other: ErrDoubleRelease},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pm := newSilentManager(t)
+			if _, err := pm.AddPoolWithOptions("checked", func() PoolAble { return &tuneTestObject{} }); err != nil {
+				t.Fatal(err)
+			}
+			instance, err := pm.AcquireInstance("checked")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := pm.ReleaseInstance("checked", instance); err != nil {
+				t.Fatal(err)
+			}
+			if tt.foreign {
+				instance = &tuneTestObject{}
+			}
+			idleBefore, _ := pm.poolSize("checked")
+
+			if tt.discard {
+				err = pm.DiscardInstance("checked", instance)
+			} else {
+				err = pm.ReleaseInstance("checked", instance)
+			}
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("returned %v, want %v", err, tt.want)
+			}
+			if errors.Is(err, tt.other) || errors.Is(err, ErrNotCheckedOut) {
+				t.Fatalf("%v wraps more than %v", err, tt.want)
+			}
+			if strings.Contains(err.Error(), ErrNotCheckedOut.Error()) {
+				t.Fatalf("error %q mentions %q", err, ErrNotCheckedOut)
+			}
+			if idle, inUse := pm.poolSize("checked"); idle != idleBefore || inUse != 0 {
+				t.Fatalf("idle=%d inUse=%d after rejected release, want idle=%d inUse=0", idle, inUse, idleBefore)
+			}
+		})
+	}
+}
+
+func TestLateReleaseAfterReclaimIsIgnored(t *testing.T) {
+	pm := newSilentManager(t)
+	if _, err := pm.AddPoolWithOptions("bound", func() PoolAble { return &tuneTestObject{} }); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	instance, err := pm.AcquireBound(ctx, "bound")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	waitInUse(t, pm, "bound", 0)
+	idleBefore, _ := pm.poolSize("bound")
+
+	if err := pm.ReleaseInstance("bound", instance); err != nil {
+		t.Fatalf("late release returned %v, want nil", err)
+	}
+	if idle, inUse := pm.poolSize("bound"); idle != idleBefore || inUse != 0 {
+		t.Fatalf("idle=%d inUse=%d after late release, want idle=%d inUse=0", idle, inUse, idleBefore)
+	}
+}
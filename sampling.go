@@ -0,0 +1,55 @@
+package poolmanager
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// telemetrySampler menentukan apakah sebuah operasi frekuensi tinggi (acquire/release) perlu
+// dicatat ke log, event, dan histogram. Sampler mendukung dua mode yang dapat digabungkan:
+// 1-in-N (SampleEvery) dan batas jumlah sampel per detik (MaxSamplesPerSecond).
+type telemetrySampler struct {
+	counter     atomic.Uint64 // Jumlah operasi yang sudah dievaluasi
+	windowStart atomic.Int64  // Awal jendela satu detik dalam UnixNano
+	windowCount atomic.Int64  // Jumlah sampel yang diambil dalam jendela saat ini
+}
+
+// sample mengembalikan true jika operasi saat ini termasuk sampel.
+// every: ambil satu dari setiap N operasi (0 atau 1 berarti semua operasi)
+// perSecond: batas maksimum sampel per detik (0 berarti tanpa batas)
+func (s *telemetrySampler) sample(every, perSecond int) bool {
+	if every > 1 && s.counter.Add(1)%uint64(every) != 0 {
+		return false
+	}
+	if perSecond <= 0 {
+		return true
+	}
+
+	now := time.Now().UnixNano()
+	start := s.windowStart.Load()
+	if now-start >= int64(time.Second) && s.windowStart.CompareAndSwap(start, now) {
+		s.windowCount.Store(0)
+	}
+	return s.windowCount.Add(1) <= int64(perSecond)
+}
+
+// shouldSample memeriksa apakah operasi frekuensi tinggi saat ini perlu diobservasi
+// sesuai konfigurasi sampling pada MonitoringConfig.
+func (pm *PoolManager) shouldSample(monitoring *MonitoringConfig) bool {
+	return pm.sampler.sample(monitoring.SampleEvery, monitoring.MaxSamplesPerSecond)
+}
+
+// observeOperation mencatat trace dan memicu event untuk operasi acquire/release jika operasi
+// tersebut terpilih sebagai sampel. Sampler tidak dievaluasi sama sekali jika tidak ada
+// tracing maupun OnEvent yang aktif.
+func (pm *PoolManager) observeOperation(event PoolEvent, format string, args ...interface{}) {
+	monitoring := pm.monitoring()
+	if !monitoring.TraceOperations && monitoring.OnEvent == nil {
+		return
+	}
+	if !pm.shouldSample(monitoring) {
+		return
+	}
+	pm.traceTo(monitoring, format, args...)
+	pm.emitEvent(monitoring, event)
+}
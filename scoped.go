@@ -0,0 +1,20 @@
+package poolmanager
+
+import "errors"
+
+// WithInstance meminjam instance dari pool, menjalankan fn, lalu selalu mengembalikan instance ke
+// pool, termasuk saat fn mengembalikan error atau panic. Panic diteruskan kembali setelah instance
+// dikembalikan. Error dari fn digabungkan dengan error ReleaseInstance, jika ada, sehingga keduanya
+// dapat diperiksa dengan errors.Is.
+func (pm *PoolManager) WithInstance(poolName string, fn func(obj PoolAble) error, opts ...AcquireOption) (err error) {
+	instance, err := pm.AcquireInstance(poolName, opts...)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if releaseErr := pm.ReleaseInstance(poolName, instance); releaseErr != nil {
+			err = errors.Join(err, releaseErr)
+		}
+	}()
+	return fn(instance)
+}
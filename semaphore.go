@@ -0,0 +1,204 @@
+package poolmanager
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// semaphoreWaiter adalah satu pemanggil yang menunggu unit semaphore tersedia
+type semaphoreWaiter struct {
+	weight   int64         // Jumlah unit yang diminta
+	priority int           // Prioritas pemanggil, semakin besar semakin didahulukan
+	ready    chan struct{} // Ditutup saat unit sudah diberikan kepada pemanggil atau acquire digagalkan
+	err      error         // Alasan acquire digagalkan sebelum ready ditutup (nil jika unit diberikan)
+}
+
+// weightedSemaphore membatasi jumlah unit in-use sebuah pool. Setiap acquire dapat meminta
+// lebih dari satu unit. Pemanggil yang menunggu dilayani berdasarkan prioritas, lalu FIFO
+// untuk prioritas yang sama; pemanggil di depan antrean tidak dapat dilewati oleh pemanggil
+// berbobot kecil sehingga permintaan besar tidak kelaparan.
+type weightedSemaphore struct {
+	mu         sync.Mutex
+	size       int64              // Kapasitas total dalam unit
+	cur        int64              // Unit yang sedang dipakai
+	maxWaiters int                // Batas jumlah waiter (0 berarti tanpa batas)
+	waiters    []*semaphoreWaiter // Antrean menunggu, terurut berdasarkan prioritas lalu waktu datang
+
+	saturatedSince time.Time // Waktu antrean waiter terakhir berubah dari kosong menjadi tidak kosong
+	advised        bool      // Apakah advisory saturasi sudah dikirim untuk episode saat ini
+}
+
+// newWeightedSemaphore membuat semaphore dengan kapasitas size unit
+func newWeightedSemaphore(size int64) *weightedSemaphore {
+	return &weightedSemaphore{size: size}
+}
+
+// Acquire mengambil weight unit, menunggu hingga tersedia atau ctx selesai.
+// Mengembalikan ErrWeightExceedsCapacity jika weight melebihi kapasitas semaphore, atau
+// ErrTooManyWaiters jika antrean sudah mencapai maxWaiters.
+func (s *weightedSemaphore) Acquire(ctx context.Context, weight int64, priority int) error {
+	s.mu.Lock()
+	if weight > s.size {
+		s.mu.Unlock()
+		return ErrWeightExceedsCapacity
+	}
+	if len(s.waiters) == 0 && s.size-s.cur >= weight {
+		s.cur += weight
+		s.mu.Unlock()
+		return nil
+	}
+	if s.maxWaiters > 0 && len(s.waiters) >= s.maxWaiters {
+		s.mu.Unlock()
+		return ErrTooManyWaiters
+	}
+
+	waiter := &semaphoreWaiter{weight: weight, priority: priority, ready: make(chan struct{})}
+	s.enqueue(waiter)
+	s.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return waiter.err
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-waiter.ready:
+			// Unit diberikan (atau acquire digagalkan oleh Resize) bersamaan dengan pembatalan
+			s.mu.Unlock()
+			return waiter.err
+		default:
+		}
+		front := len(s.waiters) > 0 && s.waiters[0] == waiter
+		s.remove(waiter)
+		if front {
+			// Pemanggil di belakang mungkin sekarang dapat dilayani
+			s.notifyWaiters()
+		}
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// TryAcquire mengambil weight unit tanpa menunggu. Mengembalikan false jika unit tidak tersedia.
+func (s *weightedSemaphore) TryAcquire(weight int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.waiters) == 0 && s.size-s.cur >= weight {
+		s.cur += weight
+		return true
+	}
+	return false
+}
+
+// Release mengembalikan weight unit dan membangunkan pemanggil yang menunggu.
+func (s *weightedSemaphore) Release(weight int64) {
+	s.mu.Lock()
+	s.cur -= weight
+	if s.cur < 0 {
+		s.cur = 0
+	}
+	s.notifyWaiters()
+	s.mu.Unlock()
+}
+
+// Resize mengubah kapasitas dan batas waiter semaphore. Unit yang sedang dipakai tetap tercatat;
+// jika kapasitas mengecil, acquire baru menunggu hingga pemakaian turun di bawah kapasitas baru.
+// Waiter yang bobotnya melebihi kapasitas baru tidak akan pernah dilayani, sehingga digagalkan
+// dengan ErrWeightExceedsCapacity agar tidak menahan antrean di belakangnya.
+func (s *weightedSemaphore) Resize(size int64, maxWaiters int) {
+	s.mu.Lock()
+	s.size = size
+	s.maxWaiters = maxWaiters
+	kept := s.waiters[:0]
+	for _, waiter := range s.waiters {
+		if waiter.weight > size {
+			waiter.err = ErrWeightExceedsCapacity
+			close(waiter.ready)
+			continue
+		}
+		kept = append(kept, waiter)
+	}
+	clear(s.waiters[len(kept):])
+	s.waiters = kept
+	s.notifyWaiters()
+	s.mu.Unlock()
+}
+
+// InUse mengembalikan jumlah unit yang sedang dipakai
+func (s *weightedSemaphore) InUse() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cur
+}
+
+// Waiting mengembalikan jumlah pemanggil yang sedang menunggu
+func (s *weightedSemaphore) Waiting() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.waiters)
+}
+
+// saturation mengembalikan lama antrean waiter tidak kosong beserta jumlah waiter saat ini
+func (s *weightedSemaphore) saturation(now time.Time) (time.Duration, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.waiters) == 0 {
+		return 0, 0
+	}
+	return now.Sub(s.saturatedSince), len(s.waiters)
+}
+
+// markAdvised menandai episode saturasi saat ini sudah dilaporkan. Mengembalikan false jika
+// advisory untuk episode ini sudah pernah dikirim.
+func (s *weightedSemaphore) markAdvised() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.advised {
+		return false
+	}
+	s.advised = true
+	return true
+}
+
+// enqueue menyisipkan waiter setelah seluruh waiter dengan prioritas yang sama atau lebih tinggi.
+// Dipanggil dengan s.mu terkunci.
+func (s *weightedSemaphore) enqueue(waiter *semaphoreWaiter) {
+	if len(s.waiters) == 0 {
+		s.saturatedSince = time.Now()
+		s.advised = false
+	}
+	i := len(s.waiters)
+	for i > 0 && s.waiters[i-1].priority < waiter.priority {
+		i--
+	}
+	s.waiters = append(s.waiters, nil)
+	copy(s.waiters[i+1:], s.waiters[i:])
+	s.waiters[i] = waiter
+}
+
+// remove menghapus waiter dari antrean. Dipanggil dengan s.mu terkunci.
+func (s *weightedSemaphore) remove(waiter *semaphoreWaiter) {
+	for i, w := range s.waiters {
+		if w == waiter {
+			s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// notifyWaiters memberikan unit kepada waiter di depan antrean selama kapasitas mencukupi.
+// Dipanggil dengan s.mu terkunci.
+func (s *weightedSemaphore) notifyWaiters() {
+	for len(s.waiters) > 0 {
+		next := s.waiters[0]
+		if s.size-s.cur < next.weight {
+			// Jangan lewati waiter di depan agar permintaan berbobot besar tidak kelaparan
+			return
+		}
+		s.cur += next.weight
+		s.waiters[0] = nil
+		s.waiters = s.waiters[1:]
+		close(next.ready)
+	}
+}
@@ -0,0 +1,286 @@
+package poolmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// semaphoreCall adalah satu pemanggil Acquire dalam test semaphore
+type semaphoreCall struct {
+	name     string
+	weight   int64
+	priority int
+}
+
+// startAcquire menjalankan Acquire di goroutine terpisah lalu menunggu hingga pemanggil masuk antrean.
+// Nama pemanggil dikirim ke served jika unit diberikan; error dikirim ke channel yang dikembalikan.
+func startAcquire(t *testing.T, s *weightedSemaphore, ctx context.Context, call semaphoreCall, served chan<- string) <-chan error {
+	t.Helper()
+	queued := s.Waiting()
+	done := make(chan error, 1)
+	go func() {
+		err := s.Acquire(ctx, call.weight, call.priority)
+		if err == nil && served != nil {
+			served <- call.name
+		}
+		done <- err
+	}()
+	waitWaiting(t, s, queued+1)
+	return done
+}
+
+// waitWaiting menunggu hingga jumlah waiter semaphore sama dengan n
+func waitWaiting(t *testing.T, s *weightedSemaphore, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for s.Waiting() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("waiters = %d, want %d", s.Waiting(), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// expectPending memastikan belum ada hasil dari pemanggil yang masih harus menunggu
+func expectPending(t *testing.T, done <-chan error, name string) {
+	t.Helper()
+	select {
+	case err := <-done:
+		t.Fatalf("%s returned %v, want it to keep waiting", name, err)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestWeightedSemaphoreOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		calls []semaphoreCall
+		want  []string
+	}{
+		{
+			name:  "fifo for equal priority",
+			calls: []semaphoreCall{{name: "a"}, {name: "b"}, {name: "c"}},
+			want:  []string{"a", "b", "c"},
+		},
+		{
+			name:  "higher priority first",
+			calls: []semaphoreCall{{name: "low", priority: 0}, {name: "high", priority: 5}, {name: "mid", priority: 2}},
+			want:  []string{"high", "mid", "low"},
+		},
+		{
+			name:  "fifo within a priority",
+			calls: []semaphoreCall{{name: "a", priority: 1}, {name: "low"}, {name: "b", priority: 1}, {name: "c", priority: 1}},
+			want:  []string{"a", "b", "c", "low"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newWeightedSemaphore(1)
+			if !s.TryAcquire(1) {
+				t.Fatal("TryAcquire on an empty semaphore failed")
+			}
+			served := make(chan string, len(tt.calls))
+			for _, call := range tt.calls {
+				call.weight = 1
+				startAcquire(t, s, context.Background(), call, served)
+			}
+
+			var got []string
+			for range tt.calls {
+				s.Release(1)
+				select {
+				case name := <-served:
+					got = append(got, name)
+				case <-time.After(time.Second):
+					t.Fatalf("no waiter served after release, served so far %v", got)
+				}
+			}
+			for i := range tt.want {
+				if i >= len(got) || got[i] != tt.want[i] {
+					t.Fatalf("served order = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWeightedSemaphoreHeadOfLineNotBypassed(t *testing.T) {
+	s := newWeightedSemaphore(2)
+	s.TryAcquire(2)
+	served := make(chan string, 2)
+	big := startAcquire(t, s, context.Background(), semaphoreCall{name: "big", weight: 2}, served)
+	small := startAcquire(t, s, context.Background(), semaphoreCall{name: "small", weight: 1}, served)
+
+	s.Release(1)
+	expectPending(t, small, "small")
+	if s.TryAcquire(1) {
+		t.Fatal("TryAcquire bypassed queued waiters")
+	}
+
+	s.Release(1)
+	if err := <-big; err != nil {
+		t.Fatalf("big acquire: %v", err)
+	}
+	expectPending(t, small, "small")
+	s.Release(2)
+	if err := <-small; err != nil {
+		t.Fatalf("small acquire: %v", err)
+	}
+}
+
+func TestWeightedSemaphoreCancellation(t *testing.T) {
+	tests := []struct {
+		name          string
+		cancel        int      // Indeks waiter yang dibatalkan
+		weights       []int64  // Bobot setiap waiter sesuai urutan antrean
+		releaseBefore int64    // Unit yang dilepas sebelum pembatalan
+		releaseAfter  int64    // Unit yang dilepas setelah pembatalan
+		wantDone      []string // Waiter yang harus dilayani setelah pembatalan
+	}{
+		// Pembatalan waiter depan yang terlalu besar membuka jalan bagi waiter di belakangnya
+		{name: "front waiter", cancel: 0, weights: []int64{2, 1}, releaseBefore: 1, wantDone: []string{"1"}},
+		{name: "middle waiter", cancel: 1, weights: []int64{1, 1, 1}, releaseAfter: 2, wantDone: []string{"0", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newWeightedSemaphore(2)
+			s.TryAcquire(2)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			served := make(chan string, len(tt.weights))
+			results := make([]<-chan error, len(tt.weights))
+			for i, weight := range tt.weights {
+				callCtx := context.Background()
+				if i == tt.cancel {
+					callCtx = ctx
+				}
+				results[i] = startAcquire(t, s, callCtx, semaphoreCall{name: string(rune('0' + i)), weight: weight}, served)
+			}
+
+			if tt.releaseBefore > 0 {
+				s.Release(tt.releaseBefore)
+			}
+			cancel()
+			if err := <-results[tt.cancel]; !errors.Is(err, context.Canceled) {
+				t.Fatalf("cancelled acquire returned %v, want context.Canceled", err)
+			}
+			if tt.releaseAfter > 0 {
+				s.Release(tt.releaseAfter)
+			}
+
+			got := map[string]bool{}
+			for range tt.wantDone {
+				select {
+				case name := <-served:
+					got[name] = true
+				case <-time.After(time.Second):
+					t.Fatalf("served %v, want %v", got, tt.wantDone)
+				}
+			}
+			for _, name := range tt.wantDone {
+				if !got[name] {
+					t.Fatalf("served %v, want %v", got, tt.wantDone)
+				}
+			}
+			if waiting := s.Waiting(); waiting != 0 {
+				t.Fatalf("waiters after cancellation = %d, want 0", waiting)
+			}
+		})
+	}
+}
+
+func TestWeightedSemaphoreResizeFailsOversizedWaiters(t *testing.T) {
+	tests := []struct {
+		name      string
+		weights   []int64 // Bobot setiap waiter sesuai urutan antrean
+		newSize   int64
+		wantFail  []bool // Waiter yang harus gagal dengan ErrWeightExceedsCapacity
+		wantQueue int    // Jumlah waiter yang masih menunggu setelah Resize
+	}{
+		{name: "front waiter no longer fits", weights: []int64{3, 1}, newSize: 2, wantFail: []bool{true, false}, wantQueue: 1},
+		{name: "all waiters still fit", weights: []int64{2, 1}, newSize: 2, wantFail: []bool{false, false}, wantQueue: 2},
+		{name: "every waiter too large", weights: []int64{4, 3}, newSize: 2, wantFail: []bool{true, true}, wantQueue: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newWeightedSemaphore(4)
+			s.TryAcquire(4)
+			results := make([]<-chan error, len(tt.weights))
+			for i, weight := range tt.weights {
+				results[i] = startAcquire(t, s, context.Background(), semaphoreCall{weight: weight}, nil)
+			}
+
+			s.Resize(tt.newSize, 0)
+			for i, fail := range tt.wantFail {
+				if !fail {
+					continue
+				}
+				select {
+				case err := <-results[i]:
+					if !errors.Is(err, ErrWeightExceedsCapacity) {
+						t.Fatalf("waiter %d returned %v, want ErrWeightExceedsCapacity", i, err)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("waiter %d still waiting after Resize", i)
+				}
+			}
+			if waiting := s.Waiting(); waiting != tt.wantQueue {
+				t.Fatalf("waiters after Resize = %d, want %d", waiting, tt.wantQueue)
+			}
+
+			// Waiter yang masih muat dilayani setelah pemakaian turun di bawah kapasitas baru
+			s.Release(4)
+			for i, fail := range tt.wantFail {
+				if fail {
+					continue
+				}
+				select {
+				case err := <-results[i]:
+					if err != nil {
+						t.Fatalf("waiter %d returned %v, want nil", i, err)
+					}
+				case <-time.After(time.Second):
+					t.Fatalf("waiter %d not served after release", i)
+				}
+				s.Release(tt.weights[i])
+			}
+		})
+	}
+}
+
+func TestWeightedSemaphoreRejections(t *testing.T) {
+	tests := []struct {
+		name       string
+		size       int64
+		maxWaiters int
+		queued     int // Waiter yang sudah mengantre sebelum Acquire
+		weight     int64
+		want       error
+	}{
+		{name: "weight above capacity", size: 2, weight: 3, want: ErrWeightExceedsCapacity},
+		{name: "waiter limit reached", size: 1, maxWaiters: 1, queued: 1, weight: 1, want: ErrTooManyWaiters},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newWeightedSemaphore(tt.size)
+			s.Resize(tt.size, tt.maxWaiters)
+			s.TryAcquire(tt.size)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			for i := 0; i < tt.queued; i++ {
+				startAcquire(t, s, ctx, semaphoreCall{weight: 1}, nil)
+			}
+
+			if err := s.Acquire(context.Background(), tt.weight, 0); !errors.Is(err, tt.want) {
+				t.Fatalf("Acquire returned %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
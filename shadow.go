@@ -0,0 +1,181 @@
+package poolmanager
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// shadowLink menghubungkan pool utama dengan pool cadangan (shadow) untuk failover
+type shadowLink struct {
+	name       string      // Nama pool shadow
+	warmSize   int         // Jumlah objek idle yang dijaga di shadow sebelum failover
+	active     atomic.Bool // Trafik pool utama sedang dialihkan ke shadow
+	rebuilding atomic.Bool // Pool utama sedang dibangun ulang di latar belakang
+}
+
+// FailoverStatus adalah keadaan failover sebuah pool utama
+type FailoverStatus struct {
+	Shadow     string // Nama pool shadow
+	Active     bool   // Trafik sedang dilayani oleh shadow
+	Rebuilding bool   // Pool utama sedang dibangun ulang
+}
+
+// AddShadowPool mendaftarkan pool shadow yang dijaga tetap hangat dengan factory dan konfigurasi
+// pool utama, namun hanya berisi warmSize objek idle (minimal 1) tanpa auto-tuning. Shadow
+// menerima trafik pool utama setelah Failover.
+func (pm *PoolManager) AddShadowPool(primary, shadow string, warmSize int) (*PoolRef, error) {
+	entry, err := pm.lookupEntry(primary, "shadow")
+	if err != nil {
+		return nil, err
+	}
+	if primary == shadow {
+		return nil, NewPoolError(primary, "shadow", errors.New("shadow pool must have a different name"))
+	}
+	if entry.adopted != nil {
+		return nil, NewPoolError(primary, "shadow", errors.New("adopted pools cannot have a shadow pool"))
+	}
+	if warmSize < 1 {
+		warmSize = 1
+	}
+
+	config := entry.configuration()
+	config.InitialSize, config.MinSize = warmSize, warmSize
+	if config.MaxSize < warmSize {
+		config.MaxSize = warmSize
+	}
+	config.AutoTune = false
+	ref, err := pm.AddPool(shadow, entry.factory, config)
+	if err != nil {
+		return nil, err
+	}
+	entry.shadow.Store(&shadowLink{name: shadow, warmSize: warmSize})
+	pm.logPoolf(InfoLevel, primary, "Shadow pool %s registered for pool: %s, warm size: %d", shadow, primary, warmSize)
+	return ref, nil
+}
+
+// Failover mengalihkan seluruh acquire pool utama ke pool shadow secara atomik, misalnya saat
+// health check pool utama gagal, lalu membangun ulang pool utama di latar belakang dengan
+// ReinitializePool. Instance yang sudah dipinjam tetap dikembalikan ke pool asalnya. Trafik tetap
+// dilayani shadow hingga Failback dipanggil.
+func (pm *PoolManager) Failover(primary, shadow string) error {
+	entry, err := pm.lookupEntry(primary, "failover")
+	if err != nil {
+		return err
+	}
+	shadowEntry, err := pm.lookupEntry(shadow, "failover")
+	if err != nil {
+		return err
+	}
+	link := entry.shadow.Load()
+	if link == nil || link.name != shadow {
+		return NewPoolError(primary, "failover", errors.New("pool is not a shadow of "+primary+": "+shadow))
+	}
+	if link.active.Load() {
+		return NewPoolError(primary, "failover", errors.New("pool has already failed over to "+shadow))
+	}
+
+	// Pastikan shadow hangat sebelum menerima trafik, objek idle sync.Pool dapat hilang saat GC
+	pm.warmShadow(shadowEntry, link.warmSize)
+	if !link.active.CompareAndSwap(false, true) {
+		return NewPoolError(primary, "failover", errors.New("pool has already failed over to "+shadow))
+	}
+	pm.logPoolf(WarningLevel, primary, "Failed over pool: %s to shadow pool: %s", primary, shadow)
+	pm.triggerEvent(PoolEvent{Type: EventFailover, PoolName: primary, Item: shadow})
+
+	if link.rebuilding.CompareAndSwap(false, true) {
+		go pm.rebuildPrimary(primary, link)
+	}
+	return nil
+}
+
+// warmShadow mengisi pool shadow dengan objek baru hingga memiliki warmSize objek idle
+func (pm *PoolManager) warmShadow(entry *poolEntry, warmSize int) {
+	conf := entry.configuration()
+	idle := atomic.LoadInt64(&entry.metrics.Load().IdleCount)
+	for i := idle; i < int64(warmSize) && reserveLive(entry.backend); i++ {
+		instance := pm.construct(entry.name, conf, entry.factory)
+		if instance == nil {
+			pm.releaseLive(entry.name)
+			return
+		}
+		shard, err := pm.putInstanceToPool(entry.name, entry.backend, conf, instance, -1)
+		if err != nil {
+			pm.handleError(entry.name, err)
+			return
+		}
+		pm.adjustRetainedBytes(entry.name, estimateSize(instance))
+		pm.adjustGauges(entry.name, shard, 1, 0)
+	}
+}
+
+// rebuildPrimary membangun ulang pool utama setelah failover
+func (pm *PoolManager) rebuildPrimary(primary string, link *shadowLink) {
+	defer link.rebuilding.Store(false)
+	if err := pm.ReinitializePool(primary); err != nil {
+		pm.logPoolf(ErrorLevel, primary, "Failed to rebuild pool: %s after failover, Error: %v", primary, err)
+		pm.handleError(primary, err)
+		return
+	}
+	pm.logPoolf(InfoLevel, primary, "Rebuilt pool: %s, traffic remains on shadow pool: %s until failback", primary, link.name)
+}
+
+// Failback mengembalikan acquire ke pool utama setelah Failover
+func (pm *PoolManager) Failback(primary string) error {
+	entry, err := pm.lookupEntry(primary, "failback")
+	if err != nil {
+		return err
+	}
+	link := entry.shadow.Load()
+	if link == nil || !link.active.CompareAndSwap(true, false) {
+		return NewPoolError(primary, "failback", errors.New("pool has not failed over"))
+	}
+	pm.logPoolf(InfoLevel, primary, "Failed back pool: %s from shadow pool: %s", primary, link.name)
+	return nil
+}
+
+// FailoverStatus mengembalikan keadaan failover pool utama. ok bernilai false jika pool tidak
+// memiliki shadow.
+func (pm *PoolManager) FailoverStatus(primary string) (status FailoverStatus, ok bool) {
+	entry, found := pm.entryFor(primary)
+	if !found {
+		return FailoverStatus{}, false
+	}
+	link := entry.shadow.Load()
+	if link == nil {
+		return FailoverStatus{}, false
+	}
+	return FailoverStatus{Shadow: link.name, Active: link.active.Load(), Rebuilding: link.rebuilding.Load()}, true
+}
+
+// routeAcquire mengembalikan entri yang melayani acquire pool, yaitu shadow selama failover
+func (pm *PoolManager) routeAcquire(entry *poolEntry) *poolEntry {
+	link := entry.shadow.Load()
+	if link == nil || !link.active.Load() {
+		return entry
+	}
+	if shadow, ok := pm.entryFor(link.name); ok {
+		return shadow
+	}
+	return entry
+}
+
+// routeRelease mengembalikan entri asal instance yang dikembalikan melalui nama pool utama.
+// Instance yang dipinjam dari shadow kembali ke shadow meskipun failover sudah berakhir.
+func (pm *PoolManager) routeRelease(entry *poolEntry, instance PoolAble) *poolEntry {
+	link := entry.shadow.Load()
+	if link == nil || instance == nil {
+		return entry
+	}
+	shadow, ok := pm.entryFor(link.name)
+	if !ok {
+		return entry
+	}
+	if pm.checkoutFor(link.name, pm.originalInstance(link.name, instance)) != nil {
+		return shadow
+	}
+	// Instance yang tidak dapat dilacak mengikuti arah trafik saat ini
+	if link.active.Load() && pm.checkoutFor(entry.name, pm.originalInstance(entry.name, instance)) == nil {
+		return shadow
+	}
+	return entry
+}
@@ -1,55 +1,180 @@
 package poolmanager
 
 import (
-	"math/rand"
+	"hash/maphash"
+	"math/rand/v2"
+	"sync"
 	"sync/atomic"
-	"time"
 )
 
 type ShardingStrategy interface {
 	GetShardIndex(poolType string, shardCount int, key string) int
 }
 
+// ShardingFunc mengadaptasi fungsi biasa menjadi ShardingStrategy
+type ShardingFunc func(poolType string, shardCount int, key string) int
+
+func (f ShardingFunc) GetShardIndex(poolType string, shardCount int, key string) int {
+	return f(poolType, shardCount, key)
+}
+
+// ShardModifier membungkus sebuah ShardingStrategy untuk mengubah perilakunya,
+// misalnya mentransformasi kunci atau menggeser indeks shard yang dihasilkan.
+type ShardModifier func(next ShardingStrategy) ShardingStrategy
+
+// WithShardModifiers menerapkan modifier secara berurutan di atas strategi dasar.
+// Modifier pertama menjadi lapisan terluar. Jika base nil, HashSharding digunakan.
+func WithShardModifiers(base ShardingStrategy, modifiers ...ShardModifier) ShardingStrategy {
+	if base == nil {
+		base = &HashSharding{}
+	}
+	strategy := base
+	for i := len(modifiers) - 1; i >= 0; i-- {
+		if modifiers[i] != nil {
+			strategy = modifiers[i](strategy)
+		}
+	}
+	return strategy
+}
+
+// KeyTransform mengubah kunci sebelum diteruskan ke strategi berikutnya
+func KeyTransform(transform func(poolType, key string) string) ShardModifier {
+	return func(next ShardingStrategy) ShardingStrategy {
+		return ShardingFunc(func(poolType string, shardCount int, key string) int {
+			return next.GetShardIndex(poolType, shardCount, transform(poolType, key))
+		})
+	}
+}
+
+// ShardOffset menggeser indeks shard yang dihasilkan sebanyak offset (modulo jumlah shard)
+func ShardOffset(offset int) ShardModifier {
+	return func(next ShardingStrategy) ShardingStrategy {
+		return ShardingFunc(func(poolType string, shardCount int, key string) int {
+			index := (next.GetShardIndex(poolType, shardCount, key) + offset) % shardCount
+			if index < 0 {
+				index += shardCount
+			}
+			return index
+		})
+	}
+}
+
+// ForPools menggunakan strategi alternatif untuk pool dengan nama tertentu,
+// sementara pool lain tetap memakai strategi berikutnya.
+func ForPools(strategy ShardingStrategy, poolTypes ...string) ShardModifier {
+	names := make(map[string]struct{}, len(poolTypes))
+	for _, name := range poolTypes {
+		names[name] = struct{}{}
+	}
+	return func(next ShardingStrategy) ShardingStrategy {
+		return ShardingFunc(func(poolType string, shardCount int, key string) int {
+			if _, ok := names[poolType]; ok {
+				return strategy.GetShardIndex(poolType, shardCount, key)
+			}
+			return next.GetShardIndex(poolType, shardCount, key)
+		})
+	}
+}
+
 // RoundRobinSharding implements round-robin strategy
 type RoundRobinSharding struct {
 	counter int64
+	Step    int64 // Jumlah langkah counter per pemilihan (0 dianggap 1)
+}
+
+// NewRoundRobinSharding membuat RoundRobinSharding dengan langkah tertentu
+func NewRoundRobinSharding(step int64) *RoundRobinSharding {
+	return &RoundRobinSharding{Step: step}
 }
 
 func (rr *RoundRobinSharding) GetShardIndex(poolType string, shardCount int, key string) int {
-	// Contoh: Menerapkan logika berbeda berdasarkan poolType
-	if poolType == "specialPool" {
-		// Jika poolType tertentu, gunakan beberapa langkah tambahan
-		return int(atomic.AddInt64(&rr.counter, 3) % int64(shardCount))
+	step := rr.Step
+	if step <= 0 {
+		step = 1
 	}
-	// Default ke round-robin biasa
-	return int(atomic.AddInt64(&rr.counter, 1) % int64(shardCount))
+	return int(uint64(atomic.AddInt64(&rr.counter, step)) % uint64(shardCount))
 }
 
 // RandomSharding implements random strategy
+// Nilai nol siap dipakai dan menggunakan sumber acak global math/rand/v2 yang aman
+// untuk goroutine. RandomSharding dengan seed tetap memakai generator lokal yang dilindungi mutex.
 type RandomSharding struct {
-	rng *rand.Rand // Generator acak lokal
+	mu  sync.Mutex
+	rng *rand.Rand // Generator acak lokal (nil berarti sumber global)
 }
 
 // NewRandomSharding membuat instance baru dari RandomSharding
 func NewRandomSharding() *RandomSharding {
+	return &RandomSharding{}
+}
+
+// NewRandomShardingWithSeed membuat RandomSharding dengan seed tetap sehingga urutan shard dapat direproduksi
+func NewRandomShardingWithSeed(seed int64) *RandomSharding {
 	return &RandomSharding{
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())), // Inisialisasi dengan seed berdasarkan waktu saat ini
+		rng: rand.New(rand.NewPCG(uint64(seed), 0)),
 	}
 }
 
 func (r *RandomSharding) GetShardIndex(poolType string, shardCount int, key string) int {
-	// Contoh: Jika poolType adalah "specialPool", maka modifikasi generator acak lokal
-	if poolType == "specialPool" {
-		r.rng = rand.New(rand.NewSource(int64(len(poolType))))
+	if r.rng == nil {
+		return rand.IntN(shardCount)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.rng.IntN(shardCount)
+}
+
+// ShardHasher menghitung nilai hash dari kombinasi poolType dan key untuk HashSharding.
+// Implementasi sebaiknya tidak mengalokasikan memori karena dipanggil pada setiap operasi.
+type ShardHasher func(poolType, key string) uint64
+
+// FNVHasher menghitung FNV-1a 32-bit atas poolType diikuti key tanpa menggabungkan string.
+// Hasilnya identik dengan hashString(poolType + key).
+func FNVHasher(poolType, key string) uint64 {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	hash := uint32(offset32)
+	for i := 0; i < len(poolType); i++ {
+		hash ^= uint32(poolType[i])
+		hash *= prime32
+	}
+	for i := 0; i < len(key); i++ {
+		hash ^= uint32(key[i])
+		hash *= prime32
+	}
+	return uint64(hash)
+}
+
+// NewMapHasher membuat ShardHasher berbasis hash/maphash dengan seed acak per proses.
+// Lebih cepat dari FNV untuk kunci panjang, tetapi hasilnya tidak stabil antar proses.
+func NewMapHasher() ShardHasher {
+	seed := maphash.MakeSeed()
+	return func(poolType, key string) uint64 {
+		var h maphash.Hash
+		h.SetSeed(seed)
+		h.WriteString(poolType)
+		h.WriteString(key)
+		return h.Sum64()
 	}
-	return r.rng.Intn(shardCount)
 }
 
 // HashSharding implements hash-based strategy
-type HashSharding struct{}
+// Hasher menentukan fungsi hash yang digunakan (nil berarti FNVHasher).
+type HashSharding struct {
+	Hasher ShardHasher
+}
+
+// NewHashSharding membuat HashSharding dengan fungsi hash tertentu
+func NewHashSharding(hasher ShardHasher) *HashSharding {
+	return &HashSharding{Hasher: hasher}
+}
 
 func (h *HashSharding) GetShardIndex(poolType string, shardCount int, key string) int {
-	// Contoh: Menggunakan kombinasi poolType dan key untuk hashing
-	hash := hashString(poolType + key)
-	return int(hash % uint32(shardCount))
+	hasher := h.Hasher
+	if hasher == nil {
+		hasher = FNVHasher
+	}
+	return int(hasher(poolType, key) % uint64(shardCount))
 }
@@ -0,0 +1,44 @@
+package poolmanager_test
+
+import (
+	"sync"
+	"testing"
+
+	poolmanager "github.com/hibbannn/pool-manager"
+)
+
+func TestSetShardingStrategyDuringAcquire(t *testing.T) {
+	pm := newTestManager(t)
+	_, err := pm.AddPoolWithOptions("sharded", func() poolmanager.PoolAble { return &testObject{} },
+		poolmanager.WithSharding(4, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			pm.SetShardingStrategy(poolmanager.NewRoundRobinSharding(1))
+			pm.SetShardingStrategy(nil)
+		}
+	}()
+	for i := 0; i < 500; i++ {
+		instance, err := pm.AcquireInstance("sharded")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := pm.ReleaseInstance("sharded", instance); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(stop)
+	wg.Wait()
+}
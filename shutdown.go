@@ -0,0 +1,85 @@
+package poolmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ShutdownHook dijalankan saat pool dihapus, sebelum objek idle dihancurkan, misalnya untuk
+// mem-flush writer yang di-pool. ctx berakhir saat ShutdownTimeout pool terlampaui.
+type ShutdownHook func(ctx context.Context) error
+
+// OnPoolShutdown mendaftarkan hook yang dijalankan oleh RemovePool (termasuk melalui Clear)
+// setelah pool berhenti menerima acquire baru dan sebelum objek idle dihancurkan. Hook dijalankan
+// berurutan sesuai urutan pendaftaran dan error dari setiap hook digabungkan ke error RemovePool.
+func (pm *PoolManager) OnPoolShutdown(poolName string, hook ShutdownHook) error {
+	if hook == nil {
+		return NewPoolError(poolName, "shutdown", errors.New("shutdown hook must not be nil"))
+	}
+	entry, err := pm.lookupEntry(poolName, "shutdown")
+	if err != nil {
+		return err
+	}
+	entry.shutdownMu.Lock()
+	entry.shutdownHooks = append(entry.shutdownHooks, hook)
+	entry.shutdownMu.Unlock()
+	return nil
+}
+
+// runShutdownHooks menjalankan hook shutdown pool secara berurutan. Jika ShutdownTimeout
+// terlampaui, hook yang sedang berjalan ditinggalkan dan hook berikutnya tidak dijalankan.
+func (pm *PoolManager) runShutdownHooks(entry *poolEntry, conf PoolConfiguration) error {
+	entry.shutdownMu.Lock()
+	hooks := entry.shutdownHooks
+	entry.shutdownHooks = nil
+	entry.shutdownMu.Unlock()
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+	if conf.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, conf.ShutdownTimeout)
+		defer cancel()
+	}
+
+	var errs []error
+	for i, hook := range hooks {
+		if ctx.Err() != nil {
+			errs = append(errs, NewPoolError(entry.name, "shutdown",
+				fmt.Errorf("%d shutdown hook(s) skipped: %w", len(hooks)-i, ctx.Err())))
+			break
+		}
+		if err := runShutdownHook(ctx, hook); err != nil {
+			errs = append(errs, NewPoolError(entry.name, "shutdown", fmt.Errorf("hook %d: %w", i, err)))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		pm.logPoolf(WarningLevel, entry.name, "Shutdown hooks failed for pool: %s, Error: %v", entry.name, err)
+		return err
+	}
+	pm.logPoolf(DebugLevel, entry.name, "Ran %d shutdown hook(s) for pool: %s", len(hooks), entry.name)
+	return nil
+}
+
+// runShutdownHook menjalankan satu hook dan berhenti menunggu saat ctx berakhir. Panic pada hook
+// dikembalikan sebagai error agar hook lain dan pembersihan pool tetap berjalan.
+func runShutdownHook(ctx context.Context, hook ShutdownHook) error {
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- hook(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
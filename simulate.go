@@ -0,0 +1,290 @@
+package poolmanager
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DemandEvent adalah satu acquire pada trace permintaan
+type DemandEvent struct {
+	At   time.Duration // Waktu acquire relatif terhadap awal trace
+	Hold time.Duration // Durasi instance dipegang sebelum dikembalikan
+}
+
+// DemandTrace adalah urutan acquire yang diputar ulang oleh Simulate
+type DemandTrace []DemandEvent
+
+// SyntheticTrace membuat trace permintaan sintetis sepanjang duration.
+// rate: jumlah acquire per detik pada waktu tertentu, memungkinkan pola beban seperti lonjakan atau siklus harian
+// hold: durasi setiap instance dipegang
+func SyntheticTrace(duration time.Duration, rate func(at time.Duration) float64, hold time.Duration) DemandTrace {
+	var trace DemandTrace
+	var at time.Duration
+	for at < duration {
+		perSecond := rate(at)
+		if perSecond <= 0 {
+			at += time.Second
+			continue
+		}
+		trace = append(trace, DemandEvent{At: at, Hold: hold})
+		at += time.Duration(float64(time.Second) / perSecond)
+	}
+	return trace
+}
+
+// SimulationSample adalah keadaan pool simulasi pada satu titik waktu virtual
+type SimulationSample struct {
+	At    time.Duration // Waktu virtual
+	Idle  int           // Jumlah objek idle
+	InUse int           // Jumlah objek yang sedang dipinjam
+}
+
+// SimulationResult merangkum hasil Simulate
+type SimulationResult struct {
+	Acquires      int64              // Jumlah acquire pada trace
+	Hits          int64              // Acquire yang dilayani objek idle
+	Misses        int64              // Acquire yang membutuhkan pemanggilan factory
+	Constructions int64              // Total pembuatan objek, termasuk InitialSize dan auto-tuning
+	Evictions     int64              // Objek idle yang dieviksi
+	AutoTunes     int64              // Jumlah perubahan ukuran oleh auto-tuning
+	PeakIdle      int                // Jumlah objek idle tertinggi
+	PeakInUse     int                // Jumlah objek dipinjam tertinggi
+	Timeline      []SimulationSample // Keadaan pool pada setiap tick eviksi dan auto-tuning
+}
+
+// MissRate mengembalikan rasio acquire yang tidak dilayani objek idle
+func (r SimulationResult) MissRate() float64 {
+	if r.Acquires == 0 {
+		return 0
+	}
+	return float64(r.Misses) / float64(r.Acquires)
+}
+
+// String merangkum hasil simulasi dalam satu baris
+func (r SimulationResult) String() string {
+	return fmt.Sprintf("acquires=%d hits=%d misses=%d (%.1f%%) constructions=%d evictions=%d autotunes=%d peak idle/in-use=%d/%d",
+		r.Acquires, r.Hits, r.Misses, r.MissRate()*100, r.Constructions, r.Evictions, r.AutoTunes, r.PeakIdle, r.PeakInUse)
+}
+
+// Simulate memutar ulang trace permintaan terhadap konfigurasi pool dengan jam virtual sehingga
+// parameter TTL, eviksi, dan auto-tuning dapat dievaluasi secara offline tanpa menunggu waktu nyata.
+// Eviksi menggunakan config.Eviction, atau TTLEvictionPolicy dengan config.TTL jika tidak diatur.
+// Auto-tuning mengikuti perhitungan AutoTuneFactor/AutoTuneDynamicFactor serta batas MinSize dan MaxSize.
+func Simulate(config PoolConfiguration, trace DemandTrace) SimulationResult {
+	sim := &simulation{config: config, policy: config.Eviction}
+	if sim.policy == nil && config.TTL > 0 {
+		sim.policy = &TTLEvictionPolicy{TTL: config.TTL}
+	}
+
+	events := make([]DemandEvent, len(trace))
+	copy(events, trace)
+	sort.SliceStable(events, func(i, j int) bool { return events[i].At < events[j].At })
+	for _, event := range events {
+		heap.Push(&sim.queue, simEvent{at: event.At, kind: simAcquire, hold: event.Hold})
+	}
+
+	var end time.Duration
+	if len(events) > 0 {
+		end = events[len(events)-1].At
+	}
+	if sim.policy != nil && config.EvictionInterval > 0 {
+		for at := config.EvictionInterval; at <= end; at += config.EvictionInterval {
+			heap.Push(&sim.queue, simEvent{at: at, kind: simEvict})
+		}
+	}
+	if config.AutoTune && config.AutoTuneInterval > 0 {
+		for at := config.AutoTuneInterval; at <= end; at += config.AutoTuneInterval {
+			heap.Push(&sim.queue, simEvent{at: at, kind: simAutoTune})
+		}
+	}
+
+	for i := 0; i < config.InitialSize; i++ {
+		sim.idle = append(sim.idle, sim.newItem(0))
+	}
+	sim.observe()
+
+	for sim.queue.Len() > 0 {
+		event := heap.Pop(&sim.queue).(simEvent)
+		sim.now = event.at
+		switch event.kind {
+		case simRelease:
+			sim.inUse--
+			event.item.lastUsed = sim.now
+			sim.idle = append(sim.idle, event.item)
+		case simAcquire:
+			sim.acquire(event.hold)
+		case simEvict:
+			sim.evict()
+			sim.sample()
+		case simAutoTune:
+			sim.autoTune()
+			sim.sample()
+		}
+		sim.observe()
+	}
+	return sim.result
+}
+
+// simItem adalah objek pool di dalam simulasi
+type simItem struct {
+	id        int
+	created   time.Duration
+	lastUsed  time.Duration
+	frequency int
+}
+
+type simEventKind int
+
+// Urutan konstanta menentukan prioritas event pada waktu yang sama: release diproses lebih dahulu
+const (
+	simRelease simEventKind = iota
+	simAcquire
+	simEvict
+	simAutoTune
+)
+
+type simEvent struct {
+	at   time.Duration
+	kind simEventKind
+	hold time.Duration
+	item *simItem
+	seq  int
+}
+
+// simQueue adalah antrean prioritas event berdasarkan waktu virtual
+type simQueue struct {
+	events []simEvent
+	seq    int
+}
+
+func (q simQueue) Len() int { return len(q.events) }
+func (q simQueue) Less(i, j int) bool {
+	a, b := q.events[i], q.events[j]
+	if a.at != b.at {
+		return a.at < b.at
+	}
+	if a.kind != b.kind {
+		return a.kind < b.kind
+	}
+	return a.seq < b.seq
+}
+func (q simQueue) Swap(i, j int) { q.events[i], q.events[j] = q.events[j], q.events[i] }
+func (q *simQueue) Push(x interface{}) {
+	event := x.(simEvent)
+	q.seq++
+	event.seq = q.seq
+	q.events = append(q.events, event)
+}
+func (q *simQueue) Pop() interface{} {
+	last := q.events[len(q.events)-1]
+	q.events = q.events[:len(q.events)-1]
+	return last
+}
+
+// simulation menyimpan keadaan pool selama Simulate berjalan
+type simulation struct {
+	config PoolConfiguration
+	policy EvictionPolicy
+	queue  simQueue
+	now    time.Duration
+	idle   []*simItem
+	inUse  int
+	nextID int
+	result SimulationResult
+}
+
+// newItem membuat objek baru pada waktu virtual tertentu
+func (s *simulation) newItem(at time.Duration) *simItem {
+	s.nextID++
+	s.result.Constructions++
+	return &simItem{id: s.nextID, created: at, lastUsed: at}
+}
+
+// acquire mengambil objek idle terakhir (seperti sync.Pool) atau membuat objek baru
+func (s *simulation) acquire(hold time.Duration) {
+	s.result.Acquires++
+	var item *simItem
+	if n := len(s.idle); n > 0 {
+		item = s.idle[n-1]
+		s.idle = s.idle[:n-1]
+		s.result.Hits++
+	} else {
+		item = s.newItem(s.now)
+		s.result.Misses++
+	}
+	item.frequency++
+	item.lastUsed = s.now
+	s.inUse++
+	heap.Push(&s.queue, simEvent{at: s.now + hold, kind: simRelease, item: item})
+}
+
+// evict mengevaluasi objek idle terhadap kebijakan eviksi. Waktu virtual diterjemahkan ke
+// waktu nyata relatif terhadap time.Now sehingga kebijakan yang memakai time.Since tetap akurat.
+func (s *simulation) evict() {
+	wall := time.Now()
+	kept := s.idle[:0]
+	for _, item := range s.idle {
+		metadata := &PoolItemMetadata{
+			PoolName:     s.config.Name,
+			LastUsed:     wall.Add(item.lastUsed - s.now),
+			CreationTime: wall.Add(item.created - s.now),
+			Frequency:    item.frequency,
+			AccessCount:  item.frequency,
+			IdleDuration: s.now - item.lastUsed,
+			Status:       "Idle",
+			IsPooled:     true,
+		}
+		if s.policy.ShouldEvict(fmt.Sprintf("%s#sim%d", s.config.Name, item.id), metadata) {
+			s.result.Evictions++
+			continue
+		}
+		kept = append(kept, item)
+	}
+	s.idle = kept
+}
+
+// autoTune menyesuaikan jumlah objek idle seperti goroutine auto-tuning pool
+func (s *simulation) autoTune() {
+	currentSize := len(s.idle)
+	if currentSize == 0 {
+		return
+	}
+	factor := s.config.AutoTuneFactor
+	if s.config.AutoTuneDynamicFactor != nil {
+		factor = s.config.AutoTuneDynamicFactor(currentSize)
+	}
+	newSize := int(float64(currentSize) * factor)
+	if newSize > s.config.MaxSize {
+		newSize = s.config.MaxSize
+	} else if newSize < s.config.MinSize {
+		newSize = s.config.MinSize
+	}
+	if newSize == currentSize {
+		return
+	}
+
+	s.result.AutoTunes++
+	for len(s.idle) < newSize {
+		s.idle = append(s.idle, s.newItem(s.now))
+	}
+	if len(s.idle) > newSize {
+		s.idle = s.idle[:newSize]
+	}
+}
+
+// observe memperbarui nilai puncak
+func (s *simulation) observe() {
+	if len(s.idle) > s.result.PeakIdle {
+		s.result.PeakIdle = len(s.idle)
+	}
+	if s.inUse > s.result.PeakInUse {
+		s.result.PeakInUse = s.inUse
+	}
+}
+
+// sample mencatat keadaan pool pada timeline
+func (s *simulation) sample() {
+	s.result.Timeline = append(s.result.Timeline, SimulationSample{At: s.now, Idle: len(s.idle), InUse: s.inUse})
+}
@@ -0,0 +1,250 @@
+package poolmanager
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// MetricsSink adalah tujuan metrik internal PoolManager. PoolManager memanggil sink setiap kali
+// counter bertambah, gauge berubah, atau durasi diobservasi, sehingga backend telemetri baru cukup
+// mengimplementasikan interface ini tanpa mengubah kode inti. Setiap pemanggilan menyertakan label
+// "pool", label "namespace" untuk pool bernamespace, beserta label statis pool. Implementasi harus aman dipanggil dari banyak goroutine.
+type MetricsSink interface {
+	AddCounter(name string, labels map[string]string, delta int64)         // Menambah counter monoton
+	SetGauge(name string, labels map[string]string, value float64)         // Menetapkan nilai gauge
+	ObserveHistogram(name string, labels map[string]string, value float64) // Mencatat satu observasi histogram
+}
+
+// Nama metrik yang dikirim PoolManager ke MetricsSink
+const (
+	SinkOperationsTotal = "pool_operations_total"         // Counter operasi dengan label "action" (get, put, evict, ...)
+	SinkInUse           = "pool_in_use"                   // Gauge objek yang sedang dipinjam
+	SinkIdle            = "pool_idle"                     // Gauge perkiraan objek idle di dalam pool
+	SinkRetainedBytes   = "pool_retained_bytes"           // Gauge perkiraan byte yang ditahan objek idle
+	SinkWaiters         = "pool_waiters"                  // Gauge goroutine yang menunggu unit MaxActive
+	SinkFactoryDuration = "pool_factory_duration_seconds" // Histogram durasi pemanggilan factory
+	SinkAcquireWait     = "pool_acquire_wait_seconds"     // Histogram waktu tunggu acquire karena MaxActive
+	SinkObjectLifetime  = "pool_object_lifetime_seconds"  // Histogram umur objek saat dihancurkan
+)
+
+// SinkBucketBounds adalah batas atas bucket histogram (dalam detik) yang digunakan InMemorySink
+// dan PrometheusSink. Nilainya sama dengan LatencyBucketBounds.
+var SinkBucketBounds = func() []float64 {
+	bounds := make([]float64, len(LatencyBucketBounds))
+	for i, bound := range LatencyBucketBounds {
+		bounds[i] = bound.Seconds()
+	}
+	return bounds
+}()
+
+// SinkAgeBucketBounds adalah batas atas bucket histogram (dalam detik) untuk SinkObjectLifetime.
+// Nilainya sama dengan AgeBucketBounds.
+var SinkAgeBucketBounds = func() []float64 {
+	bounds := make([]float64, len(AgeBucketBounds))
+	for i, bound := range AgeBucketBounds {
+		bounds[i] = bound.Seconds()
+	}
+	return bounds
+}()
+
+// sinkBucketBounds mengembalikan batas bucket histogram untuk metrik tertentu
+func sinkBucketBounds(name string) []float64 {
+	if name == SinkObjectLifetime {
+		return SinkAgeBucketBounds
+	}
+	return SinkBucketBounds
+}
+
+// NoopMetricsSink membuang seluruh metrik. Berguna sebagai nilai default atau untuk menonaktifkan
+// sink tanpa memeriksa nil.
+type NoopMetricsSink struct{}
+
+func (NoopMetricsSink) AddCounter(string, map[string]string, int64)         {}
+func (NoopMetricsSink) SetGauge(string, map[string]string, float64)         {}
+func (NoopMetricsSink) ObserveHistogram(string, map[string]string, float64) {}
+
+// SinkHistogram adalah salinan histogram yang dikumpulkan InMemorySink.
+// Buckets[i] menghitung observasi <= SinkBucketBounds[i] (SinkAgeBucketBounds[i] untuk
+// SinkObjectLifetime); bucket terakhir adalah overflow.
+type SinkHistogram struct {
+	Buckets []int64 // Jumlah observasi per bucket (tidak kumulatif)
+	Count   int64   // Total jumlah observasi
+	Sum     float64 // Total nilai seluruh observasi
+}
+
+// sinkSeries adalah satu deret metrik (nama + label) yang disimpan InMemorySink
+type sinkSeries struct {
+	name      string
+	labels    map[string]string
+	kind      string // "counter", "gauge", atau "histogram"
+	value     float64
+	histogram SinkHistogram
+}
+
+// InMemorySink menyimpan metrik di memori. Cocok untuk test dan sebagai dasar eksportir yang
+// membaca metrik secara berkala, misalnya PrometheusSink.
+type InMemorySink struct {
+	mu     sync.Mutex
+	series map[string]*sinkSeries
+}
+
+// NewInMemorySink membuat InMemorySink kosong
+func NewInMemorySink() *InMemorySink {
+	return &InMemorySink{series: make(map[string]*sinkSeries)}
+}
+
+// seriesFor mengambil deret untuk nama dan label tertentu, membuatnya jika belum ada.
+// Pemanggil harus memegang s.mu.
+func (s *InMemorySink) seriesFor(kind, name string, labels map[string]string) *sinkSeries {
+	if s.series == nil {
+		s.series = make(map[string]*sinkSeries)
+	}
+	key := seriesKey(name, labels)
+	series, ok := s.series[key]
+	if !ok {
+		series = &sinkSeries{name: name, labels: copyLabels(labels), kind: kind}
+		if kind == "histogram" {
+			series.histogram.Buckets = make([]int64, len(sinkBucketBounds(name))+1)
+		}
+		s.series[key] = series
+	}
+	return series
+}
+
+// AddCounter menambah counter
+func (s *InMemorySink) AddCounter(name string, labels map[string]string, delta int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seriesFor("counter", name, labels).value += float64(delta)
+}
+
+// SetGauge menetapkan nilai gauge
+func (s *InMemorySink) SetGauge(name string, labels map[string]string, value float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seriesFor("gauge", name, labels).value = value
+}
+
+// ObserveHistogram mencatat satu observasi histogram
+func (s *InMemorySink) ObserveHistogram(name string, labels map[string]string, value float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	histogram := &s.seriesFor("histogram", name, labels).histogram
+	bounds := sinkBucketBounds(name)
+	bucket := len(bounds)
+	for i, bound := range bounds {
+		if value <= bound {
+			bucket = i
+			break
+		}
+	}
+	histogram.Buckets[bucket]++
+	histogram.Count++
+	histogram.Sum += value
+}
+
+// Counter mengembalikan nilai counter, 0 jika belum pernah dicatat
+func (s *InMemorySink) Counter(name string, labels map[string]string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if series, ok := s.series[seriesKey(name, labels)]; ok {
+		return int64(series.value)
+	}
+	return 0
+}
+
+// Gauge mengembalikan nilai gauge terakhir dan apakah gauge pernah ditetapkan
+func (s *InMemorySink) Gauge(name string, labels map[string]string) (float64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series, ok := s.series[seriesKey(name, labels)]
+	if !ok {
+		return 0, false
+	}
+	return series.value, true
+}
+
+// Histogram mengembalikan salinan histogram, kosong jika belum pernah diobservasi
+func (s *InMemorySink) Histogram(name string, labels map[string]string) SinkHistogram {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series, ok := s.series[seriesKey(name, labels)]
+	if !ok {
+		return SinkHistogram{}
+	}
+	histogram := series.histogram
+	histogram.Buckets = append([]int64(nil), histogram.Buckets...)
+	return histogram
+}
+
+// Reset menghapus seluruh metrik yang tersimpan
+func (s *InMemorySink) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.series = make(map[string]*sinkSeries)
+}
+
+// snapshot mengembalikan salinan seluruh deret, diurutkan berdasarkan nama lalu label
+func (s *InMemorySink) snapshot() []sinkSeries {
+	s.mu.Lock()
+	keys := make([]string, 0, len(s.series))
+	for key := range s.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	series := make([]sinkSeries, len(keys))
+	for i, key := range keys {
+		series[i] = *s.series[key]
+		series[i].histogram.Buckets = append([]int64(nil), series[i].histogram.Buckets...)
+	}
+	s.mu.Unlock()
+	return series
+}
+
+// seriesKey membentuk kunci deret yang stabil dari nama dan label
+func seriesKey(name string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return name
+	}
+	return name + formatLabels(labels)
+}
+
+// sinkLabels menyusun label untuk MetricsSink: label statis pool, label "pool", label "namespace"
+// jika pool memiliki namespace, lalu pasangan kunci/nilai tambahan
+func (pm *PoolManager) sinkLabels(poolName string, kv ...string) map[string]string {
+	static := pm.poolLabels(poolName)
+	labels := make(map[string]string, len(static)+2+len(kv)/2)
+	for key, value := range static {
+		labels[key] = value
+	}
+	labels["pool"] = poolName
+	if namespace := PoolNamespace(poolName); namespace != "" {
+		labels["namespace"] = namespace
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		labels[kv[i]] = kv[i+1]
+	}
+	return labels
+}
+
+// emitCounter meneruskan penambahan counter ke MetricsSink jika diatur
+func (pm *PoolManager) emitCounter(poolName, name string, delta int64, kv ...string) {
+	if sink := pm.monitoring().MetricsSink; sink != nil {
+		sink.AddCounter(name, pm.sinkLabels(poolName, kv...), delta)
+	}
+}
+
+// emitGauge meneruskan nilai gauge ke MetricsSink jika diatur
+func (pm *PoolManager) emitGauge(poolName, name string, value float64) {
+	if sink := pm.monitoring().MetricsSink; sink != nil {
+		sink.SetGauge(name, pm.sinkLabels(poolName), value)
+	}
+}
+
+// emitDuration meneruskan durasi dalam detik ke histogram MetricsSink jika diatur
+func (pm *PoolManager) emitDuration(poolName, name string, d time.Duration) {
+	if sink := pm.monitoring().MetricsSink; sink != nil {
+		sink.ObserveHistogram(name, pm.sinkLabels(poolName), d.Seconds())
+	}
+}
@@ -0,0 +1,60 @@
+package poolmanager
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// Sizer adalah interface opsional yang dapat diimplementasikan oleh objek pool
+// untuk melaporkan perkiraan jumlah byte yang ditahan oleh objek tersebut.
+// Objek yang tidak mengimplementasikan Sizer akan diperkirakan ukurannya secara dangkal
+// berdasarkan ukuran tipe (setara unsafe.Sizeof), tanpa menghitung data yang dirujuk.
+type Sizer interface {
+	// SizeBytes mengembalikan perkiraan jumlah byte yang ditahan oleh objek.
+	SizeBytes() int64
+}
+
+// estimateSize memperkirakan ukuran instance dalam byte.
+// instance: objek yang akan diperkirakan ukurannya
+// Menggunakan Sizer jika tersedia, jika tidak menggunakan ukuran tipe dari nilai yang ditunjuk.
+func estimateSize(instance PoolAble) int64 {
+	if instance == nil {
+		return 0
+	}
+	if sizer, ok := instance.(Sizer); ok {
+		return sizer.SizeBytes()
+	}
+
+	t := reflect.TypeOf(instance)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return int64(t.Size())
+}
+
+// adjustRetainedBytes memperbarui perkiraan byte yang ditahan oleh pool.
+// poolName: nama pool yang diperbarui
+// delta: perubahan jumlah byte (positif saat objek masuk ke pool, negatif saat keluar)
+// Nilai tidak pernah dibiarkan negatif karena sync.Pool dapat membuang objek tanpa pemberitahuan.
+func (pm *PoolManager) adjustRetainedBytes(poolName string, delta int64) {
+	metrics := pm.metricsFor(poolName)
+	if metrics == nil {
+		return
+	}
+
+	if atomic.AddInt64(&metrics.RetainedBytes, delta) < 0 {
+		atomic.StoreInt64(&metrics.RetainedBytes, 0)
+	}
+	pm.emitGauge(poolName, SinkRetainedBytes, float64(atomic.LoadInt64(&metrics.RetainedBytes)))
+}
+
+// GetRetainedBytes mengembalikan perkiraan jumlah byte yang ditahan oleh objek idle di dalam pool.
+// Nilai ini adalah perkiraan: sync.Pool dapat membuang objek idle saat GC sehingga nilai
+// sebenarnya bisa lebih kecil.
+func (pm *PoolManager) GetRetainedBytes(poolName string) (int64, error) {
+	metrics, err := pm.loadMetrics(poolName)
+	if err != nil {
+		return 0, err
+	}
+	return atomic.LoadInt64(&metrics.RetainedBytes), nil
+}
@@ -0,0 +1,191 @@
+package poolmanager
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+const (
+	defaultSliceMinCap = 64      // Kapasitas kelas terkecil jika SlicePoolConfig.MinCap tidak diatur
+	defaultSliceMaxCap = 1 << 16 // Kapasitas kelas terbesar jika SlicePoolConfig.MaxCap tidak diatur
+)
+
+// SlicePoolConfig menentukan kelas kapasitas SlicePool. Kelas berupa kelipatan dua dari MinCap
+// hingga MaxCap; permintaan yang lebih besar dari MaxCap dilayani tanpa pooling.
+type SlicePoolConfig struct {
+	MinCap int // Kapasitas kelas terkecil, dibulatkan ke kelipatan dua (0 = 64)
+	MaxCap int // Kapasitas kelas terbesar, dibulatkan ke kelipatan dua (0 = 65536)
+}
+
+// SliceBuffer adalah slice yang dipinjam dari SlicePool. S dapat diperpanjang dengan append;
+// Reset mengosongkan isinya dan mengembalikan panjangnya ke nol saat buffer kembali ke pool.
+type SliceBuffer[T any] struct {
+	S     []T
+	class int // Kapasitas kelas asal buffer (0 jika buffer tidak berasal dari pool)
+}
+
+// Reset mengosongkan elemen agar tidak menahan referensi dan mengembalikan panjang slice ke nol
+func (b *SliceBuffer[T]) Reset() {
+	clear(b.S)
+	b.S = b.S[:0]
+}
+
+// SizeBytes melaporkan kapasitas slice dalam byte untuk perhitungan RetainedBytes
+func (b *SliceBuffer[T]) SizeBytes() int64 {
+	var zero T
+	return int64(cap(b.S)) * int64(reflect.TypeOf(&zero).Elem().Size())
+}
+
+// SlicePool adalah pool slice generik dengan kelas kapasitas. Setiap kelas adalah pool biasa pada
+// PoolManager bernama "<nama>.cap<kapasitas>", sehingga metrik, eviksi, dan introspeksi berlaku
+// per kelas. SlicePool menggeneralisasi pool []byte ke tipe elemen apa pun.
+type SlicePool[T any] struct {
+	pm      *PoolManager
+	name    string
+	classes []int      // Kapasitas setiap kelas, terurut naik
+	refs    []*PoolRef // Pool untuk setiap kelas, sejajar dengan classes
+}
+
+// NewSlicePool mendaftarkan pool untuk setiap kelas kapasitas SlicePool pada pm.
+// config diterapkan ke setiap kelas; MinSize, MaxSize, dan InitialSize berlaku per kelas.
+// Jika pendaftaran salah satu kelas gagal, kelas yang sudah terdaftar dihapus kembali.
+func NewSlicePool[T any](pm *PoolManager, poolName string, classes SlicePoolConfig, config PoolConfiguration) (*SlicePool[T], error) {
+	if err := ValidatePoolName(poolName); err != nil {
+		return nil, NewPoolError(poolName, "add", err)
+	}
+	minCap, maxCap := classes.MinCap, classes.MaxCap
+	if minCap <= 0 {
+		minCap = defaultSliceMinCap
+	}
+	if maxCap <= 0 {
+		maxCap = defaultSliceMaxCap
+	}
+	minCap, maxCap = nextPowerOfTwo(minCap), nextPowerOfTwo(maxCap)
+	if minCap > maxCap {
+		return nil, NewPoolError(poolName, "add", fmt.Errorf("slice MinCap %d is greater than MaxCap %d", minCap, maxCap))
+	}
+
+	p := &SlicePool[T]{pm: pm, name: poolName}
+	for capacity := minCap; capacity <= maxCap; capacity <<= 1 {
+		class := capacity
+		ref, err := pm.AddPool(sliceClassName(poolName, class), func() PoolAble {
+			return &SliceBuffer[T]{S: make([]T, 0, class), class: class}
+		}, config)
+		if err != nil {
+			_ = p.Close()
+			return nil, err
+		}
+		p.classes = append(p.classes, class)
+		p.refs = append(p.refs, ref)
+	}
+	return p, nil
+}
+
+// sliceClassName membentuk nama pool untuk satu kelas kapasitas
+func sliceClassName(poolName string, class int) string {
+	return poolName + ".cap" + strconv.Itoa(class)
+}
+
+// nextPowerOfTwo membulatkan n ke atas ke kelipatan dua terdekat
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// Get meminjam slice dengan panjang minLen dan kapasitas minimal minCap. Elemen hingga minLen
+// bernilai nol. Permintaan yang melebihi kelas terbesar dialokasikan langsung tanpa pooling.
+func (p *SlicePool[T]) Get(minLen, minCap int) (*SliceBuffer[T], error) {
+	if minLen < 0 || minCap < 0 {
+		return nil, NewPoolError(p.name, "get", errors.New("slice length and capacity must not be negative"))
+	}
+	if minCap < minLen {
+		minCap = minLen
+	}
+
+	i := p.classFor(minCap)
+	if i < 0 {
+		return &SliceBuffer[T]{S: make([]T, minLen, minCap)}, nil
+	}
+	instance, err := p.refs[i].Acquire()
+	if err != nil {
+		return nil, err
+	}
+	buf, ok := instance.(*SliceBuffer[T])
+	if !ok {
+		return nil, NewPoolError(p.refs[i].Name(), "get", &TypeMismatchError{
+			Expected: typeString[*SliceBuffer[T]](),
+			Actual:   fmt.Sprintf("%T", instance),
+		})
+	}
+	buf.S = buf.S[:minLen]
+	clear(buf.S)
+	return buf, nil
+}
+
+// Put mengembalikan buffer ke kelas asalnya. Buffer yang dialokasikan tanpa pooling dibuang.
+// Jika S diganti dengan slice yang lebih kecil dari kelasnya, slice baru dialokasikan agar
+// jaminan kapasitas kelas tetap berlaku.
+func (p *SlicePool[T]) Put(buf *SliceBuffer[T]) error {
+	if buf == nil {
+		return NewPoolError(p.name, "put", errors.New("cannot put nil slice buffer into pool"))
+	}
+	if buf.class == 0 {
+		return nil
+	}
+	i := p.classIndex(buf.class)
+	if i < 0 {
+		return NewPoolError(p.name, "put", fmt.Errorf("slice buffer class %d does not belong to pool", buf.class))
+	}
+	if cap(buf.S) < buf.class {
+		buf.S = make([]T, 0, buf.class)
+	}
+	return p.refs[i].Release(buf)
+}
+
+// classFor mengembalikan indeks kelas terkecil yang dapat menampung capacity, atau -1 jika tidak ada
+func (p *SlicePool[T]) classFor(capacity int) int {
+	for i, class := range p.classes {
+		if class >= capacity {
+			return i
+		}
+	}
+	return -1
+}
+
+// classIndex mengembalikan indeks kelas dengan kapasitas tepat class, atau -1 jika tidak ada
+func (p *SlicePool[T]) classIndex(class int) int {
+	for i, c := range p.classes {
+		if c == class {
+			return i
+		}
+	}
+	return -1
+}
+
+// Name mengembalikan nama SlicePool
+func (p *SlicePool[T]) Name() string {
+	return p.name
+}
+
+// PoolNames mengembalikan nama pool setiap kelas kapasitas, terurut dari kelas terkecil
+func (p *SlicePool[T]) PoolNames() []string {
+	names := make([]string, len(p.refs))
+	for i, ref := range p.refs {
+		names[i] = ref.Name()
+	}
+	return names
+}
+
+// Close menghapus pool setiap kelas kapasitas dari PoolManager. Error dari setiap kelas digabungkan.
+func (p *SlicePool[T]) Close() error {
+	var errs []error
+	for _, ref := range p.refs {
+		errs = append(errs, p.pm.RemovePool(ref.Name()))
+	}
+	return errors.Join(errs...)
+}
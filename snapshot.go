@@ -0,0 +1,70 @@
+package poolmanager
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ManagerSnapshot adalah salinan konsisten dari metrik seluruh pool pada satu titik waktu.
+// Snapshot dapat diekspor ke sistem telemetri atau dibandingkan satu sama lain.
+type ManagerSnapshot struct {
+	Timestamp time.Time               // Waktu snapshot diambil
+	Pools     map[string]PoolMetrics  // Metrik per pool berdasarkan nama pool
+	Owners    map[string][]OwnerUsage // Penggunaan per pemilik setiap pool selama 1 jam terakhir
+}
+
+// snapshot membaca seluruh field PoolMetrics secara atomik dan mengembalikan salinannya.
+// TotalGets, TotalPuts, TotalCacheHits, dan CurrentUsage dibaca dari penghitung yang sama
+// sehingga CurrentUsage pada salinan selalu sesuai dengan penghitung lainnya.
+func (m *PoolMetrics) snapshot() PoolMetrics {
+	adjust := atomic.LoadInt64(&m.usageAdjust)
+	snap := PoolMetrics{
+		TotalGets:           m.hot.gets.load(),
+		TotalPuts:           m.hot.puts.load(),
+		TotalEvicts:         atomic.LoadInt64(&m.TotalEvicts),
+		TotalLeaks:          atomic.LoadInt64(&m.TotalLeaks),
+		TotalDiscards:       atomic.LoadInt64(&m.TotalDiscards),
+		TotalBroken:         atomic.LoadInt64(&m.TotalBroken),
+		TotalCacheHits:      m.hot.cacheHits.load(),
+		TotalSpills:         atomic.LoadInt64(&m.TotalSpills),
+		TotalRehydrates:     atomic.LoadInt64(&m.TotalRehydrates),
+		TotalStale:          atomic.LoadInt64(&m.TotalStale),
+		TotalCoalesced:      atomic.LoadInt64(&m.TotalCoalesced),
+		TotalReclaimed:      atomic.LoadInt64(&m.TotalReclaimed),
+		TotalInvalid:        atomic.LoadInt64(&m.TotalInvalid),
+		ColdCount:           atomic.LoadInt64(&m.ColdCount),
+		RetainedBytes:       atomic.LoadInt64(&m.RetainedBytes),
+		IdleCount:           atomic.LoadInt64(&m.IdleCount),
+		InUseCount:          atomic.LoadInt64(&m.InUseCount),
+		Waiters:             atomic.LoadInt64(&m.Waiters),
+		SlowestConstruction: time.Duration(atomic.LoadInt64((*int64)(&m.SlowestConstruction))),
+		FactoryLatency:      m.FactoryLatency.snapshot(),
+		ObjectLifetime:      m.ObjectLifetime.snapshot(),
+		Shards:              m.snapshotShards(),
+	}
+	snap.CurrentUsage = int32(snap.TotalGets + snap.TotalCacheHits - snap.TotalPuts -
+		snap.TotalLeaks - snap.TotalDiscards - snap.TotalSpills + adjust)
+	return snap
+}
+
+// Snapshot mengambil salinan metrik untuk semua pool yang terdaftar.
+func (pm *PoolManager) Snapshot() ManagerSnapshot {
+	snap := ManagerSnapshot{
+		Timestamp: time.Now(),
+		Pools:     make(map[string]PoolMetrics),
+		Owners:    make(map[string][]OwnerUsage),
+	}
+	pm.forEachEntry(func(entry *poolEntry) bool {
+		poolMetrics := pm.snapshotMetrics(entry.name, entry.metrics.Load())
+		poolMetrics.MetadataEntries, poolMetrics.IdleAges = pm.metadataStats(entry.name)
+		snap.Pools[entry.name] = poolMetrics
+		return true
+	})
+	pm.ownerUsage.Range(func(key, value interface{}) bool {
+		if usages := value.(*ownerUsageRecorder).summarize(snap.Timestamp, ownerUsageBucketWidth*ownerUsageBucketCount); len(usages) > 0 {
+			snap.Owners[key.(string)] = usages
+		}
+		return true
+	})
+	return snap
+}
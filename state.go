@@ -0,0 +1,175 @@
+package poolmanager
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// poolState menyimpan status tugas latar belakang (auto-tuning dan eviksi) untuk satu pool.
+// Setiap pool memiliki context sendiri yang dibatalkan saat pool dihapus, dan setiap tugas
+// latar belakang menurunkan context-nya dari context pool tersebut sehingga start/stop
+// dapat dipanggil berulang kali tanpa race.
+type poolState struct {
+	autoTuneRunning atomic.Bool  // Apakah goroutine auto-tuning sedang berjalan
+	evictionRunning atomic.Bool  // Apakah goroutine eviksi sedang berjalan
+	lastAutoTune    atomic.Int64 // Waktu auto-tuning terakhir dalam UnixNano (0 jika belum pernah)
+	lastEviction    atomic.Int64 // Waktu eviksi terakhir dalam UnixNano (0 jika belum pernah)
+
+	evictionDeferred atomic.Int64 // Awal penundaan eviksi oleh MaintenanceConfig dalam UnixNano (0 jika tidak ditunda)
+	autoTuneDeferred atomic.Int64 // Awal penundaan auto-tuning oleh MaintenanceConfig dalam UnixNano (0 jika tidak ditunda)
+	profiling        atomic.Int32 // Jumlah ProfilePool yang sedang berjalan untuk pool
+
+	ctx            context.Context    // Context yang hidup selama pool terdaftar
+	cancel         context.CancelFunc // Membatalkan ctx beserta seluruh tugas turunannya
+	mu             sync.Mutex         // Melindungi autoTuneCancel dan evictionCancel
+	autoTuneCancel context.CancelFunc // Menghentikan goroutine auto-tuning pool (nil jika tidak berjalan)
+	evictionCancel context.CancelFunc // Menghentikan goroutine eviksi pool (nil jika tidak berjalan)
+
+	semaphore atomic.Pointer[weightedSemaphore] // Pembatas unit in-use (nil jika MaxActive tidak diatur)
+}
+
+// newPoolState membuat status pool baru dengan context yang siap digunakan.
+func newPoolState() *poolState {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &poolState{ctx: ctx, cancel: cancel}
+}
+
+// poolStateFor mengembalikan status latar belakang untuk pool tertentu, membuatnya jika belum ada.
+func (pm *PoolManager) poolStateFor(poolName string) *poolState {
+	if stateVal, ok := pm.poolStates.Load(poolName); ok {
+		return stateVal.(*poolState)
+	}
+	state := newPoolState()
+	stateVal, loaded := pm.poolStates.LoadOrStore(poolName, state)
+	if loaded {
+		// Goroutine lain lebih dulu membuat status, buang context yang tidak terpakai
+		state.cancel()
+	}
+	return stateVal.(*poolState)
+}
+
+// stopPoolState membatalkan seluruh tugas latar belakang pool dan menghapus statusnya.
+func (pm *PoolManager) stopPoolState(poolName string) {
+	if stateVal, ok := pm.poolStates.LoadAndDelete(poolName); ok {
+		stateVal.(*poolState).cancel()
+	}
+}
+
+// setCapacity menerapkan MaxActive dan MaxWaiters pada semaphore pool. Kapasitas 0 melepas
+// pembatas; peminjaman yang sedang berjalan tetap mengembalikan unitnya ke semaphore asalnya.
+func (s *poolState) setCapacity(maxActive int64, maxWaiters int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if maxActive <= 0 {
+		s.semaphore.Store(nil)
+		return
+	}
+	if sem := s.semaphore.Load(); sem != nil {
+		sem.Resize(maxActive, maxWaiters)
+		return
+	}
+	sem := newWeightedSemaphore(maxActive)
+	sem.maxWaiters = maxWaiters
+	s.semaphore.Store(sem)
+}
+
+// startAutoTune menjalankan goroutine auto-tuning untuk pool jika belum berjalan.
+// Pemanggilan berulang tidak menjalankan goroutine tambahan.
+func (pm *PoolManager) startAutoTune(poolName string, config PoolConfiguration) {
+	if config.AutoTuneInterval <= 0 {
+		pm.logPoolf(WarningLevel, poolName, "Invalid AutoTuneInterval, auto-tuning not started for pool: %s", poolName)
+		return
+	}
+
+	state := pm.poolStateFor(poolName)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if state.autoTuneCancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(state.ctx)
+	state.autoTuneCancel = cancel
+	state.autoTuneRunning.Store(true)
+	go pm.autoTune(ctx, poolName, config)
+}
+
+// stopAutoTune menghentikan goroutine auto-tuning milik pool jika sedang berjalan.
+// Mengembalikan true jika ada goroutine yang dihentikan.
+func (state *poolState) stopAutoTune() bool {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if state.autoTuneCancel == nil {
+		return false
+	}
+	state.autoTuneCancel()
+	state.autoTuneCancel = nil
+	state.autoTuneRunning.Store(false)
+	return true
+}
+
+// markAutoTuned mencatat waktu auto-tuning terakhir untuk pool tertentu.
+func (pm *PoolManager) markAutoTuned(poolName string) {
+	pm.poolStateFor(poolName).lastAutoTune.Store(time.Now().UnixNano())
+}
+
+// markEvicted mencatat waktu eviksi terakhir untuk pool tertentu.
+func (pm *PoolManager) markEvicted(poolName string) {
+	pm.poolStateFor(poolName).lastEviction.Store(time.Now().UnixNano())
+}
+
+// unixNanoToTime mengonversi UnixNano menjadi time.Time, dengan 0 dipetakan ke waktu nol.
+func unixNanoToTime(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// startEviction menjalankan goroutine eviksi untuk pool dengan interval tertentu.
+// Goroutine eviksi yang sudah berjalan dihentikan terlebih dahulu sehingga perubahan
+// interval atau kebijakan langsung berlaku tanpa meninggalkan goroutine lama.
+func (pm *PoolManager) startEviction(poolName string, interval time.Duration) {
+	if interval <= 0 {
+		pm.logPoolf(WarningLevel, poolName, "Invalid EvictionInterval, eviction not started for pool: %s", poolName)
+		return
+	}
+
+	state := pm.poolStateFor(poolName)
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if state.evictionCancel != nil {
+		state.evictionCancel()
+	}
+	ctx, cancel := context.WithCancel(state.ctx)
+	state.evictionCancel = cancel
+	state.evictionRunning.Store(true)
+	go pm.runEviction(ctx, poolName, interval)
+}
+
+// stopEviction menghentikan goroutine eviksi milik pool jika sedang berjalan.
+// Mengembalikan true jika ada goroutine yang dihentikan.
+func (state *poolState) stopEviction() bool {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	if state.evictionCancel == nil {
+		return false
+	}
+	state.evictionCancel()
+	state.evictionCancel = nil
+	state.evictionRunning.Store(false)
+	return true
+}
+
+// StopEviction menghentikan goroutine eviksi untuk satu pool.
+func (pm *PoolManager) StopEviction(poolName string) {
+	if stateVal, ok := pm.poolStates.Load(poolName); ok && stateVal.(*poolState).stopEviction() {
+		pm.logPoolf(InfoLevel, poolName, "Eviction stopped for pool: %s", poolName)
+	}
+}
@@ -0,0 +1,77 @@
+package poolmanager
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// StatsDSink mengirim metrik ke server StatsD melalui UDP. Label dikirim sebagai tag dengan
+// format DogStatsD (|#key:value,...); server StatsD klasik mengabaikan bagian tag tersebut.
+// Pengiriman bersifat fire-and-forget: paket yang gagal dikirim dibuang.
+type StatsDSink struct {
+	mu     sync.Mutex // Melindungi penulisan ke conn
+	conn   net.Conn
+	prefix string // Prefiks nama metrik, misalnya "myapp." (boleh kosong)
+}
+
+// NewStatsDSink membuat StatsDSink yang mengirim metrik ke addr (misalnya "127.0.0.1:8125").
+func NewStatsDSink(addr, prefix string) (*StatsDSink, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &StatsDSink{conn: conn, prefix: prefix}, nil
+}
+
+// AddCounter mengirim counter (|c)
+func (s *StatsDSink) AddCounter(name string, labels map[string]string, delta int64) {
+	s.send(name, strconv.FormatInt(delta, 10), "c", labels)
+}
+
+// SetGauge mengirim gauge (|g)
+func (s *StatsDSink) SetGauge(name string, labels map[string]string, value float64) {
+	s.send(name, strconv.FormatFloat(value, 'f', -1, 64), "g", labels)
+}
+
+// ObserveHistogram mengirim observasi histogram (|h)
+func (s *StatsDSink) ObserveHistogram(name string, labels map[string]string, value float64) {
+	s.send(name, strconv.FormatFloat(value, 'f', -1, 64), "h", labels)
+}
+
+// Close menutup koneksi UDP
+func (s *StatsDSink) Close() error {
+	return s.conn.Close()
+}
+
+// send menulis satu baris StatsD: <prefix><name>:<value>|<type>[|#tag:value,...]
+func (s *StatsDSink) send(name, value, kind string, labels map[string]string) {
+	var line strings.Builder
+	line.WriteString(s.prefix + statsdSanitize(name) + ":" + value + "|" + kind)
+	if len(labels) > 0 {
+		keys := make([]string, 0, len(labels))
+		for key := range labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for i, key := range keys {
+			if i == 0 {
+				line.WriteString("|#")
+			} else {
+				line.WriteString(",")
+			}
+			line.WriteString(statsdSanitize(key) + ":" + statsdSanitize(labels[key]))
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.conn.Write([]byte(line.String()))
+}
+
+// statsdSanitize mengganti karakter yang memiliki arti khusus pada protokol StatsD
+func statsdSanitize(value string) string {
+	return strings.NewReplacer(":", "_", "|", "_", ",", "_", "#", "_", "\n", "_").Replace(value)
+}
@@ -0,0 +1,91 @@
+package poolmanager
+
+import "time"
+
+// ReleaseInstanceWithTTL mengembalikan instance ke pool dan menetapkan ExpirationTime item
+// menjadi sekarang ditambah ttl, sehingga objek tertentu dapat diberi umur lebih pendek dari
+// default pool (misalnya objek yang diketahui hampir kedaluwarsa). Item yang sudah kedaluwarsa
+// dihancurkan saat berikutnya diambil dari pool. ttl <= 0 menghapus ExpirationTime item.
+func (pm *PoolManager) ReleaseInstanceWithTTL(poolName string, instance PoolAble, ttl time.Duration) error {
+	if instance != nil {
+		key := metadataKey(poolName, pm.originalInstance(poolName, instance))
+		pm.safelyUpdateMetadata(key, func(metadata *PoolItemMetadata) {
+			metadata.PoolName = poolName
+			if ttl <= 0 {
+				metadata.ExpirationTime = nil
+				return
+			}
+			expiration := time.Now().Add(ttl)
+			metadata.ExpirationTime = &expiration
+		})
+	}
+	return pm.ReleaseInstance(poolName, instance)
+}
+
+// expiredAt mengembalikan ExpirationTime instance jika sudah lewat. Instance yang berbagi
+// metadata dengan nama pool-nya tidak pernah dianggap kedaluwarsa.
+func (pm *PoolManager) expiredAt(poolName string, instance PoolAble) (time.Time, bool) {
+	key := metadataKey(poolName, instance)
+	metadataVal, ok := pm.itemMetadata.Load(key)
+	if !ok || key == poolName {
+		return time.Time{}, false
+	}
+	metadata := metadataVal.(*PoolItemMetadata)
+	if metadata.ExpirationTime == nil || time.Now().Before(*metadata.ExpirationTime) {
+		return time.Time{}, false
+	}
+	return *metadata.ExpirationTime, true
+}
+
+// discardExpired menghancurkan instance yang baru diambil dari pool jika ExpirationTime-nya
+// sudah lewat. Mengembalikan true jika instance dihancurkan dan pemanggil harus mencari penggantinya.
+func (pm *PoolManager) discardExpired(poolName string, conf PoolConfiguration, instance PoolAble) bool {
+	expiredAt, expired := pm.expiredAt(poolName, instance)
+	if !expired {
+		return false
+	}
+
+	key, lease := metadataKey(poolName, instance), pm.lastLeaseID(poolName, instance)
+	pm.recordMetric(poolName, "evict")
+	if err := pm.destroyInstance(poolName, conf, instance); err != nil {
+		pm.handleError(poolName, err)
+	}
+	pm.itemMetadata.Delete(key)
+	pm.triggerCallback(conf.OnEvict, poolName)
+	pm.tracef("acquire pool=%s key=%s lease=%s action=evict reason=expired at %s", poolName, key, lease, expiredAt.Format(time.RFC3339Nano))
+	return true
+}
+
+// takeStale menahan instance kedaluwarsa alih-alih langsung menghancurkannya jika ServeStale
+// diaktifkan, agar instance tersebut dapat diserahkan bila pembuatan pengganti gagal.
+// Mengembalikan instance yang ditahan, atau nil jika instance belum kedaluwarsa.
+func (pm *PoolManager) takeStale(poolName string, conf PoolConfiguration, instance PoolAble) PoolAble {
+	if !conf.ServeStale {
+		return nil
+	}
+	if _, expired := pm.expiredAt(poolName, instance); !expired {
+		return nil
+	}
+	return instance
+}
+
+// serveStale mencatat bahwa instance kedaluwarsa diserahkan dalam mode degraded karena
+// pembuatan instance pengganti gagal.
+func (pm *PoolManager) serveStale(poolName string, stale PoolAble, cause error) {
+	pm.recordMetric(poolName, "stale")
+	pm.logPoolf(WarningLevel, poolName, "Serving stale instance from pool: %s, replacement creation failed: %v", poolName, cause)
+	pm.tracef("acquire pool=%s key=%s action=serve_stale reason=%v", poolName, metadataKey(poolName, stale), cause)
+}
+
+// IsDegraded melaporkan apakah instance yang sedang dipinjam diserahkan dalam mode degraded,
+// yaitu objek kedaluwarsa yang diserahkan karena pembuatan instance baru gagal (lihat ServeStale).
+func (pm *PoolManager) IsDegraded(poolName string, instance PoolAble) bool {
+	if instance == nil {
+		return false
+	}
+	metadataVal, ok := pm.itemMetadata.Load(metadataKey(poolName, pm.originalInstance(poolName, instance)))
+	if !ok {
+		return false
+	}
+	return metadataVal.(*PoolItemMetadata).Degraded
+}
@@ -0,0 +1,38 @@
+package poolmanager
+
+// Validatable adalah interface opsional yang dapat diimplementasikan oleh objek pool untuk
+// memeriksa apakah objek idle masih dapat dipakai, misalnya koneksi yang sudah ditutup oleh server.
+type Validatable interface {
+	// Validate mengembalikan error jika objek tidak lagi dapat dipakai.
+	Validate() error
+}
+
+// discardInvalid memvalidasi instance idle yang baru diambil dari pool jika instance
+// mengimplementasikan Validatable. Instance yang gagal divalidasi dihancurkan melalui OnDestroy dan
+// Close. Mengembalikan true jika instance dihancurkan dan pemanggil harus mencari penggantinya.
+func (pm *PoolManager) discardInvalid(poolName string, conf PoolConfiguration, instance PoolAble) bool {
+	validatable, ok := instance.(Validatable)
+	if !ok {
+		return false
+	}
+	validateErr := validatable.Validate()
+	if validateErr == nil {
+		return false
+	}
+
+	key, lease := metadataKey(poolName, instance), pm.lastLeaseID(poolName, instance)
+	pm.recordMetric(poolName, "invalid")
+	// Instance yang dihancurkan tidak boleh lagi diserahkan dari cache
+	if cached, ok := pm.cache.Load(poolName); ok && key != poolName && instanceKey(poolName, cached) == key {
+		pm.cache.Delete(poolName)
+	}
+	if err := pm.destroyInstance(poolName, conf, instance); err != nil {
+		pm.handleError(poolName, err)
+	}
+	if key != poolName {
+		pm.itemMetadata.Delete(key)
+	}
+	pm.logPoolf(InfoLevel, poolName, "Discarded invalid instance from pool: %s, Lease: %s, Error: %v", poolName, lease, validateErr)
+	pm.tracef("acquire pool=%s key=%s lease=%s action=discard reason=validation failed: %v", poolName, key, lease, validateErr)
+	return true
+}
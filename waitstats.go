@@ -0,0 +1,144 @@
+package poolmanager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// WaitStats merangkum waktu tunggu acquire untuk satu kelas prioritas.
+// Hanya acquire yang harus mengantre karena MaxActive habis yang dicatat.
+type WaitStats struct {
+	Count   int64         // Jumlah acquire yang mengantre
+	Total   time.Duration // Total waktu tunggu
+	Max     time.Duration // Waktu tunggu terlama
+	Starved int64         // Jumlah waiter yang menunggu melebihi StarvationThreshold
+}
+
+// Mean mengembalikan rata-rata waktu tunggu
+func (w WaitStats) Mean() time.Duration {
+	if w.Count == 0 {
+		return 0
+	}
+	return w.Total / time.Duration(w.Count)
+}
+
+// waitRecorder mengumpulkan WaitStats per kelas prioritas untuk satu pool
+type waitRecorder struct {
+	mu    sync.Mutex
+	stats map[int]WaitStats
+}
+
+// observe mencatat satu waktu tunggu untuk kelas prioritas tertentu
+func (r *waitRecorder) observe(priority int, waited time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stats == nil {
+		r.stats = make(map[int]WaitStats)
+	}
+	stats := r.stats[priority]
+	stats.Count++
+	stats.Total += waited
+	if waited > stats.Max {
+		stats.Max = waited
+	}
+	r.stats[priority] = stats
+}
+
+// starved menambah hitungan waiter yang kelaparan untuk kelas prioritas tertentu
+func (r *waitRecorder) starved(priority int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stats == nil {
+		r.stats = make(map[int]WaitStats)
+	}
+	stats := r.stats[priority]
+	stats.Starved++
+	r.stats[priority] = stats
+}
+
+// snapshot mengembalikan salinan statistik per prioritas
+func (r *waitRecorder) snapshot() map[int]WaitStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stats := make(map[int]WaitStats, len(r.stats))
+	for priority, s := range r.stats {
+		stats[priority] = s
+	}
+	return stats
+}
+
+// waitRecorderFor mengambil pencatat waktu tunggu pool, membuatnya jika belum ada
+func (pm *PoolManager) waitRecorderFor(poolName string) *waitRecorder {
+	recorderVal, _ := pm.waits.LoadOrStore(poolName, &waitRecorder{})
+	return recorderVal.(*waitRecorder)
+}
+
+// acquireUnits mengambil unit semaphore untuk sebuah acquire. Jika unit tidak langsung tersedia,
+// waktu tunggu dicatat per kelas prioritas dan peringatan kelaparan dikirim saat waiter
+// menunggu lebih lama dari StarvationThreshold.
+func (pm *PoolManager) acquireUnits(poolName string, conf PoolConfiguration, sem *weightedSemaphore, poolCtx context.Context, o acquireOptions) error {
+	if sem.TryAcquire(o.weight) {
+		return nil
+	}
+	if o.tryOnly {
+		return errPoolExhausted
+	}
+
+	start := time.Now()
+	if conf.StarvationThreshold > 0 {
+		timer := time.AfterFunc(conf.StarvationThreshold, func() {
+			pm.reportStarvation(poolName, o.priority, conf.StarvationThreshold)
+		})
+		defer timer.Stop()
+	}
+	if conf.SaturationAdvisoryAfter > 0 {
+		timer := time.AfterFunc(conf.SaturationAdvisoryAfter, func() {
+			pm.checkSaturation(poolName, conf, sem)
+		})
+		defer timer.Stop()
+	}
+
+	metrics := pm.metricsFor(poolName)
+	pm.emitGauge(poolName, SinkWaiters, float64(atomic.AddInt64(&metrics.Waiters, 1)))
+	// Tunggu dibatalkan oleh context pemanggil maupun oleh penghapusan pool
+	waitCtx, cancel := mergeWaitContext(poolCtx, o.ctx, conf.WaitTimeout)
+	defer cancel()
+	endRegion := startTraceRegion(waitCtx, poolName, TraceRegionAcquireWait)
+	err := sem.Acquire(waitCtx, o.weight, o.priority)
+	if err != nil {
+		err = waitError(waitCtx, err)
+	}
+	endRegion()
+	pm.emitGauge(poolName, SinkWaiters, float64(atomic.AddInt64(&metrics.Waiters, -1)))
+	if !errors.Is(err, ErrWeightExceedsCapacity) && !errors.Is(err, ErrTooManyWaiters) {
+		waited := time.Since(start)
+		pm.waitRecorderFor(poolName).observe(o.priority, waited)
+		pm.observeWaitCapacity(poolName, waited)
+		pm.emitDuration(poolName, SinkAcquireWait, waited)
+	}
+	return err
+}
+
+// reportStarvation mencatat waiter yang menunggu melebihi ambang batas dan memicu EventStarvation
+func (pm *PoolManager) reportStarvation(poolName string, priority int, threshold time.Duration) {
+	pm.waitRecorderFor(poolName).starved(priority)
+	pm.logPoolf(WarningLevel, poolName, "Starvation detected in pool: %s, waiter with priority %d has waited longer than %s",
+		poolName, priority, threshold)
+	pm.triggerEvent(PoolEvent{Type: EventStarvation, PoolName: poolName})
+}
+
+// GetWaitStats mengembalikan statistik waktu tunggu acquire per kelas prioritas untuk pool tertentu.
+// Mengembalikan map kosong jika belum ada acquire yang mengantre.
+func (pm *PoolManager) GetWaitStats(poolName string) (map[int]WaitStats, error) {
+	if !pm.HasPool(poolName) {
+		return nil, errors.New(ErrPoolDoesNotExist + poolName)
+	}
+	recorderVal, ok := pm.waits.Load(poolName)
+	if !ok {
+		return map[int]WaitStats{}, nil
+	}
+	return recorderVal.(*waitRecorder).snapshot(), nil
+}
@@ -0,0 +1,12 @@
+module github.com/hibbannn/pool-manager/zapadapter
+
+go 1.24.0
+
+require (
+	github.com/hibbannn/pool-manager v0.0.0
+	go.uber.org/zap v1.27.0
+)
+
+require go.uber.org/multierr v1.10.0 // indirect
+
+replace github.com/hibbannn/pool-manager => ../
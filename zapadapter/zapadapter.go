@@ -0,0 +1,45 @@
+// Package zapadapter menghubungkan log terstruktur poolmanager ke go.uber.org/zap.
+//
+//	pm.SetLogger(zapadapter.New(zapLogger))
+package zapadapter
+
+import (
+	poolmanager "github.com/hibbannn/pool-manager"
+	"go.uber.org/zap"
+)
+
+// logger meneruskan pesan poolmanager ke zap.Logger
+type logger struct {
+	zap *zap.Logger
+}
+
+// New membuat poolmanager.Logger yang menulis ke zap.Logger. Field poolmanager dikirim sebagai
+// zap.String dan level dipetakan ke level zap yang setara.
+func New(zapLogger *zap.Logger) poolmanager.Logger {
+	return &logger{zap: zapLogger}
+}
+
+func (l *logger) Debug(message string, fields ...poolmanager.LogField) {
+	l.zap.Debug(message, zapFields(fields)...)
+}
+
+func (l *logger) Info(message string, fields ...poolmanager.LogField) {
+	l.zap.Info(message, zapFields(fields)...)
+}
+
+func (l *logger) Warn(message string, fields ...poolmanager.LogField) {
+	l.zap.Warn(message, zapFields(fields)...)
+}
+
+func (l *logger) Error(message string, fields ...poolmanager.LogField) {
+	l.zap.Error(message, zapFields(fields)...)
+}
+
+// zapFields mengubah field poolmanager menjadi zap.String
+func zapFields(fields []poolmanager.LogField) []zap.Field {
+	zapFields := make([]zap.Field, len(fields))
+	for i, field := range fields {
+		zapFields[i] = zap.String(field.Key, field.Value)
+	}
+	return zapFields
+}
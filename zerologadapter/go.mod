@@ -0,0 +1,16 @@
+module github.com/hibbannn/pool-manager/zerologadapter
+
+go 1.24.0
+
+require (
+	github.com/hibbannn/pool-manager v0.0.0
+	github.com/rs/zerolog v1.33.0
+)
+
+require (
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	golang.org/x/sys v0.12.0 // indirect
+)
+
+replace github.com/hibbannn/pool-manager => ../
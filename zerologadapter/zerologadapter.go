@@ -0,0 +1,44 @@
+// Package zerologadapter menghubungkan log terstruktur poolmanager ke github.com/rs/zerolog.
+//
+//	pm.SetLogger(zerologadapter.New(zerolog.New(os.Stderr)))
+package zerologadapter
+
+import (
+	poolmanager "github.com/hibbannn/pool-manager"
+	"github.com/rs/zerolog"
+)
+
+// logger meneruskan pesan poolmanager ke zerolog.Logger
+type logger struct {
+	zerolog zerolog.Logger
+}
+
+// New membuat poolmanager.Logger yang menulis ke zerolog.Logger. Field poolmanager dikirim
+// sebagai field string dan level dipetakan ke level zerolog yang setara.
+func New(zerologLogger zerolog.Logger) poolmanager.Logger {
+	return &logger{zerolog: zerologLogger}
+}
+
+func (l *logger) Debug(message string, fields ...poolmanager.LogField) {
+	send(l.zerolog.Debug(), message, fields)
+}
+
+func (l *logger) Info(message string, fields ...poolmanager.LogField) {
+	send(l.zerolog.Info(), message, fields)
+}
+
+func (l *logger) Warn(message string, fields ...poolmanager.LogField) {
+	send(l.zerolog.Warn(), message, fields)
+}
+
+func (l *logger) Error(message string, fields ...poolmanager.LogField) {
+	send(l.zerolog.Error(), message, fields)
+}
+
+// send menambahkan field poolmanager sebagai field string lalu mengirim event
+func send(event *zerolog.Event, message string, fields []poolmanager.LogField) {
+	for _, field := range fields {
+		event = event.Str(field.Key, field.Value)
+	}
+	event.Msg(message)
+}